@@ -1,7 +1,12 @@
 package algorithms
 
 import (
+	"fmt"
 	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -36,12 +41,114 @@ func TestIndel(t *testing.T) {
 
 	for _, currentCase := range distanceCases {
 		result := IndelDistance(currentCase.inputString, currentCase.targetString)
+		recursiveResult := RecursiveIndelDistance(currentCase.inputString, currentCase.targetString)
+
+		if recursiveResult != currentCase.expectedDistance {
+			t.Errorf("Error in RecursiveIndelDistance('%s', '%s'), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.expectedDistance, recursiveResult)
+		}
 
 		if result != currentCase.expectedDistance {
 			t.Errorf("Error in IndelDistance('%s', '%s'), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.expectedDistance, result)
 		}
 	}
 
+	// The dynamic version counts runes instead of bytes
+	if distance := IndelDistance("café", "cafe"); distance != 2 {
+		t.Errorf("Error in IndelDistance('café', 'cafe'), expected 2 got %d", distance)
+	}
+
+	// Both versions agree on ASCII strings
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcd")
+	for range 200 {
+		inputString := randomString(generator, alphabet, 8)
+		targetString := randomString(generator, alphabet, 8)
+		if result, recursiveResult := DynamicIndelDistance(inputString, targetString), RecursiveIndelDistance(inputString, targetString); result != recursiveResult {
+			t.Errorf("Error in DynamicIndelDistance('%s', '%s'), (Recursive) %d != %d (Dynamic)", inputString, targetString, recursiveResult, result)
+		}
+	}
+
+	// Long strings with little in common would never finish recursively, and the distance is m + n - 2*LCS in runes
+	alphabet = []rune("abcdefghijklmnopqrstuvwxyzéü日本")
+	for range 20 {
+		inputString := randomString(generator, alphabet, 200)
+		targetString := randomString(generator, alphabet, 200)
+		expected := len([]rune(inputString)) + len([]rune(targetString)) - 2*LCSLength(inputString, targetString)
+		if result := IndelDistance(inputString, targetString); result != expected {
+			t.Errorf("Error in IndelDistance() with %d and %d runes, expected %d got %d", len([]rune(inputString)), len([]rune(targetString)), expected, result)
+		}
+	}
+}
+
+func BenchmarkIndel(b *testing.B) {
+	// Random 50 rune strings take far too long to compare recursively, so the target only has a few runes near the end changed
+	generator := rand.New(rand.NewSource(42))
+	inputRunes := make([]rune, 50)
+	for i := range inputRunes {
+		inputRunes[i] = rune('a' + generator.Intn(26))
+	}
+	targetRunes := slices.Clone(inputRunes)
+	targetRunes[40], targetRunes[45] = 'A', 'B'
+	inputString, targetString := string(inputRunes), string(targetRunes)
+
+	b.Run("RecursiveIndelDistance", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			RecursiveIndelDistance(inputString, targetString)
+		}
+	})
+	b.Run("DynamicIndelDistance", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			DynamicIndelDistance(inputString, targetString)
+		}
+	})
+
+	// 200 random runes each, which the recursive version can't compare in any reasonable time
+	longInputRunes := make([]rune, 200)
+	longTargetRunes := make([]rune, 200)
+	for i := range longInputRunes {
+		longInputRunes[i] = rune('a' + generator.Intn(26))
+		longTargetRunes[i] = rune('a' + generator.Intn(26))
+	}
+	inputString, targetString = string(longInputRunes), string(longTargetRunes)
+	b.Run("DynamicIndelDistance200", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			DynamicIndelDistance(inputString, targetString)
+		}
+	})
+}
+
+func BenchmarkDamerauLevenshtein(b *testing.B) {
+	// 50 random runes each, the recursive version's cache keys make anything much longer impractical
+	generator := rand.New(rand.NewSource(42))
+	inputRunes := make([]rune, 50)
+	targetRunes := make([]rune, 50)
+	for i := range inputRunes {
+		inputRunes[i] = rune('a' + generator.Intn(26))
+		targetRunes[i] = rune('a' + generator.Intn(26))
+	}
+	inputString, targetString := string(inputRunes), string(targetRunes)
+
+	b.Run("RecursiveDamerauLevenshtein", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			RecursiveDamerauLevenshtein(inputString, targetString)
+		}
+	})
+	b.Run("DamerauLevenshtein", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			DamerauLevenshtein(inputString, targetString)
+		}
+	})
+	b.Run("DamerauLevenshteinDP", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			DamerauLevenshteinDP(inputString, targetString)
+		}
+	})
 }
 
 func TestLevenshtein(t *testing.T) {
@@ -103,6 +210,81 @@ func TestLevenshtein(t *testing.T) {
 		}
 	}
 
+	// The deprecated recursive version gives the same results on ASCII
+	for _, currentCase := range damerauDistanceCases {
+		result := RecursiveDamerauLevenshtein(currentCase.inputString, currentCase.targetString)
+
+		if result != currentCase.expectedDistance {
+			t.Errorf("Error in RecursiveDamerauLevenshtein('%s', '%s'), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.expectedDistance, result)
+		}
+	}
+	recursiveGenerator := rand.New(rand.NewSource(304))
+	for _, alphabet := range [][]rune{[]rune("ab"), []rune("abcd"), []rune("abcdefghijklmnopqrstuvwxyz")} {
+		for range 1000 {
+			inputString := randomString(recursiveGenerator, alphabet, 10)
+			targetString := randomString(recursiveGenerator, alphabet, 10)
+			expected := RecursiveDamerauLevenshtein(inputString, targetString)
+			if result := DamerauLevenshtein(inputString, targetString); result != expected {
+				t.Errorf("Error in DamerauLevenshtein('%s', '%s'), expected %d got %d", inputString, targetString, expected, result)
+			}
+		}
+	}
+
+	// Runes count as one edit, and long strings don't need a cache entry for every pair of suffixes
+	if result := DamerauLevenshtein("héllo", "hlélo"); result != 1 {
+		t.Errorf("Error in DamerauLevenshtein('héllo', 'hlélo'), expected 1 got %d", result)
+	}
+	if result := DamerauLevenshtein(strings.Repeat("ab", 2000), strings.Repeat("ba", 2000)); result != 2 {
+		t.Errorf("Error in DamerauLevenshtein() with 4000 rune strings, expected 2 got %d", result)
+	}
+
+	// Different than optimal string alignment
+	damerauDistanceCases = append(damerauDistanceCases, distanceTestCase{"ca", "abc", 2})
+
+	for _, currentCase := range damerauDistanceCases {
+		result := DamerauLevenshteinDP(currentCase.inputString, currentCase.targetString)
+
+		if result != currentCase.expectedDistance {
+			t.Errorf("Error in DamerauLevenshteinDP('%s', '%s'), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.expectedDistance, result)
+		}
+	}
+
+	optimalStringAlignmentCases := []distanceTestCase{
+		{"", "", 0},
+		{"a", "", 1},
+		{"", "alumni", 6},
+		{"alumni", "alumni", 0},
+		{"almni", "alumni", 1},
+		{"abcd", "abdc", 1},
+		{"convesre", "converse", 1},
+		{"franklin", "alumni", 6},
+		{"héllo", "hlélo", 1},
+		// Different than unrestricted Damerau–Levenshtein, "ac" can't have "b" added between it after being transposed
+		{"ca", "abc", 3},
+	}
+
+	for _, currentCase := range optimalStringAlignmentCases {
+		result := OptimalStringAlignmentDistance(currentCase.inputString, currentCase.targetString)
+
+		if result != currentCase.expectedDistance {
+			t.Errorf("Error in OptimalStringAlignmentDistance('%s', '%s'), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.expectedDistance, result)
+		}
+	}
+
+	// Optimal string alignment is between Damerau–Levenshtein and Levenshtein
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcd")
+	for range 1000 {
+		inputString := randomString(generator, alphabet, 8)
+		targetString := randomString(generator, alphabet, 8)
+		damerau := DamerauLevenshteinDP(inputString, targetString)
+		optimal := OptimalStringAlignmentDistance(inputString, targetString)
+		levenshtein := LevenshteinDistance(inputString, targetString)
+		if damerau > optimal || optimal > levenshtein {
+			t.Errorf("Error in OptimalStringAlignmentDistance('%s', '%s'), expected %d <= %d <= %d", inputString, targetString, damerau, optimal, levenshtein)
+		}
+	}
+
 	// Similarity
 	type similarityTestCase struct {
 		inputString        string
@@ -158,3 +340,4446 @@ func TestJaro(t *testing.T) {
 		}
 	}
 }
+
+// Generates a random string of up to maxLength characters from alphabet
+func randomString(generator *rand.Rand, alphabet []rune, maxLength int) string {
+	result := make([]rune, generator.Intn(maxLength+1))
+	for i := range result {
+		result[i] = alphabet[generator.Intn(len(alphabet))]
+	}
+	return string(result)
+}
+
+func TestQuickRatio(t *testing.T) {
+	type testCase struct {
+		inputString       string
+		targetString      string
+		expectedQuick     float64
+		expectedRealQuick float64
+	}
+
+	// Validated with python's difflib.SequenceMatcher
+	cases := []testCase{
+		{"", "", 1, 1},
+		{"a", "", 0, 0},
+		{"abcd", "bcde", 0.75, 1},
+		{"alumni", "alumni", 1, 1},
+		{"almni", "alumni", 0.909, 0.909},
+		{"inula", "alumni", 0.909, 0.909},
+		{"franklin", "alumni", 0.571, 0.857},
+	}
+
+	for _, currentCase := range cases {
+		quick := QuickRatio(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(quick), currentCase.expectedQuick, 3) {
+			t.Errorf("Error in QuickRatio('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedQuick, quick)
+		}
+		realQuick := RealQuickRatio(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(realQuick), currentCase.expectedRealQuick, 3) {
+			t.Errorf("Error in RealQuickRatio('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedRealQuick, realQuick)
+		}
+	}
+
+	// The bounds must never underestimate the ratio they bound, IndelSimilarity is used since
+	// it's the optimal alignment ratio, and is never lower than the Ratcliff/Obershelp ratio
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcde")
+	for range 2000 {
+		inputString := randomString(generator, alphabet, 8)
+		targetString := randomString(generator, alphabet, 8)
+
+		similarity := IndelSimilarity(inputString, targetString)
+		quick := QuickRatio(inputString, targetString)
+		realQuick := RealQuickRatio(inputString, targetString)
+
+		if quick+1e-6 < similarity {
+			t.Errorf("QuickRatio('%s', '%s') underestimated the similarity %.3f < %.3f", inputString, targetString, quick, similarity)
+		}
+		if realQuick+1e-6 < quick {
+			t.Errorf("RealQuickRatio('%s', '%s') underestimated QuickRatio %.3f < %.3f", inputString, targetString, realQuick, quick)
+		}
+		if ratio := RatcliffObershelpSimilarity(inputString, targetString); ratio > similarity+1e-6 {
+			t.Errorf("RatcliffObershelpSimilarity('%s', '%s') overestimated the similarity %.3f > %.3f", inputString, targetString, ratio, similarity)
+		}
+	}
+}
+
+func TestRatcliffObershelp(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+	}
+
+	// Validated with python's difflib.SequenceMatcher(None, inputString, targetString).ratio()
+	cases := []testCase{
+		{"", "", 1},
+		{"a", "", 0},
+		{"alumni", "alumni", 1},
+		{"alumni", "almni", 0.909},
+		{"abcd", "bcde", 0.75},
+		{"franklin", "alumni", 0.429},
+		{"New York", "New York City", 0.762},
+		{"héllo", "hello", 0.8},
+		{"GESTALT PATTERN MATCHING", "GESTALT PRACTICE", 0.6},
+		{"GESTALT PRACTICE", "GESTALT PATTERN MATCHING", 0.65},
+		{"tide", "diet", 0.25},
+		{"diet", "tide", 0.5},
+		{"The quick brown fox jumps over the lazy dog while the cat watches from the windowsill of the old barn", "A quick brown dog jumped over the lazy fox as the cat watched from a windowsill in the old red barn", 0.82},
+	}
+
+	for _, currentCase := range cases {
+		result := RatcliffObershelpSimilarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in RatcliffObershelpSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+	}
+}
+
+func TestSuggestWordWithUpperBound(t *testing.T) {
+	validWords := []string{"hi", "hello", "bonjour", "alumni", "alumnus", "franklin"}
+
+	for _, word := range []string{"alumni", "almni", "helo", "bonjur", "zzz", ""} {
+		expected := SuggestWord(word, validWords, IndelSimilarity)
+		for _, bound := range []SimilarityAlgorithm{QuickRatio, RealQuickRatio} {
+			result := SuggestWordWithUpperBound(word, validWords, IndelSimilarity, bound)
+			if result != expected {
+				t.Errorf("SuggestWordWithUpperBound(%s) differed from SuggestWord: %v != %v", word, result, expected)
+			}
+		}
+	}
+}
+
+func TestStripHTML(t *testing.T) {
+	type testCase struct {
+		input    string
+		expected string
+	}
+
+	cases := []testCase{
+		{"", ""},
+		{"plain text", "plain text"},
+		{"<b>bold</b> text", "bold text"},
+		{"<p>first</p><p>second</p>", "first second"},
+		{"line<br/>break", "line break"},
+		{"line<br />break", "line break"},
+		{`<a href="https://example.com/?a=1&amp;b=2" title="a > b">link</a>`, "link"},
+		{"fish &amp; chips &lt;3 &#39;quoted&#x27; &nbsp;end", "fish & chips <3 'quoted' end"},
+		{"&notanentity; &amp", "&notanentity; &amp"},
+		{"<!-- hidden -->shown<!DOCTYPE html>", "shown"},
+		{"<script>var a = '<b>';</script>visible<style>p { color: red; }</style>", "visible"},
+		{"  lots \n\t of   <i>space</i>  ", "lots of space"},
+		{"<b><i>nested</i> emphasis</b>", "nested emphasis"},
+		// Malformed markup is kept as text
+		{"a < b", "a < b"},
+		{"1 <2 and 3> 2", "1 <2 and 3> 2"},
+		{"unclosed <b tag", "unclosed <b tag"},
+		{"<b>unclosed bold", "unclosed bold"},
+		{"<!-- unterminated comment", ""},
+	}
+
+	for _, currentCase := range cases {
+		result := StripHTML(currentCase.input)
+		if result != currentCase.expected {
+			t.Errorf("Error in StripHTML(%q), expected %q got %q", currentCase.input, currentCase.expected, result)
+		}
+	}
+
+	// Offsets should map back to where the text came from
+	input := "<b>fish</b> &amp;  chips"
+	result, offsets := StripHTMLWithOffsets(input)
+	if result != "fish & chips" {
+		t.Fatalf("Error in StripHTMLWithOffsets(%q), got %q", input, result)
+	}
+	expectedOffsets := []int{3, 4, 5, 6, 11, 12, 17, 19, 20, 21, 22, 23}
+	if len(offsets) != len(expectedOffsets) {
+		t.Fatalf("Error in StripHTMLWithOffsets(%q), expected offsets %v got %v", input, expectedOffsets, offsets)
+	}
+	for i := range offsets {
+		if offsets[i] != expectedOffsets[i] {
+			t.Errorf("Error in StripHTMLWithOffsets(%q), expected offsets %v got %v", input, expectedOffsets, offsets)
+			break
+		}
+	}
+}
+
+func TestStripMarkdown(t *testing.T) {
+	type testCase struct {
+		input    string
+		expected string
+	}
+
+	cases := []testCase{
+		{"", ""},
+		{"plain text", "plain text"},
+		{"**bold** text", "bold text"},
+		{"__bold__ and _italic_ and *also italic*", "bold and italic and also italic"},
+		{"***nested _emphasis_***", "nested emphasis"},
+		{"~~struck~~ out", "struck out"},
+		{"snake_case_name stays", "snake_case_name stays"},
+		{"2 * 3 = 6", "2 * 3 = 6"},
+		{"2*3 = 6", "2*3 = 6"},
+		{"2*3*4", "234"},
+		{"*foo**bar*", "foo**bar"},
+		{`\*not emphasis\*`, "*not emphasis*"},
+		{"# Heading\nSome text", "Heading Some text"},
+		{"> quoted\n> > twice", "quoted twice"},
+		{"- one\n* two\n1. three", "one two three"},
+		{"above\n\n---\n\nbelow", "above below"},
+		{"[speyl](https://github.com/Descent098/speyl) and ![alt text](image.png)", "speyl and alt text"},
+		{"[**bold link**][ref]", "bold link"},
+		// Code must not be altered
+		{"use `**kwargs` here", "use **kwargs here"},
+		{"``code with ` backtick``", "code with ` backtick"},
+		{"`a  b`", "a  b"},
+		{"```\n**not bold**  _kept_\n```\nafter", "**not bold**  _kept_\n after"},
+		// Malformed markup is kept as text
+		{"[not a link", "[not a link"},
+		{"[text](unclosed", "[text](unclosed"},
+		{"unclosed `code", "unclosed `code"},
+		{"single ~tilde~", "single ~tilde~"},
+		{"**bold", "**bold"},
+		{"**bold* text", "*bold text"},
+		{"not ~~~struck~~", "not ~~~struck~~"},
+	}
+
+	for _, currentCase := range cases {
+		result := StripMarkdown(currentCase.input)
+		if result != currentCase.expected {
+			t.Errorf("Error in StripMarkdown(%q), expected %q got %q", currentCase.input, currentCase.expected, result)
+		}
+	}
+
+	// Offsets should map back to where the text came from
+	input := "**bold** `x`"
+	result, offsets := StripMarkdownWithOffsets(input)
+	if result != "bold x" {
+		t.Fatalf("Error in StripMarkdownWithOffsets(%q), got %q", input, result)
+	}
+	expectedOffsets := []int{2, 3, 4, 5, 8, 10}
+	for i := range expectedOffsets {
+		if i >= len(offsets) || offsets[i] != expectedOffsets[i] {
+			t.Errorf("Error in StripMarkdownWithOffsets(%q), expected offsets %v got %v", input, expectedOffsets, offsets)
+			break
+		}
+	}
+
+	// Rich text should compare as equal to it's plain text
+	if similarity := LevenshteinSimilarity(StripMarkdown("**bold** text"), StripHTML("<b>bold</b> text")); similarity != 1 {
+		t.Errorf("Stripped Markdown and HTML should be identical, got similarity %.3f", similarity)
+	}
+}
+
+func TestJaroWinkler(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+	}
+
+	// Validated with https://en.wikipedia.org/wiki/Jaro%E2%80%93Winkler_distance
+	cases := []testCase{
+		{"alumni", "alumni", 1},
+		{"MARTHA", "MARHTA", 0.961},
+		{"DIXON", "DICKSONX", 0.813},
+		{"DWAYNE", "DUANE", 0.840},
+		{"almni", "alumni", 0.956},
+		{"inula", "alumni", 0.411},
+		{"", "", 1},
+		{"", "alumni", 0},
+		{"a", "", 0},
+	}
+
+	for _, currentCase := range cases {
+		result := JaroWinklerSimilarity(currentCase.inputString, currentCase.targetString)
+
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in JaroWinklerSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+		if result < JaroSimilarity(currentCase.inputString, currentCase.targetString) {
+			t.Errorf("Error in JaroWinklerSimilarity('%s', '%s'), %.3f is lower than the Jaro similarity", currentCase.inputString, currentCase.targetString, result)
+		}
+	}
+
+	// The score must be capped, even with an oversized prefix bonus
+	if result := JaroWinklerSimilarityWithOptions("MARTHA", "MARHTA", 0.5, 4); result != 1 {
+		t.Errorf("Error in JaroWinklerSimilarityWithOptions('MARTHA', 'MARHTA', 0.5, 4), expected 1 got %.3f", result)
+	}
+
+	// Negative options should be treated as 0, so the score stays between 0 and 1
+	jaroResult := JaroSimilarity("abcdxyz", "abcdpqr")
+	if result := JaroWinklerSimilarityWithOptions("abcdxyz", "abcdpqr", -2, 4); result != jaroResult {
+		t.Errorf("Error in JaroWinklerSimilarityWithOptions('abcdxyz', 'abcdpqr', -2, 4), expected %.3f got %.3f", jaroResult, result)
+	}
+	if result := JaroWinklerSimilarityWithOptions("abcdxyz", "abcdpqr", 0.1, -3); result != jaroResult {
+		t.Errorf("Error in JaroWinklerSimilarityWithOptions('abcdxyz', 'abcdpqr', 0.1, -3), expected %.3f got %.3f", jaroResult, result)
+	}
+
+	// The prefix scale should be clamped to [0, 0.25]
+	scaleCases := []struct {
+		prefixScale float32
+		expected    float64
+	}{
+		{-1, 0.944},
+		{0, 0.944},
+		{0.1, 0.961},
+		{0.25, 0.986},
+		{1, 0.986},
+	}
+	for _, currentCase := range scaleCases {
+		result := JaroWinklerSimilarityWithPrefix("MARTHA", "MARHTA", currentCase.prefixScale)
+		if !compareFloat(float64(result), currentCase.expected, 3) {
+			t.Errorf("Error in JaroWinklerSimilarityWithPrefix('MARTHA', 'MARHTA', %.2f), expected %.3f got %.3f", currentCase.prefixScale, currentCase.expected, result)
+		}
+	}
+
+	// The prefix should be counted in runes, so a shared multibyte rune counts once
+	byteResult := JaroWinklerSimilarityWithOptions("éa", "éb", 0.1, 1)
+	expected := JaroSimilarity("éa", "éb") + 0.1*(1-JaroSimilarity("éa", "éb"))
+	if !compareFloat(float64(byteResult), float64(expected), 5) {
+		t.Errorf("Error in JaroWinklerSimilarityWithOptions('éa', 'éb', 0.1, 1), expected %.3f got %.3f", expected, byteResult)
+	}
+
+	// Should be usable as a SimilarityAlgorithm
+	suggestion := SuggestWord("almni", []string{"hi", "hello", "bonjour", "alumni"}, JaroWinklerSimilarity)
+	if suggestion.Word != "alumni" {
+		t.Errorf("Error in SuggestWord('almni') with JaroWinklerSimilarity, expected alumni got %s", suggestion.Word)
+	}
+}
+
+func TestSuggestTopN(t *testing.T) {
+	validWords := []string{"hi", "hello", "bonjour", "alumni", "alumnus", "alum", "xyz"}
+
+	type testCase struct {
+		word          string
+		n             int
+		threshold     float32
+		expectedWords []string
+	}
+
+	cases := []testCase{
+		{"alumni", 3, 0, []string{"alumni", "alum", "alumnus"}},
+		{"alumni", 1, 0, []string{"alumni"}},
+		{"alumni", 0, 0, []string{}},
+		{"alumni", -1, 0, []string{}},
+		// No padding when fewer strings pass the threshold
+		{"alumni", 10, 0, []string{"alumni", "alum", "alumnus", "hello", "bonjour"}},
+		{"alumni", 10, 0.8, []string{"alumni", "alum", "alumnus"}},
+		{"alumni", 10, 1, []string{}},
+	}
+
+	for _, currentCase := range cases {
+		result := SuggestTopNWithThreshold(currentCase.word, validWords, currentCase.n, currentCase.threshold, JaroSimilarity)
+		if len(result) != len(currentCase.expectedWords) {
+			t.Errorf("Error in SuggestTopNWithThreshold('%s', %d, %.2f), expected %v got %v", currentCase.word, currentCase.n, currentCase.threshold, currentCase.expectedWords, result)
+			continue
+		}
+		for i := range result {
+			if result[i].Word != currentCase.expectedWords[i] {
+				t.Errorf("Error in SuggestTopNWithThreshold('%s', %d, %.2f), expected %v got %v", currentCase.word, currentCase.n, currentCase.threshold, currentCase.expectedWords, result)
+				break
+			}
+			if i > 0 && result[i].Likelihood > result[i-1].Likelihood {
+				t.Errorf("Error in SuggestTopNWithThreshold('%s', %d, %.2f), results not sorted %v", currentCase.word, currentCase.n, currentCase.threshold, result)
+			}
+		}
+	}
+
+	// The top suggestion should always match SuggestWord
+	for _, word := range []string{"alumni", "almni", "helo", "bonjur"} {
+		expected := SuggestWord(word, validWords, LevenshteinSimilarity)
+		result := SuggestTopN(word, validWords, 3, LevenshteinSimilarity)
+		if len(result) == 0 || result[0] != expected {
+			t.Errorf("Error in SuggestTopN('%s'), expected %v first got %v", word, expected, result)
+		}
+	}
+
+	// Ties should keep the order of validWords
+	result := SuggestTopN("ab", []string{"ax", "ay", "az"}, 2, LevenshteinSimilarity)
+	if len(result) != 2 || result[0].Word != "ax" || result[1].Word != "ay" {
+		t.Errorf("Error in SuggestTopN('ab'), expected [ax ay] got %v", result)
+	}
+}
+
+func TestFindAllAboveThreshold(t *testing.T) {
+	validWords := []string{"hi", "hello", "bonjour", "alumni", "alumnus", "alum", "xyz"}
+
+	type testCase struct {
+		inputString   string
+		threshold     float32
+		maxResults    int
+		expectedWords []string
+	}
+
+	cases := []testCase{
+		{"alumni", 0.8, -1, []string{"alumni", "alum", "alumnus"}},
+		{"alumni", 0.8, 2, []string{"alumni", "alum"}},
+		{"alumni", 0.8, 0, []string{}},
+		{"alumni", 1, -1, []string{"alumni"}},
+		{"helo", 0.9, -1, []string{"hello"}},
+		{"qqq", 0.5, -1, []string{}},
+		{"qqq", 0, 3, []string{"hi", "hello", "bonjour"}},
+	}
+
+	for _, currentCase := range cases {
+		result := FindAllAboveThresholdWithLimit(currentCase.inputString, validWords, currentCase.threshold, currentCase.maxResults, JaroSimilarity)
+		words := []string{}
+		for i, suggestion := range result {
+			words = append(words, suggestion.Word)
+			if suggestion.Likelihood < currentCase.threshold || (i > 0 && suggestion.Likelihood > result[i-1].Likelihood) {
+				t.Errorf("Error in FindAllAboveThresholdWithLimit('%s', %.2f, %d), suggestions should be over the threshold and sorted, got %v", currentCase.inputString, currentCase.threshold, currentCase.maxResults, result)
+			}
+		}
+		if !slices.Equal(words, currentCase.expectedWords) {
+			t.Errorf("Error in FindAllAboveThresholdWithLimit('%s', %.2f, %d), expected %v got %v", currentCase.inputString, currentCase.threshold, currentCase.maxResults, currentCase.expectedWords, words)
+		}
+
+		if currentCase.maxResults < 0 {
+			if unlimited := FindAllAboveThreshold(currentCase.inputString, validWords, currentCase.threshold, JaroSimilarity); !slices.Equal(unlimited, result) {
+				t.Errorf("Error in FindAllAboveThreshold('%s', %.2f), expected %v got %v", currentCase.inputString, currentCase.threshold, result, unlimited)
+			}
+		}
+	}
+}
+
+func TestHamming(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedDistance   int
+		expectedErr        error
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"", "", 0, nil, 1},
+		{"alumni", "alumni", 0, nil, 1},
+		{"karolin", "kathrin", 3, nil, 0.571},
+		{"1011101", "1001001", 2, nil, 0.714},
+		{"héllo", "hallo", 1, nil, 0.8},
+		{"T2P 1N4", "T2P 1N5", 1, nil, 0.857},
+		{"a", "", 0, ErrUnequalLength, 0},
+		{"almni", "alumni", 0, ErrUnequalLength, 0},
+	}
+
+	for _, currentCase := range cases {
+		result, err := HammingDistance(currentCase.inputString, currentCase.targetString)
+		if err != currentCase.expectedErr {
+			t.Errorf("Error in HammingDistance('%s', '%s'), expected error %v got %v", currentCase.inputString, currentCase.targetString, currentCase.expectedErr, err)
+		}
+		if result != currentCase.expectedDistance {
+			t.Errorf("Error in HammingDistance('%s', '%s'), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.expectedDistance, result)
+		}
+
+		similarity := HammingSimilarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(similarity), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in HammingSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, similarity)
+		}
+
+		similarity, err = HammingSimilarityWithError(currentCase.inputString, currentCase.targetString)
+		if err != currentCase.expectedErr {
+			t.Errorf("Error in HammingSimilarityWithError('%s', '%s'), expected error %v got %v", currentCase.inputString, currentCase.targetString, currentCase.expectedErr, err)
+		}
+		if !compareFloat(float64(similarity), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in HammingSimilarityWithError('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, similarity)
+		}
+	}
+
+	// Should be usable with the distance pipeline when the lengths are known to match
+	if similarity := CalculateSimilarityOrPanic("karolin", "kathrin", HammingDistance); !compareFloat(float64(similarity), 0.786, 3) {
+		t.Errorf("Error in CalculateSimilarityOrPanic('karolin', 'kathrin', HammingDistance), expected 0.786 got %.3f", similarity)
+	}
+	func() {
+		defer func() {
+			if err := recover(); err != ErrUnequalLength {
+				t.Errorf("Error in CalculateSimilarityOrPanic('almni', 'alumni', HammingDistance), expected a panic with ErrUnequalLength got %v", err)
+			}
+		}()
+		CalculateSimilarityOrPanic("almni", "alumni", HammingDistance)
+	}()
+
+	suggestion := SuggestWord("T2P 1N5", []string{"T2P 1N4", "T3A 0B1", "T2P1N4"}, HammingSimilarity)
+	if suggestion.Word != "T2P 1N4" {
+		t.Errorf("Error in SuggestWord('T2P 1N5') with HammingSimilarity, expected 'T2P 1N4' got '%s'", suggestion.Word)
+	}
+}
+
+func TestLCS(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedLength     int
+		expectedDistance   int
+		expectedSimilarity float64
+		expectedString     string
+	}
+
+	cases := []testCase{
+		{"", "", 0, 0, 1, ""},
+		{"a", "", 0, 1, 0, ""},
+		{"", "alumni", 0, 6, 0, ""},
+		{"abcd", "abdc", 3, 2, 0.75, "abc"},
+		{"almni", "alumni", 5, 1, 0.909, "almni"},
+		{"alyni", "alumni", 4, 3, 0.727, "alni"},
+		{"inula", "alumni", 1, 9, 0.182, "i"},
+		{"alumni", "alumni", 6, 0, 1, "alumni"},
+		{"franklin", "alumni", 3, 8, 0.429, "ani"},
+		{"convesre", "converse", 7, 2, 0.875, "convese"},
+		{"héllo", "hello", 4, 2, 0.818, "hllo"},
+		{"AGGTAB", "GXTXAYB", 4, 5, 0.615, "GTAB"}, // From CLRS
+	}
+
+	for _, currentCase := range cases {
+		length := LCSLength(currentCase.inputString, currentCase.targetString)
+		if length != currentCase.expectedLength {
+			t.Errorf("Error in LCSLength('%s', '%s'), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.expectedLength, length)
+		}
+
+		distance := LCSDistance(currentCase.inputString, currentCase.targetString)
+		if distance != currentCase.expectedDistance {
+			t.Errorf("Error in LCSDistance('%s', '%s'), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.expectedDistance, distance)
+		}
+
+		similarity := LCSSimilarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(similarity), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in LCSSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, similarity)
+		}
+
+		subsequence := LCSString(currentCase.inputString, currentCase.targetString)
+		if subsequence != currentCase.expectedString {
+			t.Errorf("Error in LCSString('%s', '%s'), expected %s got %s", currentCase.inputString, currentCase.targetString, currentCase.expectedString, subsequence)
+		}
+	}
+
+	// On ASCII strings the LCS distance is the same as the Indel distance
+	if distance := LCSDistance("franklin", "alumni"); distance != IndelDistance("franklin", "alumni") {
+		t.Errorf("Error in LCSDistance('franklin', 'alumni'), expected the Indel distance %d got %d", IndelDistance("franklin", "alumni"), distance)
+	}
+}
+
+func TestNGram(t *testing.T) {
+	// Extraction
+	grams := ExtractNGrams("ab", 3)
+	expectedGrams := []string{"  a", " ab", "ab ", "b  "}
+	if len(grams) != len(expectedGrams) {
+		t.Fatalf("Error in ExtractNGrams('ab', 3), expected %q got %q", expectedGrams, grams)
+	}
+	for i := range grams {
+		if grams[i] != expectedGrams[i] {
+			t.Errorf("Error in ExtractNGrams('ab', 3), expected %q got %q", expectedGrams, grams)
+			break
+		}
+	}
+	if grams := ExtractNGrams("", 3); len(grams) != 0 {
+		t.Errorf("Error in ExtractNGrams('', 3), expected no n-grams got %q", grams)
+	}
+	if grams := ExtractNGrams("héllo", 1); len(grams) != 5 || grams[1] != "é" {
+		t.Errorf("Error in ExtractNGrams('héllo', 1), expected rune unigrams got %q", grams)
+	}
+
+	type testCase struct {
+		inputString        string
+		targetString       string
+		n                  int
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"", "", 3, 1},
+		{"a", "", 3, 0},
+		{"alumni", "alumni", 3, 1},
+		{"almni", "alumni", 3, 0.667},
+		{"almni", "alumni", 2, 0.769},
+		{"night", "nacht", 2, 0.5},
+		{"abc", "xyz", 3, 0},
+		{"aaa", "aa", 1, 0.8},
+	}
+
+	for _, currentCase := range cases {
+		result := NGramSimilarity(currentCase.inputString, currentCase.targetString, currentCase.n)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in NGramSimilarity('%s', '%s', %d), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.n, currentCase.expectedSimilarity, result)
+		}
+	}
+
+	if result := TrigramSimilarity("almni", "alumni"); !compareFloat(float64(result), 0.667, 3) {
+		t.Errorf("Error in TrigramSimilarity('almni', 'alumni'), expected 0.667 got %.3f", result)
+	}
+}
+
+func TestCosineBigram(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"", "", 1},
+		{"a", "", 0},
+		{"", "alumni", 0},
+		{"alumni", "alumni", 1},
+		{"almni", "alumni", 0.671},
+		{"abc", "xyz", 0},
+		{"a", "a", 1},
+		{"a", "ab", 0},
+		// Order of the bigrams doesn't matter
+		{"new york city", "city new york", 0.833},
+		{"aab", "abaa", 0.816},
+	}
+
+	for _, currentCase := range cases {
+		result := CosineBigramSimilarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in CosineBigramSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+	}
+
+	suggestion := SuggestWord("almni", []string{"hi", "hello", "bonjour", "alumni"}, CosineBigramSimilarity)
+	if suggestion.Word != "alumni" {
+		t.Errorf("Error in SuggestWord('almni') with CosineBigramSimilarity, expected alumni got %s", suggestion.Word)
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		n                  int
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"", "", 2, 1},
+		{"a", "", 2, 0},
+		{"", "alumni", 2, 0},
+		{"alumni", "alumni", 2, 1},
+		{"almni", "alumni", 2, 0.5},
+		{"almni", "alumni", 3, 0.167},
+		{"night", "nacht", 2, 0.143},
+		// Sets ignore repeats
+		{"aaaa", "aa", 2, 1},
+		{"abab", "ab", 2, 0.5},
+		{"a", "ab", 2, 0},
+		{"abc", "xyz", 1, 0},
+	}
+
+	for _, currentCase := range cases {
+		result := JaccardSimilarity(currentCase.inputString, currentCase.targetString, currentCase.n)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in JaccardSimilarity('%s', '%s', %d), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.n, currentCase.expectedSimilarity, result)
+		}
+		distance := JaccardDistance(currentCase.inputString, currentCase.targetString, currentCase.n)
+		if !compareFloat(float64(distance), 1-currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in JaccardDistance('%s', '%s', %d), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.n, 1-currentCase.expectedSimilarity, distance)
+		}
+	}
+
+	if result := JaccardBigramSimilarity("almni", "alumni"); !compareFloat(float64(result), 0.5, 3) {
+		t.Errorf("Error in JaccardBigramSimilarity('almni', 'alumni'), expected 0.500 got %.3f", result)
+	}
+	if result := JaccardTrigramSimilarity("almni", "alumni"); !compareFloat(float64(result), 0.167, 3) {
+		t.Errorf("Error in JaccardTrigramSimilarity('almni', 'alumni'), expected 0.167 got %.3f", result)
+	}
+}
+
+func TestTversky(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		alpha              float32
+		beta               float32
+		n                  int
+		expectedSimilarity float64
+	}
+
+	// Validated with the definition in https://en.wikipedia.org/wiki/Tversky_index
+	cases := []testCase{
+		{"", "", 1, 1, 2, 1},
+		{"a", "", 1, 1, 2, 0},
+		{"alumni", "alumni", 0.5, 0.5, 2, 1},
+		// A = {a, b, c}, B = {b, c, d}
+		{"abc", "bcd", 1, 1, 1, 0.5},
+		{"abc", "bcd", 0.5, 0.5, 1, 0.667},
+		{"abc", "bcd", 1, 0, 1, 0.667},
+		{"abc", "bcd", 0, 0, 1, 1},
+		// Asymmetric, every feature of the prototype is in the variant
+		{"ab", "abcd", 1, 0, 1, 1},
+		{"abcd", "ab", 1, 0, 1, 0.5},
+		{"ab", "abcd", 0.2, 0.8, 1, 0.556},
+		// 0/0 is 0
+		{"abc", "xyz", 0, 0, 1, 0},
+	}
+
+	for _, currentCase := range cases {
+		result := TverskySimilarity(currentCase.inputString, currentCase.targetString, currentCase.alpha, currentCase.beta, currentCase.n)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in TverskySimilarity('%s', '%s', %.1f, %.1f, %d), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.alpha, currentCase.beta, currentCase.n, currentCase.expectedSimilarity, result)
+		}
+	}
+
+	// alpha = beta = 1 is the Jaccard similarity
+	jaccard := NewTverskySimilarity(1, 1, 2)
+	for _, pair := range [][2]string{{"almni", "alumni"}, {"night", "nacht"}, {"franklin", "alumni"}} {
+		if result, expected := jaccard(pair[0], pair[1]), JaccardSimilarity(pair[0], pair[1], 2); !compareFloat(float64(result), float64(expected), 5) {
+			t.Errorf("Error in NewTverskySimilarity(1, 1, 2)('%s', '%s'), expected the Jaccard similarity %.3f got %.3f", pair[0], pair[1], expected, result)
+		}
+	}
+
+	// Negative weights are invalid
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Error in NewTverskySimilarity(-1, 1, 2), expected a panic")
+			}
+		}()
+		NewTverskySimilarity(-1, 1, 2)
+	}()
+}
+
+func TestSoundex(t *testing.T) {
+	type testCase struct {
+		word         string
+		expectedCode string
+	}
+
+	// Validated with https://www.archives.gov/research/census/soundex
+	cases := []testCase{
+		{"Robert", "R163"},
+		{"Rupert", "R163"},
+		{"Rubin", "R150"},
+		{"Ashcraft", "A261"},
+		{"Ashcroft", "A261"},
+		{"Tymczak", "T522"},
+		{"Pfister", "P236"},
+		{"Honeyman", "H555"},
+		{"Gutierrez", "G362"},
+		{"Jackson", "J250"},
+		{"Washington", "W252"},
+		{"Lee", "L000"},
+		{"Smith", "S530"},
+		{"Smyth", "S530"},
+		{"O'Hara", "O600"},
+		{"colour", "C460"},
+		{"color", "C460"},
+		{"A", "A000"},
+		{"Çelik", "C420"},
+		{"Müller", "M460"},
+		{"", ""},
+		{"123", ""},
+	}
+
+	for _, currentCase := range cases {
+		result := Soundex(currentCase.word)
+		if result != currentCase.expectedCode {
+			t.Errorf("Error in Soundex('%s'), expected %s got %s", currentCase.word, currentCase.expectedCode, result)
+		}
+	}
+
+	type similarityTestCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+	}
+
+	similarityCases := []similarityTestCase{
+		{"Smith", "Smyth", 1},
+		{"colour", "color", 1},
+		{"Robert", "Rubin", 0.5},
+		{"Robert", "Tymczak", 0},
+		{"Robert", "Rarity", 0.5},
+		{"", "", 1},
+		{"", "Robert", 0},
+		{"123", "456", 0},
+		{"fone", "phone", 0.75},
+		{"Jose", "José", 1},
+	}
+
+	for _, currentCase := range similarityCases {
+		result := SoundexSimilarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in SoundexSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+
+		// Identical words always match, unless they have an empty code
+		expectedMatch := currentCase.expectedSimilarity == 1 && Soundex(currentCase.inputString) != ""
+		if match := SoundexMatch(currentCase.inputString, currentCase.targetString); match != expectedMatch {
+			t.Errorf("Error in SoundexMatch('%s', '%s'), expected %t got %t", currentCase.inputString, currentCase.targetString, expectedMatch, match)
+		}
+	}
+
+	// Satisfies SimilarityAlgorithm, so it can be used to suggest words
+	// Soundex keeps the first letter, so "fone" (F500) matches "fine" rather than "phone" (P500)
+	suggestion := SuggestWord("fone", []string{"phone", "fine", "tone"}, SoundexSimilarity)
+	if suggestion.Word != "fine" {
+		t.Errorf("Error in SuggestWord('fone', ...) with SoundexSimilarity, expected fine got %s", suggestion.Word)
+	}
+}
+
+func TestRefinedSoundex(t *testing.T) {
+	type testCase struct {
+		word         string
+		expectedCode string
+	}
+
+	// Validated with Apache Commons Codec's RefinedSoundex
+	cases := []testCase{
+		{"testing", "T6036084"},
+		{"TESTING", "T6036084"},
+		{"The", "T60"},
+		{"quick", "Q503"},
+		{"brown", "B1908"},
+		{"fox", "F205"},
+		{"jumped", "J408106"},
+		{"over", "O0209"},
+		{"lazy", "L7050"},
+		{"dogs", "D6043"},
+		{"Caren", "C30908"},
+		{"Corwin", "C30908"},
+		{"Currum", "C30908"},
+		{"Carrington", "C309084608"},
+		{"Braz", "B1905"},
+		{"Broz", "B1905"},
+		{"Hayes", "H03"},
+		{"Heyes", "H03"},
+		{"Çelik", "C30703"},
+		{"", ""},
+		{"123", ""},
+	}
+
+	for _, currentCase := range cases {
+		result := RefinedSoundex(currentCase.word)
+		if result != currentCase.expectedCode {
+			t.Errorf("Error in RefinedSoundex('%s'), expected %s got %s", currentCase.word, currentCase.expectedCode, result)
+		}
+	}
+
+	type differenceTestCase struct {
+		inputString          string
+		targetString         string
+		expectedClassicMatch bool
+		expectedRefinedMatch bool
+	}
+
+	// Refined codes aren't truncated and have more groups, so they split up names that classic codes merge
+	differenceCases := []differenceTestCase{
+		{"Smith", "Smyth", true, true},
+		{"Robert", "Rupert", true, true},
+		{"Ashcraft", "Ashcroft", true, true},
+		{"Smith", "Sneed", true, false},
+		{"Knuth", "Kant", true, false},
+		{"Robert", "Robertson", true, false},
+		{"Caren", "Carrington", false, false},
+		{"Robert", "Rubin", false, false},
+	}
+
+	for _, currentCase := range differenceCases {
+		classicMatch := Soundex(currentCase.inputString) == Soundex(currentCase.targetString)
+		if classicMatch != currentCase.expectedClassicMatch {
+			t.Errorf("Error in Soundex('%s') == Soundex('%s'), expected %t got %t", currentCase.inputString, currentCase.targetString, currentCase.expectedClassicMatch, classicMatch)
+		}
+		refinedMatch := RefinedSoundex(currentCase.inputString) == RefinedSoundex(currentCase.targetString)
+		if refinedMatch != currentCase.expectedRefinedMatch {
+			t.Errorf("Error in RefinedSoundex('%s') == RefinedSoundex('%s'), expected %t got %t", currentCase.inputString, currentCase.targetString, currentCase.expectedRefinedMatch, refinedMatch)
+		}
+	}
+
+	type similarityTestCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+	}
+
+	similarityCases := []similarityTestCase{
+		{"Smith", "Smyth", 1},
+		{"Smith", "Sneed", 0.909},
+		{"Robert", "Rubin", 0.846},
+		{"", "", 1},
+		{"", "Robert", 0},
+		{"123", "456", 0},
+	}
+
+	for _, currentCase := range similarityCases {
+		result := RefinedSoundexSimilarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in RefinedSoundexSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+	}
+}
+
+func TestNYSIIS(t *testing.T) {
+	type testCase struct {
+		word                  string
+		expectedCode          string
+		expectedUnlimitedCode string
+	}
+
+	// Validated with Apache Commons Codec's Nysiis
+	cases := []testCase{
+		{"Macintosh", "MCANT", "MCANT"},
+		{"Knuth", "NAT", "NAT"},
+		{"Koehn", "CAN", "CAN"},
+		{"Phillipson", "FALAPS", "FALAPSAN"},
+		{"Pfeister", "FASTAR", "FASTAR"},
+		{"Schoenhoeft", "SANAFT", "SANAFT"},
+		{"McKee", "MCY", "MCY"},
+		{"Mackie", "MCY", "MCY"},
+		{"Heitschmidt", "HATSNA", "HATSNAD"},
+		{"Bart", "BAD", "BAD"},
+		{"Hurd", "HAD", "HAD"},
+		{"Hunt", "HAD", "HAD"},
+		{"Westerlund", "WASTAR", "WASTARLAD"},
+		{"Casstevens", "CASTAF", "CASTAFAN"},
+		{"Vasquez", "VASG", "VASG"},
+		{"Frazier", "FRASAR", "FRASAR"},
+		{"Bowman", "BANAN", "BANAN"},
+		{"McKnight", "MCNAGT", "MCNAGT"},
+		{"Rickert", "RACAD", "RACAD"},
+		{"Deutsch", "DAT", "DAT"},
+		{"Westphal", "WASTFA", "WASTFAL"},
+		{"Shriver", "SRAVAR", "SRAVAR"},
+		{"Kuhl", "CAL", "CAL"},
+		{"Rawson", "RASAN", "RASAN"},
+		{"Jiles", "JAL", "JAL"},
+		{"Carraway", "CARY", "CARY"},
+		{"Yamada", "YANAD", "YANAD"},
+		// Short names, apostrophes and hyphens
+		{"O'Daniel", "ODANAL", "ODANAL"},
+		{"Smith-Jones", "SNATJA", "SNATJAN"},
+		{"Al", "AL", "AL"},
+		{"A", "A", "A"},
+		{"Ss", "S", "S"},
+		{"", "", ""},
+		{"123", "", ""},
+	}
+
+	for _, currentCase := range cases {
+		result := NYSIIS(currentCase.word)
+		if result != currentCase.expectedCode {
+			t.Errorf("Error in NYSIIS('%s'), expected %s got %s", currentCase.word, currentCase.expectedCode, result)
+		}
+		unlimited := NYSIISWithMaxLength(currentCase.word, 0)
+		if unlimited != currentCase.expectedUnlimitedCode {
+			t.Errorf("Error in NYSIISWithMaxLength('%s', 0), expected %s got %s", currentCase.word, currentCase.expectedUnlimitedCode, unlimited)
+		}
+	}
+
+	type similarityTestCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+	}
+
+	similarityCases := []similarityTestCase{
+		{"Brian", "Brown", 1},
+		{"Knight", "Night", 1},
+		{"Macintosh", "McIntosh", 1},
+		{"Bart", "Bard", 1},
+		{"Hunt", "Hurd", 1},
+		{"Rawson", "Rason", 1},
+		{"", "", 1},
+		{"", "Robert", 0},
+		{"123", "456", 0},
+	}
+
+	for _, currentCase := range similarityCases {
+		result := NYSIISSimilarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in NYSIISSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+	}
+}
+
+func TestCaverphone2(t *testing.T) {
+	type testCase struct {
+		word         string
+		expectedCode string
+	}
+
+	// Validated with the examples from "Caverphone Revisited", Apache Commons Codec's Caverphone2 and abydos' Caverphone
+	cases := []testCase{
+		// The rules keep the "p", so these don't share a code
+		{"Thompson", "TMPSN11111"},
+		{"Thomson", "TMSN111111"},
+		{"Christopher", "KRSTFA1111"},
+		{"Niall", "NA11111111"},
+		{"Smith", "SMT1111111"},
+		{"Schmidt", "SKMT111111"},
+		{"Lee", "LA11111111"},
+		{"Stevenson", "STFNSN1111"},
+		{"Peter", "PTA1111111"},
+		{"ready", "RTA1111111"},
+		{"social", "SSA1111111"},
+		{"able", "APA1111111"},
+		{"Tedder", "TTA1111111"},
+		{"Karleen", "KLN1111111"},
+		{"Dyun", "TN11111111"},
+		{"Tough", "TF11111111"},
+		{"Gnome", "NM11111111"},
+		{"Yvonne", "AFN1111111"},
+		{"", ""},
+		{"123", ""},
+	}
+
+	for _, currentCase := range cases {
+		result := Caverphone2(currentCase.word)
+		if result != currentCase.expectedCode {
+			t.Errorf("Error in Caverphone2('%s'), expected %s got %s", currentCase.word, currentCase.expectedCode, result)
+		}
+	}
+
+	type similarityTestCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+	}
+
+	similarityCases := []similarityTestCase{
+		{"Thomson", "Tomson", 1},
+		{"Thompson", "Thomson", 0.933},
+		{"Peter", "Tedder", 0.933},
+		{"", "", 1},
+		{"", "Thompson", 0},
+		{"123", "456", 0},
+	}
+
+	for _, currentCase := range similarityCases {
+		result := CaverphoneSimilarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in CaverphoneSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+	}
+}
+
+func TestCaverphone1(t *testing.T) {
+	type testCase struct {
+		word         string
+		expectedCode string
+	}
+
+	// Validated with the examples from the Caverphone 1.0 specification and Apache Commons Codec's Caverphone1
+	cases := []testCase{
+		{"David", "TFT111"},
+		{"Whittle", "WTL111"},
+		{"Lee", "L11111"},
+		{"mb", "M11111"},
+		{"mbmb", "MPM111"},
+		{"Peter", "PT1111"},
+		{"Peady", "PT1111"},
+		{"Stevenson", "STFNSN"},
+		{"", ""},
+		{"123", ""},
+	}
+	for _, word := range []string{"add", "aid", "at", "art", "eat", "earth", "head", "hit", "hot", "hold", "hard", "heart", "it", "out", "old"} {
+		cases = append(cases, testCase{word, "AT1111"})
+	}
+
+	for _, currentCase := range cases {
+		result := Caverphone1(currentCase.word)
+		if result != currentCase.expectedCode {
+			t.Errorf("Error in Caverphone1('%s'), expected %s got %s", currentCase.word, currentCase.expectedCode, result)
+		}
+	}
+}
+
+func TestMRA(t *testing.T) {
+	type testCase struct {
+		word         string
+		expectedCode string
+	}
+
+	cases := []testCase{
+		{"Byrne", "BYRN"},
+		{"Boern", "BRN"},
+		{"Smith", "SMTH"},
+		{"Smyth", "SMYTH"},
+		{"Catherine", "CTHRN"},
+		{"Kathryn", "KTHRYN"},
+		{"Bennett", "BNT"},
+		{"Aubrey", "ABRY"},
+		{"Christopherson", "CHRRSN"},
+		{"O'Brien", "OBRN"},
+		{"", ""},
+		{"123", ""},
+	}
+
+	for _, currentCase := range cases {
+		result := MRAEncode(currentCase.word)
+		if result != currentCase.expectedCode {
+			t.Errorf("Error in MRAEncode('%s'), expected %s got %s", currentCase.word, currentCase.expectedCode, result)
+		}
+	}
+
+	type compareTestCase struct {
+		inputString    string
+		targetString   string
+		expectedMatch  bool
+		expectedRating int
+	}
+
+	// The pairs from https://en.wikipedia.org/wiki/Match_rating_approach
+	compareCases := []compareTestCase{
+		{"Byrne", "Boern", true, 5},
+		{"Smith", "Smyth", true, 5},
+		{"Catherine", "Kathryn", true, 4},
+		{"Boern", "Byrne", true, 5},
+		{"Smith", "Smith", true, 6},
+		{"Smith", "Jones", false, 2},
+		{"Al", "Christopherson", false, 0},
+		{"", "", false, 0},
+		{"123", "Smith", false, 0},
+	}
+
+	for _, currentCase := range compareCases {
+		match, rating := MRACompare(currentCase.inputString, currentCase.targetString)
+		if match != currentCase.expectedMatch || rating != currentCase.expectedRating {
+			t.Errorf("Error in MRACompare('%s', '%s'), expected (%t, %d) got (%t, %d)", currentCase.inputString, currentCase.targetString, currentCase.expectedMatch, currentCase.expectedRating, match, rating)
+		}
+
+		expectedSimilarity := float64(currentCase.expectedRating) / 6
+		if currentCase.inputString == currentCase.targetString {
+			expectedSimilarity = 1
+		}
+		if result := MRASimilarity(currentCase.inputString, currentCase.targetString); !compareFloat(float64(result), expectedSimilarity, 3) {
+			t.Errorf("Error in MRASimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, expectedSimilarity, result)
+		}
+	}
+}
+
+func TestMetaphone(t *testing.T) {
+	type testCase struct {
+		word         string
+		expectedCode string
+	}
+
+	// Validated against Apache Commons Codec's Metaphone without its 4 letter limit, except CH which is always X as in the original rules
+	cases := []testCase{
+		{"Thompson", "0MPSN"},
+		{"Knight", "NT"},
+		{"Wright", "RT"},
+		{"Thumb", "0M"},
+		{"Dumb", "TM"},
+		{"Phone", "FN"},
+		{"Phillips", "FLPS"},
+		{"Back", "BK"},
+		{"Knock", "NK"},
+		{"Gnome", "NM"},
+		{"Sign", "SN"},
+		{"Signed", "SNT"},
+		{"Signal", "SKNL"},
+		{"Laugh", "L"},
+		{"Night", "NT"},
+		{"Ghost", "KST"},
+		{"Ghent", "KNT"},
+		{"Church", "XRX"},
+		{"School", "SKL"},
+		{"Schmidt", "SKMTT"},
+		{"Science", "SNS"},
+		{"Scene", "SN"},
+		{"Judge", "JJ"},
+		{"Edge", "EJ"},
+		{"Dodge", "TJ"},
+		{"Xavier", "SFR"},
+		{"Box", "BKS"},
+		{"Whale", "WL"},
+		{"White", "WT"},
+		{"Why", ""},
+		{"Yes", "YS"},
+		{"Young", "YNK"},
+		{"Yellow", "YL"},
+		{"Nation", "NXN"},
+		{"Portion", "PRXN"},
+		{"Asian", "AXN"},
+		{"Shore", "XR"},
+		{"Lazy", "LS"},
+		{"Zebra", "SBR"},
+		{"Quick", "KK"},
+		{"Queen", "KN"},
+		{"Vivid", "FFT"},
+		{"Ciao", "X"},
+		{"Special", "SPXL"},
+		{"Aeon", "EN"},
+		{"Pneumonia", "NMN"},
+		{"Michael", "MXL"},
+		{"Smith", "SM0"},
+		{"Smyth", "SM0"},
+		{"Watch", "WX"},
+		{"Catherine", "K0RN"},
+		{"Kathryn", "K0RN"},
+		{"Hugh", "H"},
+		{"Howl", "HL"},
+		{"Ahead", "AHT"},
+		{"O'Hara", "OHR"},
+		{"Accident", "AKSTNT"},
+		{"testing", "TSTNK"},
+		// Digits and punctuation are skipped
+		{"Kn1ght", "NT"},
+		{"Ph-one", "FN"},
+		{"P.H.O.N.E", "FN"},
+		{"Thom's", "0MS"},
+		{"Wright!", "RT"},
+		{"sch00l", "SKL"},
+		{"42nd Street", "NTSTRT"},
+		{"", ""},
+		{"123", ""},
+	}
+
+	for _, currentCase := range cases {
+		result := Metaphone(currentCase.word)
+		if result != currentCase.expectedCode {
+			t.Errorf("Error in Metaphone('%s'), expected %s got %s", currentCase.word, currentCase.expectedCode, result)
+		}
+	}
+
+	type similarityTestCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+	}
+
+	similarityCases := []similarityTestCase{
+		{"Smith", "Smyth", 1},
+		{"Catherine", "Kathryn", 1},
+		{"Knight", "Night", 1},
+		{"Phone", "Fone", 1},
+		{"Thompson", "Tomson", 0.778},
+		{"", "", 1},
+		{"", "Robert", 0},
+		{"123", "456", 0},
+	}
+
+	for _, currentCase := range similarityCases {
+		result := MetaphoneSimilarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in MetaphoneSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+	}
+}
+
+func TestDoubleMetaphone(t *testing.T) {
+	type testCase struct {
+		word              string
+		expectedPrimary   string
+		expectedSecondary string
+	}
+
+	// Validated against the examples in Lawrence Philips' original implementation
+	cases := []testCase{
+		{"Smith", "SM0", "XMT"},
+		{"Schmidt", "XMT", "SMT"},
+		{"Xavier", "SF", "SFR"},
+		{"Jose", "HS", "HS"},
+		{"Thomas", "TMS", "TMS"},
+		{"Caesar", "SSR", "SSR"},
+		{"Chianti", "KNT", "KNT"},
+		{"Michael", "MKL", "MXL"},
+		{"Bajador", "PJTR", "PHTR"},
+		{"Dumb", "TM", "TM"},
+		{"Thumb", "0M", "TM"},
+		{"Architect", "ARKT", "ARKT"},
+		{"Orchestra", "ARKS", "ARKS"},
+		{"Gallegos", "KLKS", "KKS"},
+		{"Cabrillo", "KPRL", "KPR"},
+		{"Jankelowicz", "JNKL", "ANKL"},
+		{"Yankelovich", "ANKL", "ANKL"},
+		{"Wasserman", "ASRM", "FSRM"},
+		{"Vasserman", "FSRM", "FSRM"},
+		{"Arnow", "ARN", "ARNF"},
+		{"Arnoff", "ARNF", "ARNF"},
+		{"Filipowicz", "FLPT", "FLPF"},
+		{"Wojciech", "AJSK", "FJXK"},
+		{"Voitech", "FTK", "FTK"},
+		{"Czerny", "SRN", "XRN"},
+		{"Cherny", "XRN", "XRN"},
+		{"Giovanni", "JFN", "KFN"},
+		{"Jovanni", "JFN", "AFN"},
+		{"Mueller", "MLR", "MLR"},
+		{"Miller", "MLR", "MLR"},
+		{"Schneider", "XNTR", "SNTR"},
+		{"Snider", "SNTR", "XNTR"},
+		{"Tagliaro", "TKLR", "TLR"},
+		{"Biaggi", "PJ", "PK"},
+		{"Zhao", "J", "J"},
+		{"Laugh", "LF", "LF"},
+		{"Knight", "NT", "NT"},
+		{"Edge", "AJ", "AJ"},
+		{"Edgar", "ATKR", "ATKR"},
+		{"Accident", "AKST", "AKST"},
+		{"Bacci", "PX", "PX"},
+		{"Bacchus", "PKS", "PKS"},
+		{"Sugar", "XKR", "SKR"},
+		{"Island", "ALNT", "ALNT"},
+		{"School", "SKL", "SKL"},
+		{"Resnais", "RSN", "RSNS"},
+		{"Breaux", "PR", "PR"},
+		{"Rogier", "RJ", "RJR"},
+		{"Thompson", "TMPS", "TMPS"},
+		{"Kaczmarek", "KSMR", "KXMR"},
+		{"Gerhardt", "KRRT", "JRRT"},
+		{"Jablonski", "JPLN", "APLN"},
+		{"Yablonsky", "APLN", "APLN"},
+		{"", "", ""},
+	}
+
+	for _, currentCase := range cases {
+		primary, secondary := DoubleMetaphone(currentCase.word)
+		if primary != currentCase.expectedPrimary || secondary != currentCase.expectedSecondary {
+			t.Errorf("Error in DoubleMetaphone('%s'), expected (%s, %s) got (%s, %s)", currentCase.word, currentCase.expectedPrimary, currentCase.expectedSecondary, primary, secondary)
+		}
+	}
+
+	type similarityTestCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+	}
+
+	// Names with the same origin spelled in different languages
+	similarityCases := []similarityTestCase{
+		{"Wojciech", "Voitech", 0.714},    // Polish and Czech
+		{"Czerny", "Cherny", 1},           // Slavic
+		{"Jablonski", "Yablonsky", 1},     // Slavic
+		{"Jankelowicz", "Yankelovich", 1}, // Slavic
+		{"Smith", "Schmidt", 1},           // English and German
+		{"Schneider", "Snider", 1},        // German and English
+		{"Wasserman", "Vasserman", 1},     // Germanic
+		{"Giovanni", "Jovanni", 1},        // Italian and Spanish
+		{"Kaczmarek", "Kachmarek", 0.875}, // Polish and English
+		{"Smith", "Jones", 0.5},
+		{"", "", 1},
+		{"", "Smith", 0},
+	}
+
+	for _, currentCase := range similarityCases {
+		result := DoubleMetaphoneSimilarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in DoubleMetaphoneSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+		// The alternate codes should never make it worse than Metaphone for these names
+		if currentCase.inputString != "" && currentCase.targetString != "" && result < MetaphoneSimilarity(currentCase.inputString, currentCase.targetString) {
+			t.Errorf("DoubleMetaphoneSimilarity('%s', '%s') scored lower than MetaphoneSimilarity", currentCase.inputString, currentCase.targetString)
+		}
+	}
+}
+
+func TestPartialRatio(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"New York", "New York City, NY, USA", 1},
+		{"New York City, NY, USA", "New York", 1},
+		{"this is a test", "this is a test!", 1},
+		{"NY Yankees", "New York Yankees", 0.8},
+		{"colour", "the color red", 0.833},
+		{"héllo", "say hello", 0.8},
+		{"abc", "xyz", 0},
+		{"", "", 1},
+		{"", "abc", 0},
+	}
+
+	for _, currentCase := range cases {
+		result := PartialRatioSimilarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in PartialRatioSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+	}
+}
+
+func TestCaseInsensitive(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+		expectedDistance   int
+	}
+
+	cases := []testCase{
+		{"Hello", "hello", 1, 0},
+		{"HELLO", "hello", 1, 0},
+		{"ÉCOLE", "école", 1, 0},
+		{"Helo", "hello", 0.889, 1},
+		{"", "", 1, 0},
+	}
+
+	similarity := CaseInsensitive(LevenshteinSimilarity)
+	distance := CaseInsensitiveDistance(LevenshteinDistance)
+	for _, currentCase := range cases {
+		result := similarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in CaseInsensitive(LevenshteinSimilarity)('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+		distanceResult := distance(currentCase.inputString, currentCase.targetString)
+		if distanceResult != currentCase.expectedDistance {
+			t.Errorf("Error in CaseInsensitiveDistance(LevenshteinDistance)('%s', '%s'), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.expectedDistance, distanceResult)
+		}
+	}
+
+	// Wrapping twice shouldn't change anything, and it should work with any algorithm
+	if result := CaseInsensitive(CaseInsensitive(JaroWinklerSimilarity))("MARTHA", "martha"); result != 1 {
+		t.Errorf("Error in CaseInsensitive(CaseInsensitive(JaroWinklerSimilarity))('MARTHA', 'martha'), expected 1 got %.3f", result)
+	}
+	if result := LevenshteinSimilarity("Hello", "hello"); result == 1 {
+		t.Errorf("LevenshteinSimilarity('Hello', 'hello') should still be case sensitive")
+	}
+}
+
+func TestNormalizedUnicode(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+		expectedDistance   int
+	}
+
+	// The first string of each pair is NFC, and the second is NFD
+	cases := []testCase{
+		{"caf\u00e9", "cafe\u0301", 1, 0},
+		{"\u00c5ngstr\u00f6m", "A\u030angstro\u0308m", 1, 0},
+		{"\ud55c\uad6d\uc5b4", "\u1112\u1161\u11ab\u1100\u116e\u11a8\u110b\u1165", 1, 0},
+		{"caf\u00e9s", "cafe\u0301", 0.909, 1},
+		{"", "", 1, 0},
+	}
+
+	similarity := NormalizedUnicode(LevenshteinSimilarity)
+	distance := NormalizedUnicodeDistance(DynamicLevenshtein)
+	for _, currentCase := range cases {
+		result := similarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in NormalizedUnicode(LevenshteinSimilarity)(%+q, %+q), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+		distanceResult := distance(currentCase.inputString, currentCase.targetString)
+		if distanceResult != currentCase.expectedDistance {
+			t.Errorf("Error in NormalizedUnicodeDistance(DynamicLevenshtein)(%+q, %+q), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.expectedDistance, distanceResult)
+		}
+	}
+
+	// Without normalization the same strings are different
+	if result := DynamicLevenshtein("caf\u00e9", "cafe\u0301"); result != 2 {
+		t.Errorf("Error in DynamicLevenshtein(\"caf\\u00e9\", \"cafe\\u0301\"), expected 2 got %d", result)
+	}
+
+	// Wrappers compose
+	if result := CaseInsensitive(NormalizedUnicode(JaroWinklerSimilarity))("CAF\u00c9", "cafe\u0301"); result != 1 {
+		t.Errorf("Error in CaseInsensitive(NormalizedUnicode(JaroWinklerSimilarity)), expected 1 got %.3f", result)
+	}
+}
+
+func TestWeightedRatio(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+	}
+
+	// Expected values follow RapidFuzz's fuzz.WRatio(inputString, targetString) / 100
+	cases := []testCase{
+		{"new york city", "city new york", 0.95},
+		{"fuzzy wuzzy was a bear", "wuzzy fuzzy was a bear", 0.95},
+		{"fuzzy was a bear", "fuzzy fuzzy was a bear", 0.95},
+		{"this is a test", "this is a test!", 0.966},
+		{"New York", "New York City, NY, USA", 0.9},
+		{"Ford", "Ford Motor Company", 0.9},
+		{"a", "Ford Motor Company of America", 0.6},
+		{"alumni", "almni", 0.909},
+		{"abc", "xyz", 0},
+		{"", "", 1},
+		{"", "abc", 0},
+	}
+
+	for _, currentCase := range cases {
+		result := WeightedRatio(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in WeightedRatio('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+	}
+
+	// Custom weights
+	weights := DefaultWeightedRatioWeights
+	weights.TokenScale = 1
+	if result := NewWeightedRatio(weights)("new york city", "city new york"); result != 1 {
+		t.Errorf("Error in NewWeightedRatio(TokenScale: 1)('new york city', 'city new york'), expected 1 got %.3f", result)
+	}
+
+	// Always in range
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("ab c")
+	for range 500 {
+		inputString := randomString(generator, alphabet, 12)
+		targetString := randomString(generator, alphabet, 12)
+		if result := WeightedRatio(inputString, targetString); result < 0 || result > 1 {
+			t.Errorf("WeightedRatio('%s', '%s') = %.3f is out of range", inputString, targetString, result)
+		}
+	}
+}
+
+func TestGraphemeLevenshtein(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedDistance   int
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"\U0001F1EC\U0001F1E7", "\U0001F1FA\U0001F1F8", 1, 0.5},                            // Flags are 2 regional indicators
+		{"hi \U0001F1EC\U0001F1E7", "hi \U0001F1EC\U0001F1E7", 0, 1},                        // Identical
+		{"\U0001F468\u200D\U0001F469\u200D\U0001F467", "\U0001F468", 1, 0.5},                // ZWJ family sequence
+		{"\U0001F44D\U0001F3FD", "\U0001F44D", 1, 0.5},                                      // Skin tone modifier
+		{"cafe\u0301", "cafe", 1, 0.875},                                                    // Combining acute accent
+		{"nai\u0308ve", "naive", 1, 0.9},                                                    // Combining diaeresis
+		{"\u1112\u1161\u11AB\u1100\u116E\u11A8", "\u1112\u1161\u11AB\u1100\u116E", 1, 0.75}, // Hangul jamo clusters
+		{"kitten", "sitting", 3, 0.769},
+		{"", "", 0, 1},
+		{"", "\U0001F1EC\U0001F1E7", 1, 0},
+	}
+
+	for _, currentCase := range cases {
+		distance := GraphemeLevenshteinDistance(currentCase.inputString, currentCase.targetString)
+		if distance != currentCase.expectedDistance {
+			t.Errorf("Error in GraphemeLevenshteinDistance(%+q, %+q), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.expectedDistance, distance)
+		}
+		similarity := GraphemeLevenshteinSimilarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(similarity), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in GraphemeLevenshteinSimilarity(%+q, %+q), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, similarity)
+		}
+	}
+
+	// Runes see the flags as 2 characters each
+	if distance := DynamicLevenshtein("\U0001F1EC\U0001F1E7", "\U0001F1FA\U0001F1F8"); distance != 2 {
+		t.Errorf("Error in DynamicLevenshtein(flags), expected 2 got %d", distance)
+	}
+}
+
+func TestWeightedLevenshtein(t *testing.T) {
+	unitCost := func(inputRune, targetRune rune) float64 {
+		return 1
+	}
+
+	type testCase struct {
+		inputString      string
+		targetString     string
+		insertCost       float64
+		deleteCost       float64
+		substitutionCost func(inputRune, targetRune rune) float64
+		expectedDistance float64
+	}
+
+	cases := []testCase{
+		{"kitten", "sitting", 1, 1, unitCost, 3},
+		{"kitten", "sitting", 2, 1, unitCost, 4},
+		{"abc", "", 1, 0.5, unitCost, 1.5},
+		{"", "abc", 0.5, 1, unitCost, 1.5},
+		{"hello", "hrllo", 1, 1, QWERTYSubstitutionCost, 0.5},
+		{"hello", "hpllo", 1, 1, QWERTYSubstitutionCost, 1},
+		{"Hello", "jello", 1, 1, QWERTYSubstitutionCost, 0.5},
+		{"dog", "fig", 1, 1, QWERTYSubstitutionCost, 1},
+		{"café", "cafe", 1, 1, unitCost, 1},
+		{"", "", 1, 1, unitCost, 0},
+	}
+
+	for _, currentCase := range cases {
+		result := WeightedLevenshteinDistance(currentCase.inputString, currentCase.targetString, currentCase.insertCost, currentCase.deleteCost, currentCase.substitutionCost)
+		if !compareFloat(result, currentCase.expectedDistance, 3) {
+			t.Errorf("Error in WeightedLevenshteinDistance('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedDistance, result)
+		}
+	}
+
+	// With unit costs it's the same as the regular Levenshtein distance
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcdé")
+	for range 500 {
+		inputString := randomString(generator, alphabet, 8)
+		targetString := randomString(generator, alphabet, 8)
+		expected := DynamicLevenshtein(inputString, targetString)
+		if result := WeightedLevenshteinDistance(inputString, targetString, 1, 1, unitCost); result != float64(expected) {
+			t.Errorf("Error in WeightedLevenshteinDistance('%s', '%s'), expected %d got %.3f", inputString, targetString, expected, result)
+		}
+	}
+
+	type neighbourCase struct {
+		inputRune    rune
+		targetRune   rune
+		expectedCost float64
+	}
+
+	neighbourCases := []neighbourCase{
+		{'s', 's', 0},
+		{'s', 'a', 0.5},
+		{'s', 'd', 0.5},
+		{'s', 'w', 0.5},
+		{'s', 'e', 0.5},
+		{'s', 'z', 0.5},
+		{'s', 'x', 0.5},
+		{'S', 'x', 0.5},
+		{'s', 'q', 1},
+		{'s', 'c', 1},
+		{'p', 'l', 0.5},
+		{'m', 'k', 0.5},
+		{'a', 'p', 1},
+		{'1', '2', 0.5},
+		{'!', '@', 0.5},
+		{'1', '!', 0},
+		{'p', '[', 0.5},
+		{'l', ';', 0.5},
+		{'m', ',', 0.5},
+		{'2', 'q', 0.5},
+		{'2', 'a', 1},
+		{'é', 'e', 1},
+	}
+
+	for _, currentCase := range neighbourCases {
+		result := QWERTYSubstitutionCost(currentCase.inputRune, currentCase.targetRune)
+		if result != currentCase.expectedCost {
+			t.Errorf("Error in QWERTYSubstitutionCost('%c', '%c'), expected %.1f got %.1f", currentCase.inputRune, currentCase.targetRune, currentCase.expectedCost, result)
+		}
+	}
+}
+
+func TestSpaceEfficientLevenshtein(t *testing.T) {
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcdé")
+	for range 2000 {
+		inputString := randomString(generator, alphabet, 12)
+		targetString := randomString(generator, alphabet, 12)
+
+		expected := DynamicLevenshtein(inputString, targetString)
+		if result := SpaceEfficientLevenshteinDistance(inputString, targetString); result != expected {
+			t.Errorf("Error in SpaceEfficientLevenshteinDistance('%s', '%s'), expected %d got %d", inputString, targetString, expected, result)
+		}
+	}
+}
+
+func BenchmarkLevenshtein(b *testing.B) {
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcdefghijklmnopqrstuvwxyz")
+	inputString := randomString(generator, alphabet, 500)
+	targetString := randomString(generator, alphabet, 500)
+
+	b.Run("DynamicLevenshtein", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			DynamicLevenshtein(inputString, targetString)
+		}
+	})
+	b.Run("SpaceEfficientLevenshteinDistance", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			SpaceEfficientLevenshteinDistance(inputString, targetString)
+		}
+	})
+	b.Run("MyersLevenshtein", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			MyersLevenshtein(inputString, targetString)
+		}
+	})
+}
+
+func TestBoundedLevenshtein(t *testing.T) {
+	type testCase struct {
+		inputString      string
+		targetString     string
+		maxDistance      int
+		expectedDistance int
+	}
+
+	cases := []testCase{
+		{"kitten", "sitting", 3, 3},
+		{"kitten", "sitting", 2, 3},
+		{"kitten", "sitting", 0, 1},
+		{"kitten", "kitten", 0, 0},
+		{"a", "abcdef", 2, 3},
+		{"", "abc", 5, 3},
+		{"abc", "", -1, 1},
+	}
+
+	for _, currentCase := range cases {
+		result := BoundedLevenshteinDistance(currentCase.inputString, currentCase.targetString, currentCase.maxDistance)
+		if result != currentCase.expectedDistance {
+			t.Errorf("Error in BoundedLevenshteinDistance('%s', '%s', %d), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.maxDistance, currentCase.expectedDistance, result)
+		}
+		if result := LevenshteinDistanceMax(currentCase.inputString, currentCase.targetString, currentCase.maxDistance); result != currentCase.expectedDistance {
+			t.Errorf("Error in LevenshteinDistanceMax('%s', '%s', %d), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.maxDistance, currentCase.expectedDistance, result)
+		}
+	}
+
+	// It should be the full distance, capped at maxDistance+1
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcé")
+	for range 2000 {
+		inputString := randomString(generator, alphabet, 10)
+		targetString := randomString(generator, alphabet, 10)
+		maxDistance := generator.Intn(6)
+
+		expected := min(DynamicLevenshtein(inputString, targetString), maxDistance+1)
+		if result := BoundedLevenshteinDistance(inputString, targetString, maxDistance); result != expected {
+			t.Errorf("Error in BoundedLevenshteinDistance('%s', '%s', %d), expected %d got %d", inputString, targetString, maxDistance, expected, result)
+		}
+	}
+}
+
+func TestBoundedSuggestWord(t *testing.T) {
+	validWords := []string{"hi", "hello", "bonjour", "alumni", "alumnus", "franklin"}
+
+	type testCase struct {
+		inputString    string
+		maxDistance    int
+		expectedResult string
+	}
+
+	cases := []testCase{
+		{"almni", 1, "alumni"},
+		{"almni", 3, "alumni"},
+		{"bonjur", 1, "bonjour"},
+		{"xyz", 2, ""},
+		{"helo", 0, ""},
+	}
+
+	for _, currentCase := range cases {
+		result := BoundedSuggestWord(currentCase.inputString, validWords, currentCase.maxDistance, JaroWinklerSimilarity)
+		if result.Word != currentCase.expectedResult {
+			t.Errorf("Error in BoundedSuggestWord('%s', %d), expected %s got %s", currentCase.inputString, currentCase.maxDistance, currentCase.expectedResult, result.Word)
+		}
+	}
+}
+
+func TestSuggestWordAboveThreshold(t *testing.T) {
+	validWords := []string{"hi", "hello", "bonjour", "alumni", "alumnus", "franklin"}
+
+	type testCase struct {
+		inputString        string
+		threshold          float32
+		expectedSuggestion Suggestion
+		expectedFound      bool
+	}
+
+	cases := []testCase{
+		{"almni", 0.5, Suggestion{0.909, "alumni"}, true},
+		{"almni", 0.95, Suggestion{}, false},
+		{"hello", 0.99, Suggestion{1, "hello"}, true},
+		{"hello", 1, Suggestion{}, false},
+		{"xyz", 0.5, Suggestion{}, false},
+	}
+
+	for _, currentCase := range cases {
+		result, found := SuggestWordAboveThreshold(currentCase.inputString, validWords, currentCase.threshold, LevenshteinSimilarity)
+		if found != currentCase.expectedFound || result.Word != currentCase.expectedSuggestion.Word || !compareFloat(float64(result.Likelihood), float64(currentCase.expectedSuggestion.Likelihood), 3) {
+			t.Errorf("Error in SuggestWordAboveThreshold('%s', %.3f), expected (%v, %t) got (%v, %t)", currentCase.inputString, currentCase.threshold, currentCase.expectedSuggestion, currentCase.expectedFound, result, found)
+		}
+
+		// The deprecated version only returns the word
+		if word := SuggestWordWithThreshold(currentCase.inputString, validWords, currentCase.threshold, LevenshteinSimilarity); word != currentCase.expectedSuggestion.Word {
+			t.Errorf("Error in SuggestWordWithThreshold('%s', %.3f), expected %s got %s", currentCase.inputString, currentCase.threshold, currentCase.expectedSuggestion.Word, word)
+		}
+	}
+}
+
+func TestFrequencyWeightedSuggestWord(t *testing.T) {
+	corpus := FrequencyCorpus{"the": 1000, "they": 200, "then": 150, "thee": 5, "alumni": 1}
+
+	type testCase struct {
+		inputString   string
+		alpha         float64
+		expectedWord  string
+		expectedScore float64
+	}
+
+	cases := []testCase{
+		{"thay", 1, "they", 0.833},
+		{"thay", 0.9, "they", 0.83},
+		// "the" is less similar, but much more common
+		{"thay", 0.5, "the", 0.861},
+		{"alumni", 0.9, "alumni", 0.92},
+		{"alumni", 2, "alumni", 1},
+		{"xyz", 0.9, "", 0},
+	}
+
+	for _, currentCase := range cases {
+		result := FrequencyWeightedSuggestWordWithAlpha(currentCase.inputString, corpus, currentCase.alpha, JaroSimilarity)
+		if result.Word != currentCase.expectedWord || !compareFloat(float64(result.Likelihood), currentCase.expectedScore, 3) {
+			t.Errorf("Error in FrequencyWeightedSuggestWordWithAlpha('%s', %.1f), expected {%.3f %s} got %v", currentCase.inputString, currentCase.alpha, currentCase.expectedScore, currentCase.expectedWord, result)
+		}
+	}
+
+	// "then" and "thee" are equally similar, so the more common one wins
+	if result := FrequencyWeightedSuggestWordWithAlpha("thet", FrequencyCorpus{"thee": 5, "then": 150}, 1, JaroSimilarity); result.Word != "then" {
+		t.Errorf("Error in FrequencyWeightedSuggestWordWithAlpha('thet', 1), expected then got %s", result.Word)
+	}
+	if result := FrequencyWeightedSuggestWord("thay", corpus, JaroSimilarity); result.Word != "they" {
+		t.Errorf("Error in FrequencyWeightedSuggestWord('thay'), expected they got %s", result.Word)
+	}
+	if result := FrequencyWeightedSuggestWord("the", FrequencyCorpus{}, JaroSimilarity); result != (Suggestion{}) {
+		t.Errorf("Error in FrequencyWeightedSuggestWord('the') with an empty corpus, expected an empty suggestion got %v", result)
+	}
+}
+
+func TestSmithWaterman(t *testing.T) {
+	type testCase struct {
+		inputString       string
+		targetString      string
+		match             int
+		mismatch          int
+		gap               int
+		expectedAlignment LocalAlignment
+	}
+
+	cases := []testCase{
+		// The example from https://en.wikipedia.org/wiki/Smith%E2%80%93Waterman_algorithm
+		{"TGTTACGG", "GGTTGACTA", 3, 3, 2, LocalAlignment{13, "GTTAC", "GTTGAC", 1, 6, 1, 7}},
+		{"abcdef", "xxabcyydefzz", 2, 1, 1, LocalAlignment{10, "abcdef", "abcyydef", 0, 6, 2, 10}},
+		{"kitten", "sitting", 2, 1, 1, LocalAlignment{7, "itten", "ittin", 1, 6, 1, 6}},
+		{"héllo", "say héllo!", 2, 1, 1, LocalAlignment{10, "héllo", "héllo", 0, 6, 4, 10}},
+		{"abc", "xyz", 2, 1, 1, LocalAlignment{}},
+		{"", "abc", 2, 1, 1, LocalAlignment{}},
+	}
+
+	for _, currentCase := range cases {
+		result := SmithWatermanAlignment(currentCase.inputString, currentCase.targetString, currentCase.match, currentCase.mismatch, currentCase.gap)
+		if result != currentCase.expectedAlignment {
+			t.Errorf("Error in SmithWatermanAlignment('%s', '%s'), expected %+v got %+v", currentCase.inputString, currentCase.targetString, currentCase.expectedAlignment, result)
+		}
+		score := SmithWaterman(currentCase.inputString, currentCase.targetString, currentCase.match, currentCase.mismatch, currentCase.gap)
+		if score != currentCase.expectedAlignment.Score {
+			t.Errorf("Error in SmithWaterman('%s', '%s'), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.expectedAlignment.Score, score)
+		}
+	}
+
+	type alignedTestCase struct {
+		inputString           string
+		targetString          string
+		expectedAlignedInput  string
+		expectedAlignedTarget string
+	}
+
+	alignedCases := []alignedTestCase{
+		// The example from https://en.wikipedia.org/wiki/Smith%E2%80%93Waterman_algorithm
+		{"TGTTACGG", "GGTTGACTA", "GTT-AC", "GTTGAC"},
+		{"GGTTGACTA", "TGTTACGG", "GTTGAC", "GTT-AC"},
+		// The best cell is in the middle of the matrix, so the traceback starts there instead of at the end
+		{"xxxxAGCTAGCTyyyy", "zzAGCTAGCTzz", "AGCTAGCT", "AGCTAGCT"},
+		{"héllo", "say héllo!", "héllo", "héllo"},
+		{"abc", "xyz", "", ""},
+	}
+
+	for _, currentCase := range alignedCases {
+		alignedInput, alignedTarget := SmithWatermanAlignedStrings(currentCase.inputString, currentCase.targetString, 3, 3, 2)
+		if alignedInput != currentCase.expectedAlignedInput || alignedTarget != currentCase.expectedAlignedTarget {
+			t.Errorf("Error in SmithWatermanAlignedStrings('%s', '%s'), expected '%s' and '%s' got '%s' and '%s'", currentCase.inputString, currentCase.targetString, currentCase.expectedAlignedInput, currentCase.expectedAlignedTarget, alignedInput, alignedTarget)
+		}
+	}
+
+	type similarityTestCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+	}
+
+	similarityCases := []similarityTestCase{
+		{"New York", "New York City", 1},
+		{"héllo", "say héllo!", 1},
+		{"kitten", "sitting", 0.583},
+		{"abc", "xyz", 0},
+		{"", "", 1},
+		{"", "abc", 0},
+	}
+
+	for _, currentCase := range similarityCases {
+		result := SmithWatermanSimilarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in SmithWatermanSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+	}
+}
+
+func TestAffineGap(t *testing.T) {
+	type testCase struct {
+		inputString      string
+		targetString     string
+		gapOpen          int
+		gapExtend        int
+		mismatch         int
+		expectedDistance int
+	}
+
+	cases := []testCase{
+		{"abcdef", "abcXXXXXXdef", 3, 1, 1, 8},
+		{"abcdef", "aXbXcXdXeXfX", 3, 1, 1, 13},
+		{"abcdef", "abcXXXXXXdef", 1, 1, 1, 6},
+		{"kitten", "sitting", 3, 1, 1, 5},
+		{"kitten", "sitting", 3, 1, 5, 13},
+		{"abc", "", 3, 1, 1, 5},
+		{"", "abc", 2, 2, 1, 6},
+		{"", "", 3, 1, 1, 0},
+	}
+
+	for _, currentCase := range cases {
+		result := AffineGapDistance(currentCase.inputString, currentCase.targetString, currentCase.gapOpen, currentCase.gapExtend, currentCase.mismatch)
+		if result != currentCase.expectedDistance {
+			t.Errorf("Error in AffineGapDistance('%s', '%s', %d, %d, %d), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.gapOpen, currentCase.gapExtend, currentCase.mismatch, currentCase.expectedDistance, result)
+		}
+	}
+
+	// One long pasted chunk scores much better with affine gaps than with Levenshtein
+	affine := AffineGapSimilarity("abcdef", "abcXXXXXXdef")
+	levenshtein := LevenshteinSimilarity("abcdef", "abcXXXXXXdef")
+	if !compareFloat(float64(affine), 0.852, 3) || affine <= levenshtein {
+		t.Errorf("Error in AffineGapSimilarity('abcdef', 'abcXXXXXXdef'), expected 0.852 (more than Levenshtein's %.3f) got %.3f", levenshtein, affine)
+	}
+
+	// With unit costs it's the same as the Levenshtein distance
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcé")
+	for range 1000 {
+		inputString := randomString(generator, alphabet, 10)
+		targetString := randomString(generator, alphabet, 10)
+		expected := DynamicLevenshtein(inputString, targetString)
+		if result := AffineGapDistance(inputString, targetString, 1, 1, 1); result != expected {
+			t.Errorf("Error in AffineGapDistance('%s', '%s', 1, 1, 1), expected %d got %d", inputString, targetString, expected, result)
+		}
+		if similarity := AffineGapSimilarity(inputString, targetString); similarity < 0 || similarity > 1 {
+			t.Errorf("AffineGapSimilarity('%s', '%s') = %.3f is out of range", inputString, targetString, similarity)
+		}
+	}
+}
+
+func TestLongestCommonSubstring(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedSubstring  string
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"abc", "abcdef", "abc", 0.5},
+		{"xabcdy", "zzabcdzz", "abcd", 0.5},
+		{"https://example.com/docs/install", "https://example.com/docs/usage", "https://example.com/docs/", 0.781},
+		{"iPhone 15 Pro", "Apple iPhone 15", "iPhone 15", 0.6},
+		{"GeeksforGeeks", "GeeksQuiz", "Geeks", 0.385},
+		{"abcd", "abdc", "ab", 0.5},
+		{"ab", "ba", "a", 0.5},
+		{"héllo", "jéllo", "éllo", 0.8},
+		{"abc", "xyz", "", 0},
+		{"", "", "", 1},
+		{"", "abc", "", 0},
+	}
+
+	for _, currentCase := range cases {
+		substring := LongestCommonSubstring(currentCase.inputString, currentCase.targetString)
+		if substring != currentCase.expectedSubstring {
+			t.Errorf("Error in LongestCommonSubstring('%s', '%s'), expected %s got %s", currentCase.inputString, currentCase.targetString, currentCase.expectedSubstring, substring)
+		}
+		length := LongestCommonSubstringLength(currentCase.inputString, currentCase.targetString)
+		if length != len([]rune(currentCase.expectedSubstring)) {
+			t.Errorf("Error in LongestCommonSubstringLength('%s', '%s'), expected %d got %d", currentCase.inputString, currentCase.targetString, len([]rune(currentCase.expectedSubstring)), length)
+		}
+		similarity := LongestCommonSubstringSimilarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(similarity), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in LongestCommonSubstringSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, similarity)
+		}
+	}
+}
+
+func TestQGram(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		q                  int
+		expectedDistance   int
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"abcd", "abcd", 2, 0, 1},
+		{"abcd", "abce", 2, 2, 0.667},
+		{"abcd", "dcba", 2, 6, 0},
+		{"aaa", "aa", 2, 1, 0.667},
+		{"leia", "leela", 2, 5, 0.286},
+		{"ab", "abcdef", 3, 5, 0},
+		{"ab", "ab", 3, 0, 1},
+		{"héllo", "hello", 2, 4, 0.5},
+		{"abc", "abd", 0, 2, 0.667},
+		{"", "", 2, 0, 1},
+		{"", "abc", 2, 2, 0},
+	}
+
+	for _, currentCase := range cases {
+		distance := QGramDistance(currentCase.inputString, currentCase.targetString, currentCase.q)
+		if distance != currentCase.expectedDistance {
+			t.Errorf("Error in QGramDistance('%s', '%s', %d), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.q, currentCase.expectedDistance, distance)
+		}
+		similarity := QGramSimilarity(currentCase.inputString, currentCase.targetString, currentCase.q)
+		if !compareFloat(float64(similarity), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in QGramSimilarity('%s', '%s', %d), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.q, currentCase.expectedSimilarity, similarity)
+		}
+	}
+
+	// It's a lower bound on the Levenshtein distance, and reusing a profile gives the same result
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcé")
+	for range 1000 {
+		inputString := randomString(generator, alphabet, 10)
+		targetString := randomString(generator, alphabet, 10)
+		q := 1 + generator.Intn(3)
+
+		distance := QGramDistance(inputString, targetString, q)
+		if levenshtein := DynamicLevenshtein(inputString, targetString); distance > 2*q*levenshtein {
+			t.Errorf("QGramDistance('%s', '%s', %d) = %d is more than 2q times the Levenshtein distance %d", inputString, targetString, q, distance, levenshtein)
+		}
+		if profileDistance := NewQGramProfile(inputString, q).Distance(NewQGramProfile(targetString, q)); profileDistance != distance {
+			t.Errorf("QGramProfile.Distance('%s', '%s', %d), expected %d got %d", inputString, targetString, q, distance, profileDistance)
+		}
+	}
+}
+
+func TestSorensenDice(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		n                  int
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"aaa", "aa", 2, 0.667},
+		{"night", "nacht", 2, 0.25},
+		{"context", "contact", 2, 0.5},
+		{"alumni", "almni", 2, 0.667},
+		{"alumni", "almni", 3, 0.286},
+		{"abc", "abd", 1, 0.667},
+		{"ab", "abc", 3, 0},
+		{"héllo", "hello", 2, 0.5},
+		{"", "", 2, 1},
+		{"", "abc", 2, 0},
+	}
+
+	for _, currentCase := range cases {
+		result := SorensenDiceSimilarity(currentCase.inputString, currentCase.targetString, currentCase.n)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in SorensenDiceSimilarity('%s', '%s', %d), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.n, currentCase.expectedSimilarity, result)
+		}
+		distance := SorensenDiceDistance(currentCase.inputString, currentCase.targetString, currentCase.n)
+		if !compareFloat(float64(distance), 1-currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in SorensenDiceDistance('%s', '%s', %d), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.n, 1-currentCase.expectedSimilarity, distance)
+		}
+		if currentCase.n == 2 {
+			if bigram := DiceBigramSimilarity(currentCase.inputString, currentCase.targetString); bigram != result {
+				t.Errorf("Error in DiceBigramSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, result, bigram)
+			}
+		}
+	}
+}
+
+func BenchmarkRatcliffObershelp(b *testing.B) {
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcdefghijklmnopqrstuvwxyz ")
+
+	for _, length := range []int{10, 100, 1000} {
+		inputRunes := make([]rune, length)
+		targetRunes := make([]rune, length)
+		for i := range inputRunes {
+			inputRunes[i] = alphabet[generator.Intn(len(alphabet))]
+			targetRunes[i] = alphabet[generator.Intn(len(alphabet))]
+		}
+		inputString, targetString := string(inputRunes), string(targetRunes)
+
+		b.Run(fmt.Sprintf("%d runes", length), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				RatcliffObershelpSimilarity(inputString, targetString)
+			}
+		})
+	}
+}
+
+func TestLevenshteinEditScript(t *testing.T) {
+	type testCase struct {
+		inputString  string
+		targetString string
+		expectedOps  string // The first letter of each operation's kind
+	}
+
+	cases := []testCase{
+		{"", "", ""},
+		{"", "abc", "III"},
+		{"abc", "", "DDD"},
+		{"alumni", "alumni", "EEEEEE"},
+		{"almni", "alumni", "EEIEEE"},
+		{"alumni", "almni", "EEDEEE"},
+		{"kitten", "sitting", "SEEESEI"},
+		{"héllo", "hello", "ESEEE"},
+		{"franklin", "alumni", "DDESSSSS"},
+	}
+
+	for _, currentCase := range cases {
+		ops := LevenshteinEditScript(currentCase.inputString, currentCase.targetString)
+
+		kinds := ""
+		edits := 0
+		for _, op := range ops {
+			kinds += op.Kind.String()[:1]
+			if op.Kind != EditEqual {
+				edits += 1
+			}
+		}
+		if kinds != currentCase.expectedOps {
+			t.Errorf("Error in LevenshteinEditScript('%s', '%s'), expected %s got %s", currentCase.inputString, currentCase.targetString, currentCase.expectedOps, kinds)
+		}
+		if distance := LevenshteinDistance(currentCase.inputString, currentCase.targetString); edits != distance {
+			t.Errorf("Error in LevenshteinEditScript('%s', '%s'), expected %d edits got %d", currentCase.inputString, currentCase.targetString, distance, edits)
+		}
+		if result := ApplyEditScript(currentCase.inputString, ops); result != currentCase.targetString {
+			t.Errorf("Error in ApplyEditScript('%s', LevenshteinEditScript('%s', '%s')), expected %s got %s", currentCase.inputString, currentCase.inputString, currentCase.targetString, currentCase.targetString, result)
+		}
+	}
+
+	// Applying the script always produces the target
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcé")
+	for range 1000 {
+		inputString := randomString(generator, alphabet, 10)
+		targetString := randomString(generator, alphabet, 10)
+		if result := ApplyEditScript(inputString, LevenshteinEditScript(inputString, targetString)); result != targetString {
+			t.Errorf("Error in ApplyEditScript('%s', LevenshteinEditScript('%s', '%s')), expected %s got %s", inputString, inputString, targetString, targetString, result)
+		}
+	}
+
+	// Runes that aren't covered by an operation are kept
+	ops := []EditOp{{Kind: EditSubstitute, InputPosition: 1, TargetRune: 'u'}, {Kind: EditInsert, InputPosition: 5, TargetRune: 's'}}
+	if result := ApplyEditScript("hello", ops); result != "hullos" {
+		t.Errorf("Error in ApplyEditScript('hello', ...), expected hullos got %s", result)
+	}
+}
+
+func TestAlignmentString(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedInputLine  string
+		expectedTargetLine string
+		expectedMatchLine  string
+	}
+
+	cases := []testCase{
+		{"", "", "", "", ""},
+		{"kitten", "sitting", "kitten+", "sitting", " ||| | "},
+		{"almni", "alumni", "al+mni", "alumni", "|| |||"},
+		{"alumni", "almni", "alumni", "al-mni", "|| |||"},
+		{"héllo", "hello", "héllo", "hello", "| |||"},
+		{"abc", "", "abc", "---", "   "},
+		{"", "abc", "+++", "abc", "   "},
+	}
+
+	for _, currentCase := range cases {
+		ops := LevenshteinEditScript(currentCase.inputString, currentCase.targetString)
+		inputLine, targetLine, matchLine := AlignmentString(currentCase.inputString, currentCase.targetString, ops)
+		if inputLine != currentCase.expectedInputLine || targetLine != currentCase.expectedTargetLine || matchLine != currentCase.expectedMatchLine {
+			t.Errorf("Error in AlignmentString('%s', '%s', ...), expected ('%s', '%s', '%s') got ('%s', '%s', '%s')", currentCase.inputString, currentCase.targetString, currentCase.expectedInputLine, currentCase.expectedTargetLine, currentCase.expectedMatchLine, inputLine, targetLine, matchLine)
+		}
+
+		// A nil script uses the Levenshtein edit script
+		nilInputLine, nilTargetLine, nilMatchLine := AlignmentString(currentCase.inputString, currentCase.targetString, nil)
+		if nilInputLine != inputLine || nilTargetLine != targetLine || nilMatchLine != matchLine {
+			t.Errorf("Error in AlignmentString('%s', '%s', nil), expected ('%s', '%s', '%s') got ('%s', '%s', '%s')", currentCase.inputString, currentCase.targetString, inputLine, targetLine, matchLine, nilInputLine, nilTargetLine, nilMatchLine)
+		}
+	}
+}
+
+func TestPairwiseMatrix(t *testing.T) {
+	words := []string{"kitten", "sitting", "mitten", "", "héllo", "hello"}
+
+	distances := PairwiseDistanceMatrix(words, LevenshteinDistance)
+	similarities := PairwiseSimilarityMatrix(words, LevenshteinSimilarity)
+	if distances.Len() != len(words) || similarities.Len() != len(words) {
+		t.Errorf("Error in PairwiseDistanceMatrix(), expected %d strings got %d and %d", len(words), distances.Len(), similarities.Len())
+	}
+
+	distanceRows := distances.Rows()
+	for i := range words {
+		for j := range words {
+			expectedDistance := LevenshteinDistance(words[i], words[j])
+			if distances.Get(i, j) != expectedDistance || distanceRows[i][j] != expectedDistance {
+				t.Errorf("Error in PairwiseDistanceMatrix().Get(%d, %d), expected %d got %d (%d in Rows())", i, j, expectedDistance, distances.Get(i, j), distanceRows[i][j])
+			}
+			expectedSimilarity := LevenshteinSimilarity(words[i], words[j])
+			if similarities.Get(i, j) != expectedSimilarity {
+				t.Errorf("Error in PairwiseSimilarityMatrix().Get(%d, %d), expected %.3f got %.3f", i, j, expectedSimilarity, similarities.Get(i, j))
+			}
+		}
+	}
+
+	if empty := PairwiseDistanceMatrix([]string{}, LevenshteinDistance); empty.Len() != 0 || len(empty.Rows()) != 0 {
+		t.Errorf("Error in PairwiseDistanceMatrix() with no strings, expected an empty matrix got %v", empty.Rows())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Error in DistanceMatrix.Get() out of range, expected a panic")
+		}
+	}()
+	distances.Get(0, len(words))
+}
+
+func TestHybridSimilarity(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"fone", "phone", 0.889},
+		{"definately", "definitely", 0.975},
+		{"alumni", "alumni", 1},
+		{"Smith", "Schmidt", 0.646},
+		{"", "", 1},
+		{"", "alumni", 0},
+	}
+
+	for _, currentCase := range cases {
+		result := HybridSimilarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in HybridSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+	}
+
+	// The weight is clamped, so the result is always one of the algorithms or a blend of them
+	for _, weight := range []float32{-1, 0, 0.25, 1, 2} {
+		scorer := NewHybridScorer(SoundexSimilarity, JaroSimilarity, weight)
+		clampedWeight := max(0, min(weight, 1))
+		for _, currentCase := range cases {
+			expected := clampedWeight*SoundexSimilarity(currentCase.inputString, currentCase.targetString) + (1-clampedWeight)*JaroSimilarity(currentCase.inputString, currentCase.targetString)
+			result := scorer(currentCase.inputString, currentCase.targetString)
+			if !compareFloat(float64(result), float64(expected), 3) || result < 0 || result > 1 {
+				t.Errorf("Error in NewHybridScorer(SoundexSimilarity, JaroSimilarity, %.2f)('%s', '%s'), expected %.3f got %.3f", weight, currentCase.inputString, currentCase.targetString, expected, result)
+			}
+		}
+	}
+
+	// Algorithms that go over 1 are still clamped
+	overOne := func(inputString, targetString string) float32 { return 2 }
+	if result := NewHybridScorer(overOne, overOne, 0.5)("a", "b"); result != 1 {
+		t.Errorf("Error in NewHybridScorer() with algorithms over 1, expected 1.000 got %.3f", result)
+	}
+}
+
+func TestLevenshteinWithCosts(t *testing.T) {
+	// Costs for common OCR confusions
+	costs := SubstitutionCosts{'0': {'O': 0.1}, 'm': {'n': 0.4}, 'l': {'1': 0.2}, 'x': {'y': 3}}
+
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedDistance   float64
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"B0B", "BOB", 0.1, 0.983},
+		{"BOB", "B0B", 0.1, 0.983},
+		{"name", "nane", 0.4, 0.95},
+		{"hel1o", "hello", 0.2, 0.98},
+		{"kitten", "sitting", 3, 0.769},
+		{"x", "y", 2, 0},
+		{"m0ll", "nOl1", 0.7, 0.913},
+		{"", "abc", 3, 0},
+		{"", "", 0, 1},
+	}
+
+	for _, currentCase := range cases {
+		distance := LevenshteinWithCosts(currentCase.inputString, currentCase.targetString, costs)
+		if !compareFloat(float64(distance), currentCase.expectedDistance, 3) {
+			t.Errorf("Error in LevenshteinWithCosts('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedDistance, distance)
+		}
+		similarity := LevenshteinWithCostsSimilarity(currentCase.inputString, currentCase.targetString, costs)
+		if !compareFloat(float64(similarity), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in LevenshteinWithCostsSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, similarity)
+		}
+	}
+
+	// Costs are symmetric unless DirectedCost is used
+	directed := SubstitutionCosts{'0': {'O': 0.1}}
+	if directed.Cost('O', '0') != 0.1 || directed.DirectedCost('O', '0') != 1 || directed.DirectedCost('0', 'O') != 0.1 || directed.Cost('a', 'a') != 0 {
+		t.Errorf("Error in SubstitutionCosts, expected symmetric Cost() and directed DirectedCost()")
+	}
+
+	// Unit costs are the normal Levenshtein distance
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcé")
+	for range 200 {
+		inputString := randomString(generator, alphabet, 8)
+		targetString := randomString(generator, alphabet, 8)
+		if distance := LevenshteinWithCosts(inputString, targetString, nil); int(distance) != LevenshteinDistance(inputString, targetString) {
+			t.Errorf("Error in LevenshteinWithCosts('%s', '%s', nil), expected %d got %.3f", inputString, targetString, LevenshteinDistance(inputString, targetString), distance)
+		}
+	}
+
+	// Composes with SuggestWord through a closure
+	suggestion := SuggestWord("C0L0R", []string{"COLOR", "CALOR", "COLLAR"}, func(inputString, targetString string) float32 {
+		return LevenshteinWithCostsSimilarity(inputString, targetString, costs)
+	})
+	if suggestion.Word != "COLOR" {
+		t.Errorf("Error in SuggestWord('C0L0R') with LevenshteinWithCostsSimilarity, expected COLOR got %s", suggestion.Word)
+	}
+}
+
+func TestTokenSimilarity(t *testing.T) {
+	type testCase struct {
+		inputString       string
+		targetString      string
+		expectedTokenSort float64
+		expectedTokenSet  float64
+	}
+
+	cases := []testCase{
+		{"new york city", "city new york", 1, 1},
+		{"new  york\tcity", "city new york", 1, 1},
+		{"new york", "new york city", 0.762, 1},
+		{"fuzzy was a bear", "fuzzy fuzzy was a bear", 0.842, 1},
+		{"new york mets", "new york yankees", 0.724, 0.828},
+		{"abc", "xyz", 0.5, 0.5},
+		{"kitten", "sitting", 0.769, 0.769},
+	}
+
+	for _, currentCase := range cases {
+		result := TokenSortSimilarity(currentCase.inputString, currentCase.targetString, LevenshteinSimilarity)
+		if !compareFloat(float64(result), currentCase.expectedTokenSort, 3) {
+			t.Errorf("Error in TokenSortSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedTokenSort, result)
+		}
+		result = TokenSetSimilarity(currentCase.inputString, currentCase.targetString, LevenshteinSimilarity)
+		if !compareFloat(float64(result), currentCase.expectedTokenSet, 3) {
+			t.Errorf("Error in TokenSetSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedTokenSet, result)
+		}
+	}
+
+	// The algorithm is used on the normalized strings
+	if result := TokenSortSimilarity("b a", "a b", func(inputString, targetString string) float32 {
+		if inputString != "a b" || targetString != "a b" {
+			t.Errorf("Error in TokenSortSimilarity('b a', 'a b'), expected algorithm to get 'a b' and 'a b' got '%s' and '%s'", inputString, targetString)
+		}
+		return 0.5
+	}); result != 0.5 {
+		t.Errorf("Error in TokenSortSimilarity('b a', 'a b'), expected the algorithm's similarity 0.5 got %.3f", result)
+	}
+}
+
+func TestPartialSimilarity(t *testing.T) {
+	type testCase struct {
+		query              string
+		target             string
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"Ford", "Ford Motor Company", 1},
+		{"Ford Motor Company", "Ford", 1},
+		{"Frod", "Ford Motor Company", 0.917},
+		{"Motr", "Ford Motor Company", 0.833},
+		{"abc", "xyz", 0},
+		{"", "abc", 0},
+		{"", "", 1},
+	}
+
+	for _, currentCase := range cases {
+		result := PartialSimilarity(currentCase.query, currentCase.target, JaroSimilarity)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in PartialSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.query, currentCase.target, currentCase.expectedSimilarity, result)
+		}
+	}
+
+	// Scoring windows with the Indel similarity is the partial ratio
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcé ")
+	for range 200 {
+		inputString := randomString(generator, alphabet, 10)
+		targetString := randomString(generator, alphabet, 10)
+		expected := PartialRatioSimilarity(inputString, targetString)
+		result := PartialSimilarity(inputString, targetString, func(inputString, targetString string) float32 {
+			return 1 - float32(LCSDistance(inputString, targetString))/float32(len([]rune(inputString))+len([]rune(targetString)))
+		})
+		if !compareFloat(float64(result), float64(expected), 3) {
+			t.Errorf("Error in PartialSimilarity('%s', '%s') with the Indel similarity, expected %.3f got %.3f", inputString, targetString, expected, result)
+		}
+	}
+}
+
+func TestKeyboardDistance(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedDistance   float64
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"cst", "cat", 0.5, 0.833},
+		{"cst", "cut", 1, 0.667},
+		{"HELLO", "hello", 0, 1},
+		{"hrllo", "hello", 0.5, 0.9},
+		{"p@ss", "p2ss", 0, 1},
+		{"12345", "12346", 0.5, 0.9},
+		{"kitten", "sitting", 3, 0.571},
+		{"", "abc", 3, 0},
+		{"", "", 0, 1},
+	}
+
+	for _, currentCase := range cases {
+		distance := KeyboardDistance(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(distance), currentCase.expectedDistance, 3) {
+			t.Errorf("Error in KeyboardDistance('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedDistance, distance)
+		}
+		similarity := KeyboardSimilarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(similarity), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in KeyboardSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, similarity)
+		}
+	}
+
+	// Levenshtein can't tell "cat" and "cut" apart, so it keeps the first, but the keyboard knows "a" is next to "s"
+	validWords := []string{"cut", "cat"}
+	if suggestion := SuggestWord("cst", validWords, LevenshteinSimilarity); suggestion.Word != "cut" {
+		t.Errorf("Error in SuggestWord('cst') with LevenshteinSimilarity, expected cut got %s", suggestion.Word)
+	}
+	if suggestion := SuggestWord("cst", validWords, KeyboardSimilarity); suggestion.Word != "cat" {
+		t.Errorf("Error in SuggestWord('cst') with KeyboardSimilarity, expected cat got %s", suggestion.Word)
+	}
+
+	if neighbours := string(QWERTYNeighbours('S')); neighbours != "adewxz" {
+		t.Errorf("Error in QWERTYNeighbours('S'), expected adewxz got %s", neighbours)
+	}
+	if neighbours := string(QWERTYNeighbours('!')); neighbours != "2q" {
+		t.Errorf("Error in QWERTYNeighbours('!'), expected 2q got %s", neighbours)
+	}
+	if neighbours := QWERTYNeighbours('é'); neighbours != nil {
+		t.Errorf("Error in QWERTYNeighbours('é'), expected nil got %s", string(neighbours))
+	}
+}
+
+func TestCaseAwareLevenshtein(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		caseCost           float32
+		expectedDistance   float64
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"HTTPserver", "HTTPServer", DefaultCaseCost, 0.25, 0.975},
+		{"HTTPserver", "HTTPServer", 1, 1, 0.9},
+		{"HTTPserver", "HTTPServer", 0, 0, 1},
+		{"HTTPserver", "HTTPServer", -1, 0, 1},
+		{"Straße", "STRASSE", DefaultCaseCost, 3, 0.571},
+		{"straße", "STRAẞE", DefaultCaseCost, 1.5, 0.75},
+		{"Ärger", "ärger", DefaultCaseCost, 0.25, 0.95},
+		{"ΣΟΦΟΣ", "σοφος", DefaultCaseCost, 1.25, 0.75},
+		{"kitten", "sitting", DefaultCaseCost, 3, 0.571},
+		{"", "abc", DefaultCaseCost, 3, 0},
+		{"", "", DefaultCaseCost, 0, 1},
+	}
+
+	for _, currentCase := range cases {
+		distance := CaseAwareLevenshtein(currentCase.inputString, currentCase.targetString, currentCase.caseCost)
+		if !compareFloat(float64(distance), currentCase.expectedDistance, 3) {
+			t.Errorf("Error in CaseAwareLevenshtein('%s', '%s', %.2f), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.caseCost, currentCase.expectedDistance, distance)
+		}
+		similarity := NewCaseAwareLevenshteinSimilarity(currentCase.caseCost)(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(similarity), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in NewCaseAwareLevenshteinSimilarity(%.2f)('%s', '%s'), expected %.3f got %.3f", currentCase.caseCost, currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, similarity)
+		}
+	}
+
+	if similarity := CaseAwareLevenshteinSimilarity("HTTPserver", "HTTPServer"); !compareFloat(float64(similarity), 0.975, 3) {
+		t.Errorf("Error in CaseAwareLevenshteinSimilarity('HTTPserver', 'HTTPServer'), expected 0.975 got %.3f", similarity)
+	}
+	if suggestion := SuggestWord("httpserver", []string{"HTTPServe", "HTTPServer"}, CaseAwareLevenshteinSimilarity); suggestion.Word != "HTTPServer" {
+		t.Errorf("Error in SuggestWord('httpserver') with CaseAwareLevenshteinSimilarity, expected HTTPServer got %s", suggestion.Word)
+	}
+}
+
+func TestDamerauLevenshteinWeighted(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		transposeCost      float32
+		editCost           float32
+		expectedDistance   float64
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"ca", "abc", 1, 1, 2, 0.333},
+		{"teh", "the", 1, 1, 1, 0.667},
+		{"teh", "the", 0.6, 1, 0.6, 0.8},
+		{"ca", "abc", 0.6, 1, 1.6, 0.467},
+		{"abcd", "badc", 0.5, 1, 1, 0.75},
+		{"kitten", "sitting", 0.6, 1, 3, 0.571},
+		{"kitten", "sitting", 0.6, 2, 6, 0.571},
+		{"teh", "the", 0.6, 0, 0, 1},
+		{"", "abc", 0.6, 1, 3, 0},
+		{"", "", 0.6, 1, 0, 1},
+	}
+
+	for _, currentCase := range cases {
+		distance := DamerauLevenshteinWeighted(currentCase.inputString, currentCase.targetString, currentCase.transposeCost, currentCase.editCost)
+		if !compareFloat(float64(distance), currentCase.expectedDistance, 3) {
+			t.Errorf("Error in DamerauLevenshteinWeighted('%s', '%s', %.1f, %.1f), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.transposeCost, currentCase.editCost, currentCase.expectedDistance, distance)
+		}
+		similarity := NewDamerauLevenshteinWeightedSimilarity(currentCase.transposeCost, currentCase.editCost)(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(similarity), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in NewDamerauLevenshteinWeightedSimilarity(%.1f, %.1f)('%s', '%s'), expected %.3f got %.3f", currentCase.transposeCost, currentCase.editCost, currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, similarity)
+		}
+	}
+
+	// Unit costs are the normal Damerau–Levenshtein distance, and similarities stay between 0-1 for any costs
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcé")
+	similarity := NewDamerauLevenshteinWeightedSimilarity(0.3, 1.5)
+	for range 500 {
+		inputString := randomString(generator, alphabet, 8)
+		targetString := randomString(generator, alphabet, 8)
+		if distance := DamerauLevenshteinWeighted(inputString, targetString, 1, 1); int(distance) != DamerauLevenshteinDP(inputString, targetString) {
+			t.Errorf("Error in DamerauLevenshteinWeighted('%s', '%s', 1, 1), expected %d got %.3f", inputString, targetString, DamerauLevenshteinDP(inputString, targetString), distance)
+		}
+		if result := similarity(inputString, targetString); result < 0 || result > 1 {
+			t.Errorf("Error in NewDamerauLevenshteinWeightedSimilarity(0.3, 1.5)('%s', '%s'), expected a similarity between 0-1 got %.3f", inputString, targetString, result)
+		}
+	}
+}
+
+func TestDistanceFunc(t *testing.T) {
+	// A table of aliases, where each rune is equal to the ones in the same group
+	aliases := map[rune]int{'0': 1, 'O': 1, 'o': 1, '&': 2, '+': 2}
+	aliasEqual := func(inputRune, targetRune rune) bool {
+		return inputRune == targetRune || (aliases[inputRune] != 0 && aliases[inputRune] == aliases[targetRune])
+	}
+
+	type testCase struct {
+		inputString         string
+		targetString        string
+		equal               RuneEqualFunc
+		expectedLevenshtein int
+		expectedIndel       int
+	}
+
+	cases := []testCase{
+		{"HTTPServer", "httpserver", nil, 5, 10},
+		{"HTTPServer", "httpserver", FoldCase, 0, 0},
+		{"ΣΟΦΟΣ", "σοφος", FoldCase, 0, 0},
+		{"Straße", "STRASSE", FoldCase, 2, 3},
+		{"call 555-1234", "call 555-9876", nil, 4, 8},
+		{"call 555-1234", "call 555-9876", DigitsEqual, 0, 0},
+		{"call 555-1234", "call 555 9876", DigitsEqual, 1, 2},
+		{"new\tyork", "new york", nil, 1, 2},
+		{"new\tyork", "new york", WhitespaceEqual, 0, 0},
+		{"B0B & Co", "BOB + Co", aliasEqual, 0, 0},
+		{"B0B & Co", "bob + co", AnyRuneEqual(aliasEqual, FoldCase), 0, 0},
+		{"B0B & Co", "bob + co", AnyRuneEqual(aliasEqual, nil), 3, 6},
+		{"kitten", "sitting", nil, 3, 5},
+		{"", "abc", FoldCase, 3, 3},
+	}
+
+	for _, currentCase := range cases {
+		result := LevenshteinDistanceFunc(currentCase.inputString, currentCase.targetString, currentCase.equal)
+		if result != currentCase.expectedLevenshtein {
+			t.Errorf("Error in LevenshteinDistanceFunc('%s', '%s'), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.expectedLevenshtein, result)
+		}
+		result = IndelDistanceFunc(currentCase.inputString, currentCase.targetString, currentCase.equal)
+		if result != currentCase.expectedIndel {
+			t.Errorf("Error in IndelDistanceFunc('%s', '%s'), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.expectedIndel, result)
+		}
+	}
+
+	// Exact equality is the same as the normal distances
+	exact := func(inputRune, targetRune rune) bool { return inputRune == targetRune }
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abAé1 ")
+	for range 200 {
+		inputString := randomString(generator, alphabet, 8)
+		targetString := randomString(generator, alphabet, 8)
+		if result := LevenshteinDistanceFunc(inputString, targetString, exact); result != LevenshteinDistance(inputString, targetString) {
+			t.Errorf("Error in LevenshteinDistanceFunc('%s', '%s') with exact equality, expected %d got %d", inputString, targetString, LevenshteinDistance(inputString, targetString), result)
+		}
+		if result := IndelDistanceFunc(inputString, targetString, exact); result != IndelDistance(inputString, targetString) {
+			t.Errorf("Error in IndelDistanceFunc('%s', '%s') with exact equality, expected %d got %d", inputString, targetString, IndelDistance(inputString, targetString), result)
+		}
+	}
+}
+
+func TestNeedlemanWunsch(t *testing.T) {
+	type testCase struct {
+		inputString           string
+		targetString          string
+		match                 int
+		mismatch              int
+		gap                   int
+		expectedScore         int
+		expectedAlignedInput  string
+		expectedAlignedTarget string
+	}
+
+	cases := []testCase{
+		// The example from https://en.wikipedia.org/wiki/Needleman%E2%80%93Wunsch_algorithm
+		{"GCATGCG", "GATTACA", 1, 1, 1, 0, "GCA-TGCG", "G-ATTACA"},
+		{"kitten", "sitting", 1, 1, 1, 1, "kitten-", "sitting"},
+		{"abcdef", "xxabcyydefzz", 2, 1, 1, 6, "--abc--def--", "xxabcyydefzz"},
+		{"héllo", "hello", 1, 1, 1, 3, "héllo", "hello"},
+		{"abc", "", 1, 1, 2, -6, "abc", "---"},
+		{"", "", 1, 1, 1, 0, "", ""},
+	}
+
+	for _, currentCase := range cases {
+		score := NeedlemanWunsch(currentCase.inputString, currentCase.targetString, currentCase.match, currentCase.mismatch, currentCase.gap)
+		if score != currentCase.expectedScore {
+			t.Errorf("Error in NeedlemanWunsch('%s', '%s'), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.expectedScore, score)
+		}
+		alignedInput, alignedTarget := NeedlemanWunschAlignment(currentCase.inputString, currentCase.targetString, currentCase.match, currentCase.mismatch, currentCase.gap)
+		if alignedInput != currentCase.expectedAlignedInput || alignedTarget != currentCase.expectedAlignedTarget {
+			t.Errorf("Error in NeedlemanWunschAlignment('%s', '%s'), expected '%s' and '%s' got '%s' and '%s'", currentCase.inputString, currentCase.targetString, currentCase.expectedAlignedInput, currentCase.expectedAlignedTarget, alignedInput, alignedTarget)
+		}
+	}
+
+	// A substitution matrix where vowels only cost a little to swap for each other
+	isVowel := func(r rune) bool { return strings.ContainsRune("aeiou", r) }
+	vowels := func(inputRune, targetRune rune) int {
+		switch {
+		case inputRune == targetRune:
+			return 2
+		case isVowel(inputRune) && isVowel(targetRune):
+			return 1
+		default:
+			return -2
+		}
+	}
+	score, alignedInput, alignedTarget := NeedlemanWunschWithMatrix("colour", "color", vowels, 1)
+	if score != 9 || alignedInput != "colour" || alignedTarget != "colo-r" {
+		t.Errorf("Error in NeedlemanWunschWithMatrix('colour', 'color'), expected 9, 'colour' and 'colo-r' got %d, '%s' and '%s'", score, alignedInput, alignedTarget)
+	}
+	if score, _, _ := NeedlemanWunschWithMatrix("bat", "bit", vowels, 1); score != 5 {
+		t.Errorf("Error in NeedlemanWunschWithMatrix('bat', 'bit'), expected 5 got %d", score)
+	}
+}
+
+func TestNumericAwareSimilarity(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"Invoice 1024", "Invoice 1025", 1},
+		{"Invoice 1024", "Invoice 9999", 0.701},
+		{"v007", "v7", 1},
+		{"v0", "v10", 0.333},
+		{"id 99999999999999999999", "id 99999999999999999998", 0.978},
+		{"a1b", "a1", 0.667},
+		{"1a", "a1", 0.5},
+		{"kitten", "sitting", 0.769},
+		{"abc", "", 0},
+		{"", "", 1},
+	}
+
+	for _, currentCase := range cases {
+		result := NumericAwareSimilarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in NumericAwareSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+	}
+
+	// Close numbers rank higher, where Levenshtein only counts the changed digits
+	validWords := []string{"Invoice 9024", "Invoice 1030"}
+	if suggestion := SuggestWord("Invoice 1024", validWords, LevenshteinSimilarity); suggestion.Word != "Invoice 9024" {
+		t.Errorf("Error in SuggestWord('Invoice 1024') with LevenshteinSimilarity, expected Invoice 9024 got %s", suggestion.Word)
+	}
+	if suggestion := SuggestWord("Invoice 1024", validWords, NumericAwareSimilarity); suggestion.Word != "Invoice 1030" {
+		t.Errorf("Error in SuggestWord('Invoice 1024') with NumericAwareSimilarity, expected Invoice 1030 got %s", suggestion.Word)
+	}
+
+	// Strings without digits are the same as the text algorithm
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcé ")
+	numericJaro := NewNumericAwareSimilarity(JaroSimilarity)
+	for range 200 {
+		inputString := randomString(generator, alphabet, 8)
+		targetString := randomString(generator, alphabet, 8)
+		if result := numericJaro(inputString, targetString); result != JaroSimilarity(inputString, targetString) {
+			t.Errorf("Error in NewNumericAwareSimilarity(JaroSimilarity)('%s', '%s'), expected %.3f got %.3f", inputString, targetString, JaroSimilarity(inputString, targetString), result)
+		}
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	// The built-in algorithms are registered under their canonical names
+	builtIn := map[string]SimilarityAlgorithm{
+		"jaro":                JaroSimilarity,
+		"jaro_winkler":        JaroWinklerSimilarity,
+		"levenshtein":         LevenshteinSimilarity,
+		"damerau_levenshtein": DamerauLevenshteinSimilarity,
+		"indel":               IndelSimilarity,
+		"hamming":             HammingSimilarity,
+	}
+	for name, expected := range builtIn {
+		algorithm, exists := GetAlgorithm(name)
+		if !exists {
+			t.Errorf("Error in GetAlgorithm('%s'), expected it to be registered", name)
+			continue
+		}
+		if result := algorithm("almni", "alumni"); result != expected("almni", "alumni") {
+			t.Errorf("Error in GetAlgorithm('%s'), expected %.3f for ('almni', 'alumni') got %.3f", name, expected("almni", "alumni"), result)
+		}
+	}
+
+	if algorithm, exists := GetAlgorithm("Levenshtein"); exists || algorithm != nil {
+		t.Errorf("Error in GetAlgorithm('Levenshtein'), expected names to be case sensitive")
+	}
+
+	names := ListAlgorithms()
+	if !slices.IsSorted(names) {
+		t.Errorf("Error in ListAlgorithms(), expected sorted names got %v", names)
+	}
+	for _, name := range names {
+		if algorithm, _ := GetAlgorithm(name); algorithm("alumni", "alumni") != 1 {
+			t.Errorf("Error in GetAlgorithm('%s'), expected identical strings to have a similarity of 1", name)
+		}
+	}
+
+	// Registering from several goroutines while others read
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterAlgorithm(fmt.Sprintf("test_%d", i), func(inputString, targetString string) float32 {
+				if inputString == targetString {
+					return 1
+				}
+				return 0.5
+			})
+		}()
+		go func() {
+			defer wg.Done()
+			GetAlgorithm("levenshtein")
+			ListAlgorithms()
+		}()
+	}
+	wg.Wait()
+	for i := range 8 {
+		name := fmt.Sprintf("test_%d", i)
+		if algorithm, exists := GetAlgorithm(name); !exists || algorithm("a", "b") != 0.5 {
+			t.Errorf("Error in RegisterAlgorithm('%s'), expected GetAlgorithm() to find it", name)
+		}
+		if !slices.Contains(ListAlgorithms(), name) {
+			t.Errorf("Error in ListAlgorithms(), expected it to contain %s", name)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Error in RegisterAlgorithm('nil', nil), expected a panic")
+		}
+	}()
+	RegisterAlgorithm("nil", nil)
+}
+
+func TestWhitespaceInsensitive(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		removeAll          bool
+		expectedSimilarity float64
+		expectedDistance   int
+	}
+
+	cases := []testCase{
+		{"new  york", "new york", false, 1, 0},
+		{" new\tyork\n", "new york", false, 1, 0},
+		{"new\u00a0york", "new york", false, 1, 0},
+		{"newyork", "new york", false, 0.933, 1},
+		{"newyork", "new york", true, 1, 0},
+		{"new  york", "ne wyork", true, 1, 0},
+		{"new yrok", "new york", false, 0.875, 2},
+		{"   ", "", false, 1, 0},
+	}
+
+	for _, currentCase := range cases {
+		similarity := WhitespaceInsensitive(LevenshteinSimilarity, currentCase.removeAll)(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(similarity), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in WhitespaceInsensitive(LevenshteinSimilarity, %t)(%q, %q), expected %.3f got %.3f", currentCase.removeAll, currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, similarity)
+		}
+		distance := WhitespaceInsensitiveDistance(LevenshteinDistance, currentCase.removeAll)(currentCase.inputString, currentCase.targetString)
+		if distance != currentCase.expectedDistance {
+			t.Errorf("Error in WhitespaceInsensitiveDistance(LevenshteinDistance, %t)(%q, %q), expected %d got %d", currentCase.removeAll, currentCase.inputString, currentCase.targetString, currentCase.expectedDistance, distance)
+		}
+	}
+
+	// The corpus is normalized the same way, but the suggestion is the word as it was written
+	validWords := []string{"New  York", "Newark"}
+	suggestion := SuggestWord("new york", validWords, CaseInsensitive(WhitespaceInsensitive(JaroSimilarity, true)))
+	if suggestion.Word != "New  York" || suggestion.Likelihood != 1 {
+		t.Errorf("Error in SuggestWord('new york') with WhitespaceInsensitive, expected New  York with a likelihood of 1 got %v", suggestion)
+	}
+}
+
+func TestConfiguredAlgorithm(t *testing.T) {
+	type testCase struct {
+		name     string
+		result   float32
+		expected float32
+	}
+
+	cases := []testCase{
+		// The defaults are the same as the plain algorithms
+		{"JaroWinklerFunc", NewConfiguredAlgorithm(JaroWinklerFunc)("MARTHA", "MARHTA"), JaroWinklerSimilarity("MARTHA", "MARHTA")},
+		{"NGramFunc", NewConfiguredAlgorithm(NGramFunc)("alumni", "almni"), TrigramSimilarity("alumni", "almni")},
+		{"JaccardFunc", NewConfiguredAlgorithm(JaccardFunc)("alumni", "almni"), JaccardTrigramSimilarity("alumni", "almni")},
+		// Options change the parameters
+		{"WithPrefixScale(0.2)", NewConfiguredAlgorithm(JaroWinklerFunc, WithPrefixScale(0.2))("MARTHA", "MARHTA"), JaroWinklerSimilarityWithPrefix("MARTHA", "MARHTA", 0.2)},
+		{"WithPrefixScale(5)", NewConfiguredAlgorithm(JaroWinklerFunc, WithPrefixScale(5))("MARTHA", "MARHTA"), JaroWinklerSimilarityWithPrefix("MARTHA", "MARHTA", 0.25)},
+		{"WithNGramSize(2)", NewConfiguredAlgorithm(JaccardFunc, WithNGramSize(2))("alumni", "almni"), JaccardBigramSimilarity("alumni", "almni")},
+		{"WithNGramSize(-1)", NewConfiguredAlgorithm(NGramFunc, WithNGramSize(-1))("alumni", "almni"), NGramSimilarity("alumni", "almni", 1)},
+		{"WithCaseFold(true)", NewConfiguredAlgorithm(IgnoreConfig(LevenshteinSimilarity), WithCaseFold(true))("HELLO", "hello"), 1},
+		{"WithCaseFold(false)", NewConfiguredAlgorithm(IgnoreConfig(LevenshteinSimilarity), WithCaseFold(false))("HELLO", "hello"), LevenshteinSimilarity("HELLO", "hello")},
+		{"WeightedLevenshteinFunc", NewConfiguredAlgorithm(WeightedLevenshteinFunc)("cst", "cat"), KeyboardSimilarity("cut", "cat")},
+		{"WithQWERTYDistance()", NewConfiguredAlgorithm(WeightedLevenshteinFunc, WithQWERTYDistance())("cst", "cat"), 0.833},
+		{"WithQWERTYDistance(), distant keys", NewConfiguredAlgorithm(WeightedLevenshteinFunc, WithQWERTYDistance())("cut", "cat"), 0.667},
+		{"WithSubstitutionCost(nil)", NewConfiguredAlgorithm(WeightedLevenshteinFunc, WithQWERTYDistance(), WithSubstitutionCost(nil))("cst", "cat"), 0.667},
+		{"WithVisualSimilarity()", NewConfiguredAlgorithm(WeightedLevenshteinFunc, WithVisualSimilarity())("INV0ICE", "INVOICE"), 0.964},
+		// Later options win
+		{"WithNGramSize(2), WithNGramSize(3)", NewConfiguredAlgorithm(NGramFunc, WithNGramSize(2), WithNGramSize(3))("alumni", "almni"), TrigramSimilarity("alumni", "almni")},
+	}
+
+	for _, currentCase := range cases {
+		if !compareFloat(float64(currentCase.result), float64(currentCase.expected), 3) {
+			t.Errorf("Error in NewConfiguredAlgorithm() with %s, expected %.3f got %.3f", currentCase.name, currentCase.expected, currentCase.result)
+		}
+	}
+
+	// Configured algorithms are SimilarityAlgorithms, so they work anywhere one does
+	algorithm := NewConfiguredAlgorithm(JaroWinklerFunc, WithPrefixScale(0.25), WithCaseFold(true))
+	if suggestion := SuggestWord("JONH", []string{"Jane", "John", "Joan"}, algorithm); suggestion.Word != "John" {
+		t.Errorf("Error in SuggestWord('JONH') with a configured algorithm, expected John got %s", suggestion.Word)
+	}
+}
+
+func TestLevenshteinWithRules(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedDistance   float64
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"cornpany", "company", 0.25, 0.969},
+		{"company", "cornpany", 0.25, 0.969},
+		{"c1ose", "close", 0.25, 0.95},
+		{"AppIe", "Apple", 0.25, 0.95},
+		{"0rder", "Order", 0.25, 0.95},
+		{"vvorld", "world", 0.25, 0.958},
+		{"clog", "dog", 0.25, 0.938},
+		{"rnodern", "modem", 0.5, 0.929},
+		{"kitten", "sitting", 3, 0.571},
+		{"", "abc", 3, 0},
+		{"", "", 0, 1},
+	}
+
+	for _, currentCase := range cases {
+		distance := LevenshteinWithRules(currentCase.inputString, currentCase.targetString, OCRCosts)
+		if !compareFloat(float64(distance), currentCase.expectedDistance, 3) {
+			t.Errorf("Error in LevenshteinWithRules('%s', '%s', OCRCosts), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedDistance, distance)
+		}
+		similarity := OCRSimilarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(similarity), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in OCRSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, similarity)
+		}
+	}
+
+	// Without rules it's the normal Levenshtein distance
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("rnmcé")
+	for range 200 {
+		inputString := randomString(generator, alphabet, 8)
+		targetString := randomString(generator, alphabet, 8)
+		if distance := LevenshteinWithRules(inputString, targetString, nil); int(distance) != LevenshteinDistance(inputString, targetString) {
+			t.Errorf("Error in LevenshteinWithRules('%s', '%s', nil), expected %d got %.3f", inputString, targetString, LevenshteinDistance(inputString, targetString), distance)
+		}
+		if distance := LevenshteinWithRules(inputString, targetString, OCRCosts); distance > float32(LevenshteinDistance(inputString, targetString)) {
+			t.Errorf("Error in LevenshteinWithRules('%s', '%s', OCRCosts), expected at most %d got %.3f", inputString, targetString, LevenshteinDistance(inputString, targetString), distance)
+		}
+	}
+
+	// Empty and negative rules
+	rules := []SubstitutionRule{{"", "x", 0}, {"ab", "c", -1}}
+	if distance := LevenshteinWithRules("xab", "c", rules); distance != 1 {
+		t.Errorf("Error in LevenshteinWithRules('xab', 'c'), expected 1 got %.3f", distance)
+	}
+
+	// Scanned line items against product names
+	products := []string{"Cornflakes 500g", "Company Mug", "Carpet Cleaner"}
+	if suggestion := SuggestWord("Cornpany Mug", products, OCRSimilarity); suggestion.Word != "Company Mug" || suggestion.Likelihood < 0.95 {
+		t.Errorf("Error in SuggestWord('Cornpany Mug') with OCRSimilarity, expected Company Mug above 0.95 got %v", suggestion)
+	}
+}
+
+func TestSift4(t *testing.T) {
+	type testCase struct {
+		inputString      string
+		targetString     string
+		maxOffset        int
+		expectedDistance int
+	}
+
+	cases := []testCase{
+		// Examples from https://github.com/tdebatty/java-string-similarity
+		{"This is the first string", "And this is another string", 5, 11},
+		{"Lorem ipsum dolor sit amet, consectetur adipiscing elit.", "Amet Lorm ispum dolor sit amet, consetetur adixxxpiscing elit.", 10, 12},
+		// Typos are usually the same as Levenshtein
+		{"kitten", "sitting", 0, 3},
+		{"almni", "alumni", 0, 1},
+		{"héllo", "hello", 0, 1},
+		// Transpositions only count once, like Damerau–Levenshtein
+		{"convesre", "converse", 0, 1},
+		{"ab", "ba", 0, 1},
+		{"", "abc", 0, 3},
+		{"", "", 0, 0},
+	}
+
+	for _, currentCase := range cases {
+		result := Sift4Distance(currentCase.inputString, currentCase.targetString, currentCase.maxOffset)
+		if result != currentCase.expectedDistance {
+			t.Errorf("Error in Sift4Distance('%s', '%s', %d), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.maxOffset, currentCase.expectedDistance, result)
+		}
+	}
+
+	// Compare against Levenshtein on strings with one or two typos
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcdefghij")
+	same, total := 0, 1000
+	for range total {
+		inputRunes := []rune(randomString(generator, alphabet, 20))
+		targetRunes := slices.Clone(inputRunes)
+		for range 1 + generator.Intn(2) {
+			if len(targetRunes) == 0 {
+				break
+			}
+			position := generator.Intn(len(targetRunes))
+			switch generator.Intn(3) {
+			case 0:
+				targetRunes[position] = alphabet[generator.Intn(len(alphabet))]
+			case 1:
+				targetRunes = slices.Delete(targetRunes, position, position+1)
+			default:
+				targetRunes = slices.Insert(targetRunes, position, alphabet[generator.Intn(len(alphabet))])
+			}
+		}
+
+		distance := Sift4Distance(string(inputRunes), string(targetRunes), 0)
+		exact := LevenshteinDistance(string(inputRunes), string(targetRunes))
+		if distance == exact {
+			same += 1
+		}
+		if distance < 0 || distance > max(len(inputRunes), len(targetRunes)) {
+			t.Errorf("Error in Sift4Distance('%s', '%s'), expected a distance between 0 and the longer length got %d", string(inputRunes), string(targetRunes), distance)
+		}
+	}
+	if same < total*8/10 {
+		t.Errorf("Error in Sift4Distance(), expected at least 80%% of typos to match LevenshteinDistance got %d/%d", same, total)
+	}
+
+	if result := Sift4Similarity("almni", "alumni"); result != LevenshteinSimilarity("almni", "alumni") {
+		t.Errorf("Error in Sift4Similarity('almni', 'alumni'), expected %.3f got %.3f", LevenshteinSimilarity("almni", "alumni"), result)
+	}
+}
+
+func BenchmarkSift4(b *testing.B) {
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcdefghijklmnopqrstuvwxyz ")
+
+	for _, length := range []int{1000, 5000} {
+		inputRunes := make([]rune, length)
+		for i := range inputRunes {
+			inputRunes[i] = alphabet[generator.Intn(len(alphabet))]
+		}
+		// A copy with a typo every 50 runes, like a long description that's been retyped
+		targetRunes := slices.Clone(inputRunes)
+		for i := 0; i < len(targetRunes); i += 50 {
+			targetRunes[i] = alphabet[generator.Intn(len(alphabet))]
+		}
+		inputString, targetString := string(inputRunes), string(targetRunes)
+
+		b.Run(fmt.Sprintf("Sift4Distance %d runes", length), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				Sift4Distance(inputString, targetString, DefaultSift4MaxOffset)
+			}
+		})
+		b.Run(fmt.Sprintf("DynamicLevenshtein %d runes", length), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				DynamicLevenshtein(inputString, targetString)
+			}
+		})
+	}
+}
+
+func TestStripDiacritics(t *testing.T) {
+	type testCase struct {
+		inputString string
+		expected    string
+	}
+
+	cases := []testCase{
+		// Spanish
+		{"mañana", "manana"},
+		{"árbol, canción, pingüino", "arbol, cancion, pinguino"},
+		{"¿Qué tal?", "¿Que tal?"},
+		// French
+		{"résumé", "resume"},
+		{"naïve", "naive"},
+		{"garçon à l'hôpital", "garcon a l'hopital"},
+		{"Noël", "Noel"},
+		// German
+		{"Müller", "Muller"},
+		{"Ärger über Öl", "Arger uber Ol"},
+		{"Straße", "Straße"},
+		// Portuguese
+		{"São João", "Sao Joao"},
+		{"ação", "acao"},
+		{"você", "voce"},
+		// Already decomposed
+		{"re\u0301sume\u0301", "resume"},
+		// Non-Latin scripts are left as is
+		{"日本語", "日本語"},
+		{"がぎ", "がぎ"},
+		{"हिन्दी", "हिन्दी"},
+		{"한국어", "한국어"},
+		{"ελληνικά", "ελληνικά"},
+		{"русский й", "русский й"},
+		{"", ""},
+	}
+
+	for _, currentCase := range cases {
+		if result := StripDiacritics(currentCase.inputString); result != currentCase.expected {
+			t.Errorf("Error in StripDiacritics(%q), expected %q got %q", currentCase.inputString, currentCase.expected, result)
+		}
+	}
+
+	similarity := WithDiacriticStripping(LevenshteinSimilarity)
+	for _, pair := range [][2]string{{"resumé", "resume"}, {"naïve", "naive"}, {"Müller", "Muller"}, {"São Paulo", "Sao Paulo"}} {
+		if result := similarity(pair[0], pair[1]); result != 1 {
+			t.Errorf("Error in WithDiacriticStripping(LevenshteinSimilarity)(%q, %q), expected 1 got %.3f", pair[0], pair[1], result)
+		}
+	}
+	if result := similarity("resumé", "resumed"); !compareFloat(float64(result), 0.923, 3) {
+		t.Errorf("Error in WithDiacriticStripping(LevenshteinSimilarity)('resumé', 'resumed'), expected 0.923 got %.3f", result)
+	}
+}
+
+func TestSift3(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		maxOffset          int
+		expectedDistance   int
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"kitten", "sitting", 0, 3, 0.769},
+		{"héllo", "hello", 0, 1, 0.909},
+		{"abc", "ab", 0, 1, 0.8},
+		// Rougher than Levenshtein, half a rune of the average length is rounded up
+		{"almni", "alumni", 0, 2, 0.818},
+		// Transpositions aren't handled
+		{"ab", "ba", 0, 2, 0.5},
+		{"convesre", "converse", 0, 2, 0.875},
+		{"This is the first string", "And this is another string", 5, 12, 0.76},
+		{"resume", "resume", 0, 0, 1},
+		{"abc", "xyz", 0, 3, 0.5},
+		{"", "abc", 0, 3, 0},
+		{"", "", 0, 0, 1},
+	}
+
+	for _, currentCase := range cases {
+		result := Sift3Distance(currentCase.inputString, currentCase.targetString, currentCase.maxOffset)
+		if result != currentCase.expectedDistance {
+			t.Errorf("Error in Sift3Distance('%s', '%s', %d), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.maxOffset, currentCase.expectedDistance, result)
+		}
+		if currentCase.maxOffset != 0 {
+			continue
+		}
+		similarity := Sift3Similarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(similarity), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in Sift3Similarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, similarity)
+		}
+	}
+
+	// Only identical strings have a distance of 0
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcé")
+	for range 1000 {
+		inputString := randomString(generator, alphabet, 8)
+		targetString := randomString(generator, alphabet, 8)
+		if result := Sift3Distance(inputString, targetString, 0); (result == 0) != (inputString == targetString) {
+			t.Errorf("Error in Sift3Distance('%s', '%s'), got %d", inputString, targetString, result)
+		}
+	}
+}
+
+func TestMongeElkan(t *testing.T) {
+	type testCase struct {
+		inputString         string
+		targetString        string
+		expectedSimilarity  float64
+		expectedSymmetric   float64
+		expectedLevenshtein float64
+	}
+
+	cases := []testCase{
+		{"university of calgary", "calgary university", 0.667, 0.833, 0.741},
+		{"calgary university", "university of calgary", 1, 0.833, 1},
+		{"univrsity of calgary", "university of calgary", 0.989, 0.989, 0.982},
+		{"paul johnson", "johson paule", 0.915, 0.915, 0.906},
+		{"new  york", "new york", 1, 1, 1},
+		{"", "abc", 0, 0, 0},
+		{"abc", "   ", 0, 0, 0},
+		{"  ", "", 1, 1, 1},
+	}
+
+	for _, currentCase := range cases {
+		similarity := MongeElkanSimilarity(currentCase.inputString, currentCase.targetString, nil)
+		if !compareFloat(float64(similarity), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in MongeElkanSimilarity('%s', '%s', nil), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, similarity)
+		}
+		symmetric := NewMongeElkanSimilarity(JaroSimilarity, true)(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(symmetric), currentCase.expectedSymmetric, 3) {
+			t.Errorf("Error in SymmetricMongeElkanSimilarity('%s', '%s', JaroSimilarity), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSymmetric, symmetric)
+		}
+		levenshtein := NewMongeElkanSimilarity(LevenshteinSimilarity, false)(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(levenshtein), currentCase.expectedLevenshtein, 3) {
+			t.Errorf("Error in MongeElkanSimilarity('%s', '%s', LevenshteinSimilarity), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedLevenshtein, levenshtein)
+		}
+	}
+}
+
+func TestNormalizationPipeline(t *testing.T) {
+	type testCase struct {
+		normalizer func(string) string
+		name       string
+		input      string
+		expected   string
+	}
+
+	cases := []testCase{
+		{FoldCaseString, "FoldCaseString", "Hello WORLD", "hello world"},
+		{FoldCaseString, "FoldCaseString", "Straße", "strasse"},
+		{FoldCaseString, "FoldCaseString", "ΣΊΣΥΦΟΣ", "σίσυφοσ"},
+		{StripDiacritics, "StripDiacritics", "Crème Brûlée", "Creme Brulee"},
+		{CollapseWhitespace, "CollapseWhitespace", " new\t york\n city ", "new york city"},
+		{StripPunctuation, "StripPunctuation", "o'neil-smith, jr.", "oneilsmith jr"},
+		{StripPunctuation, "StripPunctuation", "¿$5 + 3?", "$5 + 3"},
+		{TrimSpace, "TrimSpace", "\t new  york \n", "new  york"},
+	}
+
+	for _, currentCase := range cases {
+		if result := currentCase.normalizer(currentCase.input); result != currentCase.expected {
+			t.Errorf("Error in %s(%q), expected %q got %q", currentCase.name, currentCase.input, currentCase.expected, result)
+		}
+	}
+
+	pipeline := NewPipeline(FoldCaseString, StripPunctuation, StripDiacritics, CollapseWhitespace)
+	if result := pipeline.Apply("  Crème-Brûlée,  S'il Vous Plaît! "); result != "cremebrulee sil vous plait" {
+		t.Errorf("Error in NormalizationPipeline.Apply(), expected %q got %q", "cremebrulee sil vous plait", result)
+	}
+	if result := NewPipeline().Apply(" Unchanged "); result != " Unchanged " {
+		t.Errorf("Error in NormalizationPipeline.Apply() with no normalizers, expected %q got %q", " Unchanged ", result)
+	}
+
+	similarity := pipeline.WrapAlgorithm(LevenshteinSimilarity)
+	if result := similarity("Résumé, Final!", "resume  final"); result != 1 {
+		t.Errorf("Error in NormalizationPipeline.WrapAlgorithm(LevenshteinSimilarity)('Résumé, Final!', 'resume  final'), expected 1 got %.3f", result)
+	}
+
+	// Applying a normalizer twice is the same as applying it once
+	normalizers := map[string]func(string) string{
+		"FoldCaseString":     FoldCaseString,
+		"StripDiacritics":    StripDiacritics,
+		"CollapseWhitespace": CollapseWhitespace,
+		"StripPunctuation":   StripPunctuation,
+		"TrimSpace":          TrimSpace,
+		"Pipeline":           pipeline.Apply,
+	}
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("aAéÉßΣς \t\n.,'-!¿ﬁİ\u0301")
+	inputs := []string{}
+	for _, currentCase := range cases {
+		inputs = append(inputs, currentCase.input)
+	}
+	for range 1000 {
+		inputs = append(inputs, randomString(generator, alphabet, 12))
+	}
+	for name, normalizer := range normalizers {
+		for _, input := range inputs {
+			once := normalizer(input)
+			if twice := normalizer(once); twice != once {
+				t.Errorf("Error in %s(%q), applying it twice gave %q instead of %q", name, input, twice, once)
+			}
+		}
+	}
+}
+
+func TestNGramDistance(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		n                  int
+		expectedSimilarity float64
+	}
+
+	// The similarities (1 - distance) from the tests of Apache Lucene's NGramDistance
+	cases := []testCase{
+		{"al", "al", 1, 1},
+		{"a", "a", 1, 1},
+		{"b", "a", 1, 0},
+		{"martha", "marhta", 1, 0.667},
+		{"jones", "johnson", 1, 0.429},
+		{"natural", "contrary", 1, 0.25},
+		{"abcvwxyz", "cabvwxyz", 1, 0.75},
+		{"dwayne", "duane", 1, 0.667},
+		{"dixon", "dicksonx", 1, 0.5},
+		{"six", "ten", 1, 0},
+		{"al", "al", 2, 1},
+		{"a", "a", 2, 1},
+		{"b", "a", 2, 0},
+		{"martha", "marhta", 2, 0.667},
+		{"jones", "johnson", 2, 0.429},
+		{"natural", "contrary", 2, 0.25},
+		{"abcvwxyz", "cabvwxyz", 2, 0.625},
+		{"dwayne", "duane", 2, 0.583},
+		{"dixon", "dicksonx", 2, 0.5},
+		{"six", "ten", 2, 0},
+		{"al", "al", 3, 1},
+		{"a", "a", 3, 1},
+		{"b", "a", 3, 0},
+		{"martha", "marhta", 3, 0.722},
+		{"jones", "johnson", 3, 0.476},
+		{"natural", "contrary", 3, 0.208},
+		{"abcvwxyz", "cabvwxyz", 3, 0.5625},
+		{"dwayne", "duane", 3, 0.528},
+		{"dixon", "dicksonx", 3, 0.458},
+		{"six", "ten", 3, 0},
+		// Operates on runes, and defaults to bigrams
+		{"héllo", "hello", 0, 0.8},
+		{"", "abc", 0, 0},
+		{"", "", 0, 1},
+	}
+
+	for _, currentCase := range cases {
+		result := 1 - NGramDistance(currentCase.inputString, currentCase.targetString, currentCase.n)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in NGramDistance('%s', '%s', %d), expected a similarity of %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.n, currentCase.expectedSimilarity, result)
+		}
+		if currentCase.n != 2 && currentCase.n != 0 {
+			continue
+		}
+		if similarity := KondrakNGramSimilarity(currentCase.inputString, currentCase.targetString); !compareFloat(float64(similarity), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in KondrakNGramSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, similarity)
+		}
+	}
+
+	// With n=1 it's the normalized Levenshtein distance
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcé")
+	for range 1000 {
+		inputString := randomString(generator, alphabet, 8)
+		targetString := randomString(generator, alphabet, 8)
+		longestLength := max(len([]rune(inputString)), len([]rune(targetString)))
+		if longestLength == 0 {
+			continue
+		}
+		expected := float64(DynamicLevenshtein(inputString, targetString)) / float64(longestLength)
+		if result := NGramDistance(inputString, targetString, 1); !compareFloat(float64(result), expected, 3) {
+			t.Errorf("Error in NGramDistance('%s', '%s', 1), expected %.3f got %.3f", inputString, targetString, expected, result)
+		}
+	}
+}
+
+func TestCachedAlgorithm(t *testing.T) {
+	calls := 0
+	counted := func(inputString, targetString string) float32 {
+		calls += 1
+		return LevenshteinSimilarity(inputString, targetString)
+	}
+
+	cache := NewCachedAlgorithm(counted, 2)
+	if result := cache.Similarity("almni", "alumni"); !compareFloat(float64(result), float64(LevenshteinSimilarity("almni", "alumni")), 3) {
+		t.Errorf("Error in CachedAlgorithm.Similarity('almni', 'alumni'), expected %.3f got %.3f", LevenshteinSimilarity("almni", "alumni"), result)
+	}
+	// The pair is cached regardless of order
+	cache.Similarity("alumni", "almni")
+	cache.Similarity("almni", "alumni")
+	if hits, misses := cache.CacheStats(); hits != 2 || misses != 1 || calls != 1 {
+		t.Errorf("Error in CachedAlgorithm.CacheStats(), expected 2 hits, 1 miss and 1 call got %d hits, %d misses and %d calls", hits, misses, calls)
+	}
+
+	// Using ("almni", "alumni") makes ("helo", "hello") the least recently used, so it's removed when the cache is full
+	cache.Similarity("helo", "hello")
+	cache.Similarity("almni", "alumni")
+	cache.Similarity("bonjur", "bonjour")
+	if cache.Len() != 2 {
+		t.Errorf("Error in CachedAlgorithm.Len(), expected 2 got %d", cache.Len())
+	}
+	calls = 0
+	cache.Similarity("alumni", "almni")
+	cache.Similarity("bonjour", "bonjur")
+	if calls != 0 {
+		t.Errorf("Error in CachedAlgorithm, expected the most recently used pairs to be cached but the algorithm was called %d times", calls)
+	}
+	cache.Similarity("helo", "hello")
+	if calls != 1 {
+		t.Errorf("Error in CachedAlgorithm, expected the least recently used pair to be removed but the algorithm was called %d times", calls)
+	}
+
+	// Safe to use from multiple goroutines, every lookup is either a hit or a miss
+	shared := NewCachedAlgorithm(JaroSimilarity, 8)
+	words := []string{"alumni", "almni", "hello", "helo", "bonjour", "bonjur"}
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				inputString, targetString := words[(i+j)%len(words)], words[j%len(words)]
+				if result := shared.Similarity(inputString, targetString); result != JaroSimilarity(inputString, targetString) {
+					t.Errorf("Error in CachedAlgorithm.Similarity('%s', '%s'), expected %.3f got %.3f", inputString, targetString, JaroSimilarity(inputString, targetString), result)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if hits, misses := shared.CacheStats(); hits+misses != 800 || shared.Len() > 8 {
+		t.Errorf("Error in CachedAlgorithm from multiple goroutines, expected 800 lookups and at most 8 entries got %d hits, %d misses and %d entries", hits, misses, shared.Len())
+	}
+
+	// Can be passed anywhere a SimilarityAlgorithm is expected
+	suggestion := SuggestWord("almni", words, NewCachedAlgorithm(LevenshteinSimilarity, 0).Similarity)
+	if suggestion.Word != "almni" {
+		t.Errorf("Error in SuggestWord('almni') with CachedAlgorithm, expected almni got %v", suggestion)
+	}
+}
+
+func TestMemoizedDistance(t *testing.T) {
+	levenshtein := MemoizedDistance(RecursiveLevenshteinStep)
+	indel := MemoizedDistance(RecursiveIndelStep)
+
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcd")
+	for range 1000 {
+		inputString := randomString(generator, alphabet, 10)
+		targetString := randomString(generator, alphabet, 10)
+		if result, expected := levenshtein(inputString, targetString), DynamicLevenshtein(inputString, targetString); result != expected {
+			t.Errorf("Error in MemoizedDistance(RecursiveLevenshteinStep)('%s', '%s'), expected %d got %d", inputString, targetString, expected, result)
+		}
+		if result, expected := indel(inputString, targetString), IndelDistance(inputString, targetString); result != expected {
+			t.Errorf("Error in MemoizedDistance(RecursiveIndelStep)('%s', '%s'), expected %d got %d", inputString, targetString, expected, result)
+		}
+	}
+
+	// Each subproblem is only calculated once
+	calls := 0
+	counted := MemoizedDistance(func(inputString, targetString string, recurse DistanceAlgorithm) int {
+		calls += 1
+		return RecursiveLevenshteinStep(inputString, targetString, recurse)
+	})
+	if result := counted("abcdefghijklmnopqrst", "tsrqponmlkjihgfedcba"); result != 20 {
+		t.Errorf("Error in MemoizedDistance(RecursiveLevenshteinStep) on 20 character strings, expected 20 got %d", result)
+	}
+	if calls > 21*21 {
+		t.Errorf("Error in MemoizedDistance(RecursiveLevenshteinStep), expected at most %d subproblems got %d", 21*21, calls)
+	}
+}
+
+func TestRecursiveLevenshteinUnicode(t *testing.T) {
+	type testCase struct {
+		inputString      string
+		targetString     string
+		expectedDistance int
+	}
+
+	cases := []testCase{
+		{"café", "cafe", 1},
+		{"naïve", "naive", 1},
+		{"日本語", "日本", 1},
+		{"日本語", "中国語", 2},
+		{"straße", "strasse", 2},
+		{"🙂🙃", "🙃🙂", 2},
+		{"Ωmega", "omega", 1},
+		{"", "ü", 1},
+	}
+
+	step := MemoizedDistance(RecursiveLevenshteinStep)
+	for _, currentCase := range cases {
+		if expected := DynamicLevenshtein(currentCase.inputString, currentCase.targetString); expected != currentCase.expectedDistance {
+			t.Errorf("Error in DynamicLevenshtein('%s', '%s'), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.expectedDistance, expected)
+		}
+		if result := RecursiveLevenshtein(currentCase.inputString, currentCase.targetString); result != currentCase.expectedDistance {
+			t.Errorf("Error in RecursiveLevenshtein('%s', '%s'), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.expectedDistance, result)
+		}
+		if result := step(currentCase.inputString, currentCase.targetString); result != currentCase.expectedDistance {
+			t.Errorf("Error in MemoizedDistance(RecursiveLevenshteinStep)('%s', '%s'), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.expectedDistance, result)
+		}
+	}
+
+	generator := rand.New(rand.NewSource(306))
+	alphabet := []rune("aäéü日本🙂")
+	for range 500 {
+		inputString := randomString(generator, alphabet, 12)
+		targetString := randomString(generator, alphabet, 12)
+		expected := DynamicLevenshtein(inputString, targetString)
+		if result := RecursiveLevenshtein(inputString, targetString); result != expected {
+			t.Errorf("Error in RecursiveLevenshtein('%s', '%s'), expected %d got %d", inputString, targetString, expected, result)
+		}
+		if result := step(inputString, targetString); result != expected {
+			t.Errorf("Error in MemoizedDistance(RecursiveLevenshteinStep)('%s', '%s'), expected %d got %d", inputString, targetString, expected, result)
+		}
+	}
+
+	// Long strings finish now that each pair of suffixes is only calculated once
+	inputString := randomString(generator, alphabet, 300)
+	targetString := randomString(generator, alphabet, 300)
+	if result, expected := RecursiveLevenshtein(inputString, targetString), DynamicLevenshtein(inputString, targetString); result != expected {
+		t.Errorf("Error in RecursiveLevenshtein() on long strings, expected %d got %d", expected, result)
+	}
+}
+
+func BenchmarkMemoizedDistance(b *testing.B) {
+	inputString := "abcdefghijklmnopqrst"
+	targetString := "abdcefhgijkmlnopqsrt"
+	levenshtein := MemoizedDistance(RecursiveLevenshteinStep)
+
+	b.Run("MemoizedDistance(RecursiveLevenshteinStep)", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			levenshtein(inputString, targetString)
+		}
+	})
+	b.Run("DynamicLevenshtein", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			DynamicLevenshtein(inputString, targetString)
+		}
+	})
+}
+
+func TestTFIDFScorer(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+	}
+
+	scorer := NewTFIDFScorer([]string{"acme inc", "globex inc", "initech inc", "hooli inc", "umbrella corp", "acme labs"})
+	cases := []testCase{
+		// "inc" is in most of the corpus, so sharing it counts for less than sharing "acme"
+		{"acme inc", "acme labs", 0.514},
+		{"acme inc", "globex inc", 0.299},
+		{"hooli inc", "hooli", 0.860},
+		// Tokens that aren't in the corpus get the highest weight
+		{"wayne inc", "wayne corp", 0.723},
+		{"acme acme inc", "acme inc", 0.961},
+		{"inc acme", "acme inc", 1},
+		{"acme inc", "acme inc", 1},
+		{"acme", "globex", 0},
+		{"", "acme", 0},
+		{" ", "", 1},
+	}
+
+	for _, currentCase := range cases {
+		result := scorer.Similarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in TFIDFScorer.Similarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+	}
+
+	// Can be passed anywhere a SimilarityAlgorithm is expected
+	suggestion := SuggestWord("acme incorporated", []string{"globex inc", "acme labs", "initech inc"}, scorer.Similarity)
+	if suggestion.Word != "acme labs" || !compareFloat(float64(suggestion.Likelihood), 0.337, 3) {
+		t.Errorf("Error in SuggestWord('acme incorporated') with TFIDFScorer.Similarity, expected acme labs with a likelihood of 0.337 got %v", suggestion)
+	}
+
+	// Updating the corpus changes the weights
+	scorer.Update([]string{"acme", "acme", "acme", "globex"})
+	if result := scorer.Similarity("acme inc", "acme labs"); !compareFloat(float64(result), 0.180, 3) {
+		t.Errorf("Error in TFIDFScorer.Similarity('acme inc', 'acme labs') after Update(), expected 0.180 got %.3f", result)
+	}
+}
+
+func TestUkkonenDistance(t *testing.T) {
+	type testCase struct {
+		inputString      string
+		targetString     string
+		maxDistance      int
+		expectedDistance int
+	}
+
+	cases := []testCase{
+		{"kitten", "sitting", 3, 3},
+		{"kitten", "sitting", 2, 3},
+		{"kitten", "sitting", 0, 1},
+		{"kitten", "kitten", 0, 0},
+		{"a", "abcdef", 2, 3},
+		{"", "abc", 5, 3},
+		{"abc", "", -1, 1},
+		{"", "", 0, 0},
+		{"héllo", "hello", 1, 1},
+		{"flaw", "lawn", 2, 2},
+	}
+
+	for _, currentCase := range cases {
+		result := UkkonenDistance(currentCase.inputString, currentCase.targetString, currentCase.maxDistance)
+		if result != currentCase.expectedDistance {
+			t.Errorf("Error in UkkonenDistance('%s', '%s', %d), expected %d got %d", currentCase.inputString, currentCase.targetString, currentCase.maxDistance, currentCase.expectedDistance, result)
+		}
+	}
+
+	// It should be the full distance, capped at maxDistance+1
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcé")
+	for range 5000 {
+		inputString := randomString(generator, alphabet, 12)
+		targetString := randomString(generator, alphabet, 12)
+		maxDistance := generator.Intn(14)
+
+		expected := min(DynamicLevenshtein(inputString, targetString), maxDistance+1)
+		if result := UkkonenDistance(inputString, targetString, maxDistance); result != expected {
+			t.Errorf("Error in UkkonenDistance('%s', '%s', %d), expected %d got %d", inputString, targetString, maxDistance, expected, result)
+		}
+	}
+}
+
+func BenchmarkUkkonenDistance(b *testing.B) {
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcdefghijklmnopqrstuvwxyz ")
+
+	inputRunes := make([]rune, 1000)
+	for i := range inputRunes {
+		inputRunes[i] = alphabet[generator.Intn(len(alphabet))]
+	}
+	// A copy with a typo every 200 runes
+	targetRunes := slices.Clone(inputRunes)
+	for i := 0; i < len(targetRunes); i += 200 {
+		targetRunes[i] = alphabet[generator.Intn(len(alphabet))]
+	}
+	inputString, targetString := string(inputRunes), string(targetRunes)
+
+	for _, maxDistance := range []int{2, 10} {
+		b.Run(fmt.Sprintf("UkkonenDistance k=%d", maxDistance), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				UkkonenDistance(inputString, targetString, maxDistance)
+			}
+		})
+		b.Run(fmt.Sprintf("BoundedLevenshteinDistance k=%d", maxDistance), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				BoundedLevenshteinDistance(inputString, targetString, maxDistance)
+			}
+		})
+	}
+	b.Run("DynamicLevenshtein", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			DynamicLevenshtein(inputString, targetString)
+		}
+	})
+}
+
+func TestBitapSearch(t *testing.T) {
+	type testCase struct {
+		text              string
+		pattern           string
+		maxErrors         int
+		expectedPositions []int
+	}
+
+	cases := []testCase{
+		{"the quick brown fox", "quick", 0, []int{4}},
+		{"the quikc brown fox", "quick", 0, []int{}},
+		{"the quikc brown fox", "quick", 2, []int{3, 4, 5}},
+		{"connection refused, conection reset", "connection", 1, []int{0, 1, 20}},
+		{"abcabc", "abc", 0, []int{0, 3}},
+		{"xabc", "abc", 1, []int{0, 1, 2}},
+		// Offsets are in bytes, so they can be used to slice the text
+		{"über uber", "uber", 0, []int{6}},
+		{"über uber", "uber", 1, []int{0, 2, 5, 6, 7}},
+		{"abc", "", 1, []int{}},
+		{"", "abc", 1, []int{}},
+		{"abc", "abc", -1, []int{0}},
+	}
+
+	for _, currentCase := range cases {
+		result := BitapSearch(currentCase.text, currentCase.pattern, currentCase.maxErrors)
+		if !slices.Equal(result, currentCase.expectedPositions) {
+			t.Errorf("Error in BitapSearch('%s', '%s', %d), expected %v got %v", currentCase.text, currentCase.pattern, currentCase.maxErrors, currentCase.expectedPositions, result)
+		}
+	}
+
+	// A match starts at i if some substring starting at i is within maxErrors of the pattern
+	bruteForce := func(text, pattern string, maxErrors int) []int {
+		positions := []int{}
+		textRunes := []rune(text)
+		offset := 0
+		for i := range textRunes {
+			for j := i + 1; j <= len(textRunes); j++ {
+				if DynamicLevenshtein(string(textRunes[i:j]), pattern) <= maxErrors {
+					positions = append(positions, offset)
+					break
+				}
+			}
+			offset += len(string(textRunes[i]))
+		}
+		return positions
+	}
+
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcé")
+	for range 500 {
+		text := randomString(generator, alphabet, 20)
+		pattern := randomString(generator, alphabet, 6)
+		if pattern == "" {
+			continue
+		}
+		maxErrors := generator.Intn(3)
+		if result, expected := BitapSearch(text, pattern, maxErrors), bruteForce(text, pattern, maxErrors); !slices.Equal(result, expected) {
+			t.Errorf("Error in BitapSearch('%s', '%s', %d), expected %v got %v", text, pattern, maxErrors, expected, result)
+		}
+	}
+
+	// Patterns longer than 64 runes are split across multiple words
+	for range 20 {
+		pattern := randomString(generator, alphabet, 10) + strings.Repeat("ab", 35)
+		text := randomString(generator, alphabet, 10) + pattern[:40] + randomString(generator, alphabet, 3) + pattern[40:]
+		maxErrors := generator.Intn(4)
+		if result, expected := BitapSearch(text, pattern, maxErrors), bruteForce(text, pattern, maxErrors); !slices.Equal(result, expected) {
+			t.Errorf("Error in BitapSearch('%s', '%s', %d), expected %v got %v", text, pattern, maxErrors, expected, result)
+		}
+	}
+	longPattern := strings.Repeat("abcdefghij", 10)
+	longText := "xx" + longPattern[:30] + "Z" + longPattern[31:] + "yy"
+	if result := BitapSearch(longText, longPattern, 1); !slices.Equal(result, []int{2}) {
+		t.Errorf("Error in BitapSearch() with a 100 rune pattern, expected [2] got %v", result)
+	}
+	if result := BitapSearch(longText, longPattern, 0); len(result) != 0 {
+		t.Errorf("Error in BitapSearch() with a 100 rune pattern and no errors, expected [] got %v", result)
+	}
+}
+
+func BenchmarkBitapSearch(b *testing.B) {
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcdefghijklmnopqrstuvwxyz ")
+	textRunes := make([]rune, 10000)
+	for i := range textRunes {
+		textRunes[i] = alphabet[generator.Intn(len(alphabet))]
+	}
+	text := string(textRunes)
+
+	for _, pattern := range []string{"conection refused", strings.Repeat("connection refused ", 5)} {
+		for _, maxErrors := range []int{0, 2} {
+			b.Run(fmt.Sprintf("%d runes k=%d", len(pattern), maxErrors), func(b *testing.B) {
+				b.ReportAllocs()
+				for n := 0; n < b.N; n++ {
+					BitapSearch(text, pattern, maxErrors)
+				}
+			})
+		}
+	}
+}
+
+func TestPrefixSimilarity(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+		expectedBoosted    float64
+	}
+
+	// The boosted similarity is PrefixBoostedSimilarity(LevenshteinSimilarity, 0.5)
+	cases := []testCase{
+		{"über", "überfahrt", 0.444, 0.741},
+		{"alu", "alumni", 0.5, 0.75},
+		{"alu", "value", 0, 0.75},
+		{"Über", "überfahrt", 0, 0.6},
+		{"alumni", "alumni", 1, 1},
+		{"", "", 1, 1},
+		{"", "alumni", 0, 0},
+		{"alumni", "", 0, 0},
+	}
+
+	boosted := PrefixBoostedSimilarity(LevenshteinSimilarity, 0.5)
+	for _, currentCase := range cases {
+		if result := PrefixSimilarity(currentCase.inputString, currentCase.targetString); !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in PrefixSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+		if result := boosted(currentCase.inputString, currentCase.targetString); !compareFloat(float64(result), currentCase.expectedBoosted, 3) {
+			t.Errorf("Error in PrefixBoostedSimilarity(LevenshteinSimilarity, 0.5)('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedBoosted, result)
+		}
+	}
+
+	// Case folding comes from the usual wrappers
+	if result := CaseInsensitive(PrefixSimilarity)("Über", "überfahrt"); !compareFloat(float64(result), 0.444, 3) {
+		t.Errorf("Error in CaseInsensitive(PrefixSimilarity)('Über', 'überfahrt'), expected 0.444 got %.3f", result)
+	}
+
+	// A boost of 0 leaves the algorithm unchanged, and boosts are clamped to 0-1
+	if result := PrefixBoostedSimilarity(JaroSimilarity, 0)("alu", "alumni"); result != JaroSimilarity("alu", "alumni") {
+		t.Errorf("Error in PrefixBoostedSimilarity(JaroSimilarity, 0)('alu', 'alumni'), expected %.3f got %.3f", JaroSimilarity("alu", "alumni"), result)
+	}
+	if result := PrefixBoostedSimilarity(JaroSimilarity, 5)("alu", "alumni"); !compareFloat(float64(result), 0.917, 3) {
+		t.Errorf("Error in PrefixBoostedSimilarity(JaroSimilarity, 5)('alu', 'alumni'), expected 0.917 got %.3f", result)
+	}
+
+	// The bonus lets words that start with the input overtake ones that are fewer edits away
+	validWords := []string{"value", "alumni", "blue"}
+	if suggestion := SuggestWord("alu", validWords, LevenshteinSimilarity); suggestion.Word != "value" {
+		t.Errorf("Error in SuggestWord('alu') with LevenshteinSimilarity, expected value got %v", suggestion)
+	}
+	if suggestion := SuggestWord("alu", validWords, PrefixBoostedSimilarity(LevenshteinSimilarity, 1)); suggestion.Word != "alumni" || !compareFloat(float64(suggestion.Likelihood), 0.833, 3) {
+		t.Errorf("Error in SuggestWord('alu') with PrefixBoostedSimilarity(LevenshteinSimilarity, 1), expected alumni with a likelihood of 0.833 got %v", suggestion)
+	}
+}
+
+func TestSuffixSimilarity(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+		expectedBoosted    float64
+	}
+
+	// The boosted similarity is SuffixBoostedSimilarity(LevenshteinSimilarity, 0.5)
+	cases := []testCase{
+		{"cats", "dogs", 0.25, 0.672},
+		{"walked", "talked", 0.833, 0.951},
+		{"mail.google.com", "maps.google.com", 0.733, 0.958},
+		{"report.pdf", "report.txt", 0, 0.85},
+		// One string is the end of the other
+		{"ample", "example", 0.714, 0.893},
+		// Multi-byte runes are compared whole, "é" and "è" share their last byte
+		{"café", "olé", 0.25, 0.708},
+		{"é", "è", 0, 0.75},
+		{"überfahrt", "fahrt", 0.556, 0.807},
+		{"alumni", "alumni", 1, 1},
+		{"", "", 1, 1},
+		{"", "alumni", 0, 0},
+		{"alumni", "", 0, 0},
+	}
+
+	boosted := SuffixBoostedSimilarity(LevenshteinSimilarity, 0.5)
+	for _, currentCase := range cases {
+		if result := SuffixSimilarity(currentCase.inputString, currentCase.targetString); !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in SuffixSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+		if result := boosted(currentCase.inputString, currentCase.targetString); !compareFloat(float64(result), currentCase.expectedBoosted, 3) {
+			t.Errorf("Error in SuffixBoostedSimilarity(LevenshteinSimilarity, 0.5)('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedBoosted, result)
+		}
+	}
+
+	// A boost of 0 leaves the algorithm unchanged
+	if result := SuffixBoostedSimilarity(JaroSimilarity, -1)("walked", "talked"); result != JaroSimilarity("walked", "talked") {
+		t.Errorf("Error in SuffixBoostedSimilarity(JaroSimilarity, -1)('walked', 'talked'), expected %.3f got %.3f", JaroSimilarity("walked", "talked"), result)
+	}
+}
+
+func TestDistanceRegistry(t *testing.T) {
+	for _, name := range ListDistances() {
+		metric, exists := GetDistance(name)
+		if !exists || metric == nil {
+			t.Errorf("Error in GetDistance('%s'), expected a distance from ListDistances() to exist", name)
+			continue
+		}
+		if found, ok := DistanceName(metric); !ok || found != name {
+			t.Errorf("Error in DistanceName() for '%s', got '%s', %t", name, found, ok)
+		}
+	}
+
+	if name, ok := DistanceName(LevenshteinDistance); !ok || name != "levenshtein" {
+		t.Errorf("Error in DistanceName(LevenshteinDistance), expected levenshtein got '%s', %t", name, ok)
+	}
+	if _, ok := DistanceName(SpaceEfficientLevenshteinDistance); ok {
+		t.Errorf("Error in DistanceName(SpaceEfficientLevenshteinDistance), only the registered function should be found")
+	}
+	if _, ok := DistanceName(nil); ok {
+		t.Errorf("Error in DistanceName(nil), expected it not to be found")
+	}
+
+	RegisterDistance("test_dynamic_levenshtein", DynamicLevenshtein)
+	if name, ok := DistanceName(DynamicLevenshtein); !ok || name != "test_dynamic_levenshtein" {
+		t.Errorf("Error in DistanceName(DynamicLevenshtein) after registering it, got '%s', %t", name, ok)
+	}
+	if _, exists := GetAlgorithm("test_dynamic_levenshtein"); exists {
+		t.Errorf("Error in RegisterDistance(), distances shouldn't be added to the similarity registry")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Error in RegisterDistance() with a nil algorithm, expected a panic")
+		}
+	}()
+	RegisterDistance("nil", nil)
+}
+
+func TestJaroSimilarityWindow(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		window             int
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		// A negative window is the standard floor(max(len)/2) - 1
+		{"martha", "marhta", -1, 0.944},
+		{"dixon", "dicksonx", -1, 0.767},
+		{"crate", "trace", -1, 0.733},
+		{"martha", "marhta", 0, 0.778},
+		{"martha", "marhta", 1, 0.944},
+		{"dixon", "dicksonx", 1, 0.55},
+		// Wider windows find matches further apart
+		{"dixon", "dicksonx", 5, 0.808},
+		{"crate", "trace", 5, 0.933},
+		{"abcdef", "fedcba", 5, 0.833},
+		{"ab", "ba", 0, 0},
+		{"ab", "ba", 1, 0.833},
+		{"a", "b", 3, 0},
+		{"", "abc", 3, 0},
+		{"abc", "abc", 0, 1},
+	}
+
+	for _, currentCase := range cases {
+		result := JaroSimilarityWindow(currentCase.inputString, currentCase.targetString, currentCase.window)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in JaroSimilarityWindow('%s', '%s', %d), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.window, currentCase.expectedSimilarity, result)
+		}
+		if curried := NewJaroSimilarityWindow(currentCase.window)(currentCase.inputString, currentCase.targetString); curried != result {
+			t.Errorf("Error in NewJaroSimilarityWindow(%d)('%s', '%s'), expected %.3f got %.3f", currentCase.window, currentCase.inputString, currentCase.targetString, result, curried)
+		}
+	}
+}
+
+func TestOverlapCoefficient(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		n                  int
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"", "", 2, 1},
+		{"a", "", 2, 0},
+		{"", "alumni", 2, 0},
+		{"alumni", "alumni", 2, 1},
+		{"almni", "alumni", 2, 0.75},
+		{"almni", "alumni", 3, 0.333},
+		{"night", "nacht", 2, 0.25},
+		// Subsets are a perfect match
+		{"aaaa", "aa", 2, 1},
+		{"abab", "ab", 2, 1},
+		{"umn", "alumni", 2, 1},
+		{"a", "ab", 2, 0},
+		{"abc", "xyz", 1, 0},
+	}
+
+	for _, currentCase := range cases {
+		result := OverlapCoefficient(currentCase.inputString, currentCase.targetString, currentCase.n)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in OverlapCoefficient('%s', '%s', %d), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.n, currentCase.expectedSimilarity, result)
+		}
+		distance := OverlapCoefficientDistance(currentCase.inputString, currentCase.targetString, currentCase.n)
+		if !compareFloat(float64(distance), 1-currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in OverlapCoefficientDistance('%s', '%s', %d), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.n, 1-currentCase.expectedSimilarity, distance)
+		}
+	}
+
+	// Abbreviations share most of their n-grams with the full word, but are swamped by the union in Jaccard
+	abbreviations := [][2]string{{"intl", "international"}, {"dept", "department"}}
+	for _, pair := range abbreviations {
+		overlap := OverlapCoefficient(pair[0], pair[1], 2)
+		jaccard := JaccardSimilarity(pair[0], pair[1], 2)
+		if !compareFloat(float64(overlap), 0.667, 3) {
+			t.Errorf("Error in OverlapCoefficient('%s', '%s', 2), expected 0.667 got %.3f", pair[0], pair[1], overlap)
+		}
+		if overlap <= 3*jaccard {
+			t.Errorf("Expected OverlapCoefficient('%s', '%s', 2) = %.3f to be well above JaccardSimilarity() = %.3f", pair[0], pair[1], overlap, jaccard)
+		}
+	}
+}
+
+// Sums lambda^(span in first + span in second) over every pair of matching index subsets of length k
+func bruteForceSubsequenceKernel(first, second []rune, k int, lambda float64) float64 {
+	var subsequences func(runes []rune, start int, picked []int, visit func([]int))
+	subsequences = func(runes []rune, start int, picked []int, visit func([]int)) {
+		if len(picked) == k {
+			visit(picked)
+			return
+		}
+		for index := start; index < len(runes); index++ {
+			subsequences(runes, index+1, append(picked, index), visit)
+		}
+	}
+
+	kernel := 0.0
+	subsequences(first, 0, nil, func(firstIndexes []int) {
+		firstSpan := firstIndexes[k-1] - firstIndexes[0] + 1
+		firstIndexes = slices.Clone(firstIndexes)
+		subsequences(second, 0, nil, func(secondIndexes []int) {
+			for position := range k {
+				if first[firstIndexes[position]] != second[secondIndexes[position]] {
+					return
+				}
+			}
+			secondSpan := secondIndexes[k-1] - secondIndexes[0] + 1
+			kernel += math.Pow(lambda, float64(firstSpan+secondSpan))
+		})
+	})
+	return kernel
+}
+
+func TestSSKSimilarity(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		k                  int
+		lambda             float32
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"", "", 2, 0.5, 1},
+		{"a", "", 2, 0.5, 0},
+		{"", "alumni", 2, 0.5, 0},
+		{"alumni", "alumni", 2, 0.5, 1},
+		// Only "ca" is shared, so the similarity is λ^4 / (2λ^4 + λ^6) = 1 / (2 + λ^2)
+		{"cat", "car", 2, 0.5, 0.444},
+		{"cat", "car", 2, 1, 0.333},
+		// Too short to have a subsequence of length k
+		{"a", "ab", 2, 0.5, 0},
+		{"abc", "xyz", 1, 0.5, 0},
+		{"ab", "ba", 0, 0.5, 1},
+	}
+
+	for _, currentCase := range cases {
+		result := SSKSimilarity(currentCase.inputString, currentCase.targetString, currentCase.k, currentCase.lambda)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in SSKSimilarity('%s', '%s', %d, %.2f), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.k, currentCase.lambda, currentCase.expectedSimilarity, result)
+		}
+	}
+
+	// Gaps are penalized, but still match where n-grams don't
+	if gapped, contiguous := SSKSimilarity("ab", "axxb", 2, 0.5), SSKSimilarity("ab", "abxx", 2, 0.5); gapped <= 0 || gapped >= contiguous {
+		t.Errorf("Expected 0 < SSKSimilarity('ab', 'axxb') = %.3f < SSKSimilarity('ab', 'abxx') = %.3f", gapped, contiguous)
+	}
+
+	generator := rand.New(rand.NewSource(299))
+	for range 200 {
+		first := []rune(randomString(generator, []rune("abcé"), 7))
+		second := []rune(randomString(generator, []rune("abcé"), 7))
+		k := generator.Intn(3) + 1
+		lambda := 0.1 + generator.Float64()*0.9
+		expected := bruteForceSubsequenceKernel(first, second, k, lambda)
+		result := subsequenceKernel(first, second, k, lambda)
+		if math.Abs(expected-result) > 1e-9 {
+			t.Errorf("Error in subsequenceKernel('%s', '%s', %d, %.2f), expected %.6f got %.6f", string(first), string(second), k, lambda, expected, result)
+		}
+	}
+
+	// Long inputs are truncated instead of taking quadratic memory
+	long := strings.Repeat("ab", SSKMaxLength)
+	if result := SSKSimilarity(long, long+"c", 2, 0.5); result != 1 {
+		t.Errorf("Error in SSKSimilarity() with inputs longer than SSKMaxLength, expected 1.000 got %.3f", result)
+	}
+
+	algorithm := NewSSKSimilarity(2, 0.5)
+	if result := algorithm("cat", "car"); !compareFloat(float64(result), 0.444, 3) {
+		t.Errorf("Error in NewSSKSimilarity(2, 0.5)('cat', 'car'), expected 0.444 got %.3f", result)
+	}
+	for _, lambda := range []float32{0, -0.5, 1.5} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewSSKSimilarity(2, %.2f) should panic", lambda)
+				}
+			}()
+			NewSSKSimilarity(2, lambda)
+		}()
+	}
+}
+
+func TestMyersLevenshtein(t *testing.T) {
+	generator := rand.New(rand.NewSource(300))
+	alphabets := [][]rune{[]rune("ab"), []rune("abcdé"), []rune("abcdefghijklmnopqrstuvwxyz日本")}
+	for _, alphabet := range alphabets {
+		for range 2000 {
+			inputString := randomString(generator, alphabet, 12)
+			targetString := randomString(generator, alphabet, 12)
+
+			expected := DynamicLevenshtein(inputString, targetString)
+			if result := MyersLevenshtein(inputString, targetString); result != expected {
+				t.Errorf("Error in MyersLevenshtein('%s', '%s'), expected %d got %d", inputString, targetString, expected, result)
+			}
+		}
+
+		// Long enough to need several blocks, including the lengths around the block boundaries
+		for range 300 {
+			inputString := randomString(generator, alphabet, 200)
+			targetString := randomString(generator, alphabet, 200)
+
+			expected := DynamicLevenshtein(inputString, targetString)
+			if result := MyersLevenshtein(inputString, targetString); result != expected {
+				t.Errorf("Error in MyersLevenshtein() with %d and %d runes, expected %d got %d", len([]rune(inputString)), len([]rune(targetString)), expected, result)
+			}
+		}
+		for _, length := range []int{63, 64, 65, 127, 128, 129} {
+			inputString := string(alphabet[:1]) + strings.Repeat(string(alphabet[len(alphabet)-1]), length-1)
+			targetString := randomString(generator, alphabet, 2*length)
+
+			expected := DynamicLevenshtein(inputString, targetString)
+			if result := MyersLevenshtein(inputString, targetString); result != expected {
+				t.Errorf("Error in MyersLevenshtein() with %d and %d runes, expected %d got %d", length, len([]rune(targetString)), expected, result)
+			}
+		}
+	}
+
+	if result := LevenshteinDistance("almni", "alumni"); result != 1 {
+		t.Errorf("Error in LevenshteinDistance('almni', 'alumni'), expected 1 got %d", result)
+	}
+}
+
+func TestIsWithinDistance(t *testing.T) {
+	generator := rand.New(rand.NewSource(303))
+	alphabet := []rune("abcé")
+	for range 2000 {
+		inputString := randomString(generator, alphabet, 10)
+		targetString := randomString(generator, alphabet, 10)
+		maxDistance := generator.Intn(5)
+
+		expected := OptimalStringAlignmentDistance(inputString, targetString)
+		if result := BoundedDamerauLevenshteinDistance(inputString, targetString, maxDistance); result != min(expected, maxDistance+1) {
+			t.Errorf("Error in BoundedDamerauLevenshteinDistance('%s', '%s', %d), expected %d got %d", inputString, targetString, maxDistance, min(expected, maxDistance+1), result)
+		}
+		if result := DamerauLevenshteinWithin(inputString, targetString, maxDistance); result != (expected <= maxDistance) {
+			t.Errorf("Error in DamerauLevenshteinWithin('%s', '%s', %d), expected %t got %t", inputString, targetString, maxDistance, expected <= maxDistance, result)
+		}
+		for _, algorithm := range []DistanceAlgorithm{DamerauLevenshtein, OptimalStringAlignmentDistance} {
+			if result := IsWithinDistance(inputString, targetString, maxDistance, algorithm); result != (expected <= maxDistance) {
+				t.Errorf("Error in IsWithinDistance('%s', '%s', %d) with a Damerau–Levenshtein distance, expected %t got %t", inputString, targetString, maxDistance, expected <= maxDistance, result)
+			}
+		}
+
+		expected = LevenshteinDistance(inputString, targetString)
+		if result := LevenshteinWithin(inputString, targetString, maxDistance); result != (expected <= maxDistance) {
+			t.Errorf("Error in LevenshteinWithin('%s', '%s', %d), expected %t got %t", inputString, targetString, maxDistance, expected <= maxDistance, result)
+		}
+		for _, algorithm := range []DistanceAlgorithm{nil, LevenshteinDistance, DynamicLevenshtein} {
+			if result := IsWithinDistance(inputString, targetString, maxDistance, algorithm); result != (expected <= maxDistance) {
+				t.Errorf("Error in IsWithinDistance('%s', '%s', %d), expected %t got %t", inputString, targetString, maxDistance, expected <= maxDistance, result)
+			}
+		}
+
+		expected = DamerauLevenshteinDP(inputString, targetString)
+		if result := IsWithinDistance(inputString, targetString, maxDistance, DamerauLevenshteinDP); result != (expected <= maxDistance) {
+			t.Errorf("Error in IsWithinDistance('%s', '%s', %d, DamerauLevenshteinDP), expected %t got %t", inputString, targetString, maxDistance, expected <= maxDistance, result)
+		}
+	}
+
+	if IsWithinDistance("", "", -1, nil) || IsWithinDistance("", "", -1, DamerauLevenshteinDP) || LevenshteinWithin("", "", -1) || DamerauLevenshteinWithin("", "", -1) {
+		t.Errorf("Nothing should be within a negative maxDistance")
+	}
+	if result := BoundedDamerauLevenshteinDistance("ca", "abc", 3); result != 3 {
+		t.Errorf("Error in BoundedDamerauLevenshteinDistance('ca', 'abc', 3), expected 3 got %d", result)
+	}
+
+	type testCase struct {
+		inputString  string
+		validStrings []string
+		maxDistance  int
+		expectedWord string
+		expectedOk   bool
+	}
+
+	cases := []testCase{
+		{"selct", []string{"from", "select", "delete"}, 1, "select", true},
+		{"delet", []string{"from", "select", "delete"}, 1, "delete", true},
+		// The first word within maxDistance is returned, even if a later one is closer
+		{"cat", []string{"bat", "cat"}, 1, "bat", true},
+		{"at", []string{"from", "cat", "bat"}, 1, "cat", true},
+		{"updte", []string{"from", "select", "delete"}, 1, "", false},
+		{"from", nil, 1, "", false},
+		{"from", []string{"from"}, -1, "", false},
+	}
+
+	for _, currentCase := range cases {
+		word, ok := AnyWithinDistance(currentCase.inputString, currentCase.validStrings, currentCase.maxDistance)
+		if word != currentCase.expectedWord || ok != currentCase.expectedOk {
+			t.Errorf("Error in AnyWithinDistance('%s', %v, %d), expected ('%s', %t) got ('%s', %t)", currentCase.inputString, currentCase.validStrings, currentCase.maxDistance, currentCase.expectedWord, currentCase.expectedOk, word, ok)
+		}
+	}
+}
+
+func TestQWERTYDistance(t *testing.T) {
+	type testCase struct {
+		inputRune    rune
+		targetRune   rune
+		expectedCost float64
+	}
+
+	cases := []testCase{
+		{'a', 'a', 0},
+		{'s', 'd', 0.5},
+		{'s', 'w', 0.559},
+		{'s', 'z', 0.559},
+		{'s', 'f', 1},
+		{'a', 'p', 1},
+		// Shifted runes are typed with the same key
+		{'S', 'd', 0.5},
+		{'!', '2', 0.5},
+		{'A', 'a', 0},
+		// Runes that aren't on the keyboard
+		{'é', 'e', 1},
+		{'é', 'é', 0},
+	}
+
+	for _, currentCase := range cases {
+		result := QWERTYDistance(currentCase.inputRune, currentCase.targetRune)
+		if !compareFloat(result, currentCase.expectedCost, 3) {
+			t.Errorf("Error in QWERTYDistance('%c', '%c'), expected %.3f got %.3f", currentCase.inputRune, currentCase.targetRune, currentCase.expectedCost, result)
+		}
+	}
+
+	// Other layouts can be swapped in
+	original := QWERTYKeyCoordinates
+	defer func() { QWERTYKeyCoordinates = original }()
+	QWERTYKeyCoordinates = map[rune][2]float64{'a': {0, 1}, 'z': {1, 1}, 'q': {0, 2}}
+	if result := QWERTYDistance('a', 'z'); !compareFloat(result, 0.5, 3) {
+		t.Errorf("Error in QWERTYDistance('a', 'z') with an AZERTY layout, expected 0.500 got %.3f", result)
+	}
+	if result := QWERTYDistance('s', 'd'); result != 1 {
+		t.Errorf("Error in QWERTYDistance('s', 'd') with keys missing from the layout, expected 1.000 got %.3f", result)
+	}
+}
+
+func TestVisualSimilarity(t *testing.T) {
+	type testCase struct {
+		inputRune          rune
+		targetRune         rune
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{'a', 'a', 1},
+		{'0', 'O', 0.75},
+		{'O', '0', 0.75},
+		{'l', '1', 0.75},
+		{'1', 'I', 0.75},
+		{'|', 'l', 0.75},
+		{'5', 'S', 0.75},
+		// Homoglyphs from other scripts
+		{'a', 'а', 0.75},
+		{'ο', 'o', 0.75},
+		{'P', 'Р', 0.75},
+		// Not confusable
+		{'l', '7', 0},
+		{'a', 'b', 0},
+		{'0', 'Q', 0},
+	}
+
+	for _, currentCase := range cases {
+		result := VisualSimilarity(currentCase.inputRune, currentCase.targetRune)
+		if !compareFloat(result, currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in VisualSimilarity('%c', '%c'), expected %.3f got %.3f", currentCase.inputRune, currentCase.targetRune, currentCase.expectedSimilarity, result)
+		}
+		cost := VisualSubstitutionCost(currentCase.inputRune, currentCase.targetRune)
+		if !compareFloat(cost, 1-currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in VisualSubstitutionCost('%c', '%c'), expected %.3f got %.3f", currentCase.inputRune, currentCase.targetRune, 1-currentCase.expectedSimilarity, cost)
+		}
+	}
+
+	// Confusables make OCR mistakes cheaper than other typos
+	if result := WeightedLevenshteinDistance("INV0ICE", "INVOICE", 1, 1, VisualSubstitutionCost); !compareFloat(result, 0.25, 3) {
+		t.Errorf("Error in WeightedLevenshteinDistance('INV0ICE', 'INVOICE') with VisualSubstitutionCost, expected 0.250 got %.3f", result)
+	}
+	if result := WeightedLevenshteinDistance("INVQICE", "INVOICE", 1, 1, VisualSubstitutionCost); !compareFloat(result, 1, 3) {
+		t.Errorf("Error in WeightedLevenshteinDistance('INVQICE', 'INVOICE') with VisualSubstitutionCost, expected 1.000 got %.3f", result)
+	}
+
+	// The table can be extended
+	VisualConfusables['Q'] = append(VisualConfusables['Q'], '0')
+	defer func() { delete(VisualConfusables, 'Q') }()
+	if result := VisualSimilarity('0', 'Q'); !compareFloat(result, 0.75, 3) {
+		t.Errorf("Error in VisualSimilarity('0', 'Q') after adding it to VisualConfusables, expected 0.750 got %.3f", result)
+	}
+}
+
+func TestPhonemeDistance(t *testing.T) {
+	type phonemesTestCase struct {
+		word             string
+		expectedPhonemes string
+	}
+
+	phonemesCases := []phonemesTestCase{
+		{"phone", "F OW N"},
+		{"knight", "N AY T"},
+		{"city", "S IH T IY"},
+		{"thing", "TH IH NG"},
+		{"judge", "JH AH JH"},
+		{"quick", "K W IH K"},
+		{"box", "B AA K S"},
+		{"rose", "R OW Z"},
+		{"table", "T EY B AH L"},
+		{"little", "L IH T AH L"},
+		{"here", "HH IY R"},
+		{"my", "M AY"},
+		{"happy", "HH AE P IY"},
+		{"ghost", "G AA S T"},
+		{"nation", "N AE SH AH N"},
+		{"Fork", "F AO R K"},
+		{"bird", "B ER D"},
+		{"", ""},
+		{"123", ""},
+	}
+
+	for _, currentCase := range phonemesCases {
+		result := strings.Join(Phonemes(currentCase.word), " ")
+		if result != currentCase.expectedPhonemes {
+			t.Errorf("Error in Phonemes('%s'), expected %s got %s", currentCase.word, currentCase.expectedPhonemes, result)
+		}
+	}
+
+	type costTestCase struct {
+		inputPhoneme  string
+		targetPhoneme string
+		expectedCost  float64
+	}
+
+	costCases := []costTestCase{
+		{"P", "P", 0},
+		{"P", "B", 0.4},  // Voicing
+		{"P", "T", 0.2},  // Place
+		{"F", "TH", 0.2}, // Place
+		{"T", "S", 0.5},  // Manner
+		{"P", "Z", 1},    // Everything, capped
+		{"IY", "IH", 0.15},
+		{"IY", "AA", 0.8},
+		{"IY", "EY", 0.4},
+		{"W", "UW", 0.5},
+		{"UW", "W", 0.5},
+		{"P", "AA", 1},
+		{"P", "unknown", 1},
+	}
+
+	for _, currentCase := range costCases {
+		result := PhonemeSubstitutionCost(currentCase.inputPhoneme, currentCase.targetPhoneme)
+		if !compareFloat(result, currentCase.expectedCost, 3) {
+			t.Errorf("Error in PhonemeSubstitutionCost('%s', '%s'), expected %.3f got %.3f", currentCase.inputPhoneme, currentCase.targetPhoneme, currentCase.expectedCost, result)
+		}
+	}
+
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedDistance   float64
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"phone", "fone", 0, 1},
+		{"night", "knight", 0, 1},
+		{"Catherine", "Katherine", 0, 1},
+		{"bat", "pat", 0.4, 0.867},
+		{"pat", "tat", 0.2, 0.933},
+		{"bit", "beat", 0.15, 0.95},
+		{"sat", "mat", 1, 0.667},
+		{"cat", "dog", 1.6, 0.467},
+		{"", "", 0, 1},
+		{"cat", "", 3, 0},
+	}
+
+	for _, currentCase := range cases {
+		distance := PhonemeDistance(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(distance, currentCase.expectedDistance, 3) {
+			t.Errorf("Error in PhonemeDistance('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedDistance, distance)
+		}
+		similarity := PhonemeSimilarity(currentCase.inputString, currentCase.targetString)
+		if !compareFloat(float64(similarity), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in PhonemeSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, similarity)
+		}
+	}
+}
+
+func TestComparer(t *testing.T) {
+	exactlyEqual := func(inputRune, targetRune rune) bool { return inputRune == targetRune }
+
+	// One comparer is reused for every case, so anything left over in its buffers from a longer comparison would show up here
+	comparer := NewComparer()
+	generator := rand.New(rand.NewSource(307))
+	alphabets := [][]rune{[]rune("ab"), []rune("abcdé"), []rune("abcdefghijklmnopqrstuvwxyz日本")}
+	for _, alphabet := range alphabets {
+		for _, maxLength := range []int{150, 12, 70, 3} {
+			for range 100 {
+				inputString := randomString(generator, alphabet, maxLength)
+				targetString := randomString(generator, alphabet, maxLength)
+
+				expected := LevenshteinDistanceFunc(inputString, targetString, exactlyEqual)
+				results := map[string]int{
+					"DynamicLevenshtein":                comparer.DynamicLevenshtein(inputString, targetString),
+					"SpaceEfficientLevenshteinDistance": comparer.SpaceEfficientLevenshteinDistance(inputString, targetString),
+					"MyersLevenshtein":                  comparer.MyersLevenshtein(inputString, targetString),
+					"LevenshteinDistance":               comparer.LevenshteinDistance(inputString, targetString),
+					"BoundedLevenshteinDistance":        comparer.BoundedLevenshteinDistance(inputString, targetString, len(inputString)+len(targetString)),
+				}
+				for name, result := range results {
+					if result != expected {
+						t.Errorf("Error in Comparer.%s('%s', '%s'), expected %d got %d", name, inputString, targetString, expected, result)
+					}
+				}
+				if result := comparer.BoundedLevenshteinDistance(inputString, targetString, 2); result != min(expected, 3) {
+					t.Errorf("Error in Comparer.BoundedLevenshteinDistance('%s', '%s', 2), expected %d got %d", inputString, targetString, min(expected, 3), result)
+				}
+
+				expected = IndelDistanceFunc(inputString, targetString, exactlyEqual)
+				if result := comparer.IndelDistance(inputString, targetString); result != expected {
+					t.Errorf("Error in Comparer.IndelDistance('%s', '%s'), expected %d got %d", inputString, targetString, expected, result)
+				}
+			}
+		}
+	}
+
+	if result, expected := comparer.LevenshteinSimilarity("almni", "alumni"), LevenshteinSimilarity("almni", "alumni"); !compareFloat(float64(result), float64(expected), 3) {
+		t.Errorf("Error in Comparer.LevenshteinSimilarity('almni', 'alumni'), expected %.3f got %.3f", expected, result)
+	}
+	if result, expected := comparer.IndelSimilarity("almni", "alumni"), IndelSimilarity("almni", "alumni"); !compareFloat(float64(result), float64(expected), 3) {
+		t.Errorf("Error in Comparer.IndelSimilarity('almni', 'alumni'), expected %.3f got %.3f", expected, result)
+	}
+
+	// Once the buffers have grown to fit, comparing shouldn't allocate at all
+	inputString := strings.Repeat("kitten日本", 20)
+	targetString := strings.Repeat("sitting本", 20)
+	allocations := map[string]func(){
+		"DynamicLevenshtein":                func() { comparer.DynamicLevenshtein(inputString, targetString) },
+		"SpaceEfficientLevenshteinDistance": func() { comparer.SpaceEfficientLevenshteinDistance(inputString, targetString) },
+		"MyersLevenshtein":                  func() { comparer.MyersLevenshtein(inputString, targetString) },
+		"MyersLevenshteinShort":             func() { comparer.MyersLevenshtein("kitten日本", "sitting本") },
+		"BoundedLevenshteinDistance":        func() { comparer.BoundedLevenshteinDistance(inputString, targetString, 50) },
+		"IndelDistance":                     func() { comparer.IndelDistance(inputString, targetString) },
+	}
+	for name, compare := range allocations {
+		compare()
+		if result := testing.AllocsPerRun(10, compare); result != 0 {
+			t.Errorf("Error in Comparer.%s(), expected 0 allocations got %.1f", name, result)
+		}
+	}
+}
+
+func BenchmarkComparer(b *testing.B) {
+	generator := rand.New(rand.NewSource(42))
+	alphabet := []rune("abcdefghijklmnopqrstuvwxyz")
+	inputString := randomString(generator, alphabet, 500)
+	targetString := randomString(generator, alphabet, 500)
+	comparer := NewComparer()
+
+	b.Run("DynamicLevenshtein", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			comparer.DynamicLevenshtein(inputString, targetString)
+		}
+	})
+	b.Run("SpaceEfficientLevenshteinDistance", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			comparer.SpaceEfficientLevenshteinDistance(inputString, targetString)
+		}
+	})
+	b.Run("MyersLevenshtein", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			comparer.MyersLevenshtein(inputString, targetString)
+		}
+	})
+	b.Run("IndelDistance", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			comparer.IndelDistance(inputString, targetString)
+		}
+	})
+}
+
+func TestSegmentIdentifiers(t *testing.T) {
+	type testCase struct {
+		identifier string
+		expected   []string
+	}
+
+	camelCases := []testCase{
+		{"getUserName", []string{"get", "User", "Name"}},
+		{"GetUserName", []string{"Get", "User", "Name"}},
+		{"HTTPServer", []string{"HTTP", "Server"}},
+		{"parseHTTPResponse", []string{"parse", "HTTP", "Response"}},
+		{"userID", []string{"user", "ID"}},
+		{"base64Encode", []string{"base", "64", "Encode"}},
+		{"get_user_name", []string{"get", "user", "name"}},
+		{"kebab-case-name", []string{"kebab", "case", "name"}},
+		{"ÉcoleNormale", []string{"École", "Normale"}},
+		{"lowercase", []string{"lowercase"}},
+		{"", nil},
+	}
+	for _, currentCase := range camelCases {
+		if result := SegmentCamelCase(currentCase.identifier); !slices.Equal(result, currentCase.expected) {
+			t.Errorf("Error in SegmentCamelCase('%s'), expected %q got %q", currentCase.identifier, currentCase.expected, result)
+		}
+	}
+
+	snakeCases := []testCase{
+		{"get_user_name", []string{"get", "user", "name"}},
+		{"MAX_VALUE", []string{"MAX", "VALUE"}},
+		{"__init__", []string{"init"}},
+		{"double__underscore", []string{"double", "underscore"}},
+		{"userName", []string{"userName"}},
+		{"", nil},
+	}
+	for _, currentCase := range snakeCases {
+		if result := SegmentSnakeCase(currentCase.identifier); !slices.Equal(result, currentCase.expected) {
+			t.Errorf("Error in SegmentSnakeCase('%s'), expected %q got %q", currentCase.identifier, currentCase.expected, result)
+		}
+	}
+}
+
+func TestIdentifierSimilarity(t *testing.T) {
+	type testCase struct {
+		inputString        string
+		targetString       string
+		expectedSimilarity float64
+	}
+
+	cases := []testCase{
+		{"getUserName", "get_user_name", 1},
+		{"GetUserName", "GET_USER_NAME", 1},
+		{"HTTPServer", "http_server", 1},
+		{"getUserName", "setUserName", float64(LevenshteinSimilarity("get user name", "set user name"))},
+		{"getUserName", "getUsername", float64(LevenshteinSimilarity("get user name", "get username"))},
+	}
+	for _, currentCase := range cases {
+		result := IdentifierSimilarity(currentCase.inputString, currentCase.targetString, LevenshteinSimilarity)
+		if !compareFloat(float64(result), currentCase.expectedSimilarity, 3) {
+			t.Errorf("Error in IdentifierSimilarity('%s', '%s'), expected %.3f got %.3f", currentCase.inputString, currentCase.targetString, currentCase.expectedSimilarity, result)
+		}
+	}
+
+	// Without segmenting the styles don't match, which is what IdentifierSimilarity fixes
+	if plain, segmented := LevenshteinSimilarity("getUserName", "get_user_name"), IdentifierSimilarity("getUserName", "get_user_name", LevenshteinSimilarity); plain >= segmented {
+		t.Errorf("Error in IdentifierSimilarity('getUserName', 'get_user_name'), expected more than %.3f got %.3f", plain, segmented)
+	}
+}
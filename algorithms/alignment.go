@@ -0,0 +1,410 @@
+package algorithms
+
+// This file implements sequence alignment algorithms, which score how well two strings line up with each other
+//
+// # References
+//  - https://en.wikipedia.org/wiki/Smith%E2%80%93Waterman_algorithm
+//  - https://en.wikipedia.org/wiki/Needleman%E2%80%93Wunsch_algorithm
+//  - https://en.wikipedia.org/wiki/Substitution_matrix
+//  - https://en.wikipedia.org/wiki/Gap_penalty#Affine
+//  - Gotoh, O. (1982) An improved algorithm for matching biological sequences. Journal of Molecular Biology, 162(3), 705-708
+
+import (
+	"math"
+	"slices"
+)
+
+// The best local alignment of two strings
+type LocalAlignment struct {
+	Score       int    // The score of the alignment
+	InputMatch  string // The aligned part of the input string
+	TargetMatch string // The aligned part of the target string
+	InputStart  int    // The byte offset the aligned part starts at in the input string
+	InputEnd    int    // The byte offset the aligned part ends at (exclusive) in the input string
+	TargetStart int    // The byte offset the aligned part starts at in the target string
+	TargetEnd   int    // The byte offset the aligned part ends at (exclusive) in the target string
+}
+
+// Calculates the Smith-Waterman local alignment score of two strings
+//
+// # Notes
+//  - Finds the region of inputString that best lines up with a region of targetString, allowing mismatches and gaps
+//  - mismatch and gap are penalties, so they should be positive and are subtracted from the score
+//  - The score is never negative, and is 0 if either string is empty
+//  - Operates on runes, and runs in O(m*n) time
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  match (int): The score added for each matching rune
+//  mismatch (int): The penalty for each mismatched rune
+//  gap (int): The penalty for each rune skipped in either string
+//
+// # Returns
+//  int: The score of the best local alignment
+func SmithWaterman(inputString, targetString string, match, mismatch, gap int) int {
+	return SmithWatermanAlignment(inputString, targetString, match, mismatch, gap).Score
+}
+
+// Calculates the Smith-Waterman similarity of two strings
+//
+// # Notes
+//  - Uses a score of 2 for a match, and penalties of 1 for mismatches and gaps
+//  - Normalized by the best possible score, which is the shorter string matching completely
+//  - Returns 1 when one string is a contiguous substring of the other
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func SmithWatermanSimilarity(inputString, targetString string) float32 {
+	if inputString == targetString {
+		return 1
+	}
+
+	shortestLength := min(len([]rune(inputString)), len([]rune(targetString)))
+	if shortestLength == 0 {
+		return 0
+	}
+
+	const match = 2
+	score := SmithWaterman(inputString, targetString, match, 1, 1)
+	return float32(score) / float32(match*shortestLength)
+}
+
+// Finds the Smith-Waterman local alignment of two strings
+//
+// # Notes
+//  - Traces back from the highest scoring cell to find the aligned parts of each string, and where they are
+//  - When several alignments have the same score, the one that ends first in inputString is used
+//  - The aligned parts are empty if nothing lines up
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  match (int): The score added for each matching rune
+//  mismatch (int): The penalty for each mismatched rune
+//  gap (int): The penalty for each rune skipped in either string
+//
+// # Returns
+//  LocalAlignment: The best local alignment, and where it is in each string
+func SmithWatermanAlignment(inputString, targetString string, match, mismatch, gap int) LocalAlignment {
+	alignment, _, _ := smithWaterman(inputString, targetString, match, mismatch, gap)
+	return alignment
+}
+
+// Finds the Smith-Waterman local alignment of two strings, with gaps marked the way alignment tools show them
+//
+// # Notes
+//  - Each string has a "-" where the other has a rune that it skips, so both strings are the same length and line up column by column
+//  - i.e. "TGTTACGG" and "GGTTGACTA" are "GTT-AC" and "GTTGAC" with a match of 3, and penalties of 3 and 2
+//  - Both strings are empty if nothing lines up
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  match (int): The score added for each matching rune
+//  mismatch (int): The penalty for each mismatched rune
+//  gap (int): The penalty for each rune skipped in either string
+//
+// # Returns
+//  string: The aligned part of inputString, with "-" for gaps
+//  string: The aligned part of targetString, with "-" for gaps
+func SmithWatermanAlignedStrings(inputString, targetString string, match, mismatch, gap int) (string, string) {
+	_, alignedInput, alignedTarget := smithWaterman(inputString, targetString, match, mismatch, gap)
+	return alignedInput, alignedTarget
+}
+
+// Finds the Smith-Waterman local alignment of two strings, along with the aligned strings with gaps marked
+func smithWaterman(inputString, targetString string, match, mismatch, gap int) (LocalAlignment, string, string) {
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+
+	// Fill the matrix, keeping track of the highest scoring cell
+	matrix := make([][]int, len(inputStringRunes)+1)
+	for i := range matrix {
+		matrix[i] = make([]int, len(targetStringRunes)+1)
+	}
+	bestScore, bestI, bestJ := 0, 0, 0
+	for i := 1; i <= len(inputStringRunes); i++ {
+		for j := 1; j <= len(targetStringRunes); j++ {
+			diagonal := matrix[i-1][j-1] - mismatch
+			if inputStringRunes[i-1] == targetStringRunes[j-1] {
+				diagonal = matrix[i-1][j-1] + match
+			}
+			matrix[i][j] = max(0, diagonal, matrix[i-1][j]-gap, matrix[i][j-1]-gap)
+
+			if matrix[i][j] > bestScore {
+				bestScore, bestI, bestJ = matrix[i][j], i, j
+			}
+		}
+	}
+
+	// Trace back from the best cell until the score drops to 0, the aligned runes are found backwards
+	var alignedInput, alignedTarget []rune
+	i, j := bestI, bestJ
+	for i > 0 && j > 0 && matrix[i][j] > 0 {
+		diagonal := matrix[i-1][j-1] - mismatch
+		if inputStringRunes[i-1] == targetStringRunes[j-1] {
+			diagonal = matrix[i-1][j-1] + match
+		}
+
+		switch matrix[i][j] {
+		case diagonal:
+			alignedInput = append(alignedInput, inputStringRunes[i-1])
+			alignedTarget = append(alignedTarget, targetStringRunes[j-1])
+			i, j = i-1, j-1
+		case matrix[i-1][j] - gap:
+			alignedInput = append(alignedInput, inputStringRunes[i-1])
+			alignedTarget = append(alignedTarget, '-')
+			i -= 1
+		default:
+			alignedInput = append(alignedInput, '-')
+			alignedTarget = append(alignedTarget, targetStringRunes[j-1])
+			j -= 1
+		}
+	}
+	slices.Reverse(alignedInput)
+	slices.Reverse(alignedTarget)
+
+	// Convert the rune positions to byte offsets
+	inputStart := len(string(inputStringRunes[:i]))
+	inputEnd := len(string(inputStringRunes[:bestI]))
+	targetStart := len(string(targetStringRunes[:j]))
+	targetEnd := len(string(targetStringRunes[:bestJ]))
+
+	alignment := LocalAlignment{
+		Score:       bestScore,
+		InputMatch:  inputString[inputStart:inputEnd],
+		TargetMatch: targetString[targetStart:targetEnd],
+		InputStart:  inputStart,
+		InputEnd:    inputEnd,
+		TargetStart: targetStart,
+		TargetEnd:   targetEnd,
+	}
+	return alignment, string(alignedInput), string(alignedTarget)
+}
+
+// The default penalties used by AffineGapSimilarity
+const (
+	DefaultGapOpen   = 3 // The cost of the first rune of a gap
+	DefaultGapExtend = 1 // The cost of each rune after the first in a gap
+	DefaultMismatch  = 1 // The cost of replacing a rune
+)
+
+// Calculates the affine gap distance of two strings using Gotoh's algorithm
+//
+// # Notes
+//  - A gap (run of insertions or deletions) of k runes costs gapOpen + (k-1)*gapExtend, so one long gap is cheaper than many short ones
+//  - Useful when strings have extra chunks pasted into them, i.e. "abcdef" and "abcXXXXXXdef" are close when gapExtend is cheap
+//  - With all costs set to 1 it's the same as the Levenshtein distance
+//  - Uses the standard three matrix dynamic programming approach over runes, keeping 2 rows of each, and runs in O(m*n) time
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  gapOpen (int): The cost of the first rune of a gap
+//  gapExtend (int): The cost of each rune after the first in a gap
+//  mismatch (int): The cost of replacing a rune
+//
+// # Returns
+//  int: The lowest total cost of the gaps and mismatches that turn inputString into targetString
+func AffineGapDistance(inputString, targetString string, gapOpen, gapExtend, mismatch int) int {
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+	inputStringLength := len(inputStringRunes)
+	targetStringLength := len(targetStringRunes)
+
+	// Large enough to never be the minimum, but small enough to not overflow when costs are added
+	const unreachable = math.MaxInt / 4
+
+	// The lowest cost of aligning the prefixes that ends with a match or mismatch, a deletion, or an insertion
+	previousAligned := make([]int, targetStringLength+1)
+	previousDeleted := make([]int, targetStringLength+1)
+	previousInserted := make([]int, targetStringLength+1)
+	currentAligned := make([]int, targetStringLength+1)
+	currentDeleted := make([]int, targetStringLength+1)
+	currentInserted := make([]int, targetStringLength+1)
+
+	previousAligned[0], previousDeleted[0], previousInserted[0] = 0, unreachable, unreachable
+	for j := 1; j <= targetStringLength; j++ {
+		previousAligned[j] = unreachable
+		previousDeleted[j] = unreachable
+		previousInserted[j] = gapOpen + (j-1)*gapExtend
+	}
+
+	for i := 1; i <= inputStringLength; i++ {
+		currentAligned[0] = unreachable
+		currentDeleted[0] = gapOpen + (i-1)*gapExtend
+		currentInserted[0] = unreachable
+
+		for j := 1; j <= targetStringLength; j++ {
+			cost := mismatch
+			if inputStringRunes[i-1] == targetStringRunes[j-1] {
+				cost = 0
+			}
+			currentAligned[j] = min(previousAligned[j-1], previousDeleted[j-1], previousInserted[j-1]) + cost
+			currentDeleted[j] = min(previousAligned[j]+gapOpen, previousDeleted[j]+gapExtend, previousInserted[j]+gapOpen)
+			currentInserted[j] = min(currentAligned[j-1]+gapOpen, currentInserted[j-1]+gapExtend, currentDeleted[j-1]+gapOpen)
+		}
+
+		previousAligned, currentAligned = currentAligned, previousAligned
+		previousDeleted, currentDeleted = currentDeleted, previousDeleted
+		previousInserted, currentInserted = currentInserted, previousInserted
+	}
+
+	return min(previousAligned[targetStringLength], previousDeleted[targetStringLength], previousInserted[targetStringLength])
+}
+
+// Calculates the affine gap similarity of two strings using DefaultGapOpen, DefaultGapExtend and DefaultMismatch
+//
+// # Notes
+//  - Normalized by (m+n)*max(gapOpen, gapExtend), which is never less than the cost of deleting one string and inserting the other
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func AffineGapSimilarity(inputString, targetString string) float32 {
+	if inputString == targetString {
+		return 1
+	}
+
+	totalLength := len([]rune(inputString)) + len([]rune(targetString))
+	distance := AffineGapDistance(inputString, targetString, DefaultGapOpen, DefaultGapExtend, DefaultMismatch)
+	return 1 - float32(distance)/float32(totalLength*max(DefaultGapOpen, DefaultGapExtend))
+}
+
+// Scores a pair of aligned runes, higher scores mean the runes are a better match (i.e. a BLOSUM style table)
+type SubstitutionMatrix func(inputRune, targetRune rune) int
+
+// Creates a substitution matrix that scores every match and every mismatch the same
+//
+// # Parameters
+//  match (int): The score for runes that are equal
+//  mismatch (int): The penalty for runes that aren't equal, which is subtracted
+//
+// # Returns
+//  SubstitutionMatrix: The substitution matrix
+func NewSubstitutionMatrix(match, mismatch int) SubstitutionMatrix {
+	return func(inputRune, targetRune rune) int {
+		if inputRune == targetRune {
+			return match
+		}
+		return -mismatch
+	}
+}
+
+// Calculates the Needleman-Wunsch global alignment score of two strings
+//
+// # Notes
+//  - Unlike SmithWaterman, both strings are aligned from start to end, so it's suited to comparing whole sequences
+//  - mismatch and gap are penalties, so they should be positive and are subtracted from the score
+//  - The score can be negative for strings that line up badly, and is -gap times the length of the other string if one is empty
+//  - Gaps have a linear cost, use AffineGapDistance for gaps that cost less to extend than to open
+//  - Operates on runes, and runs in O(m*n) time
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  match (int): The score added for each matching rune
+//  mismatch (int): The penalty for each mismatched rune
+//  gap (int): The penalty for each rune skipped in either string
+//
+// # Returns
+//  int: The score of the best global alignment
+func NeedlemanWunsch(inputString, targetString string, match, mismatch, gap int) int {
+	score, _, _ := NeedlemanWunschWithMatrix(inputString, targetString, NewSubstitutionMatrix(match, mismatch), gap)
+	return score
+}
+
+// Finds the Needleman-Wunsch global alignment of two strings
+//
+// # Notes
+//  - Each string has a "-" where the other has a rune that it skips, so both strings are the same length and line up column by column
+//  - i.e. "GCATGCG" and "GATTACA" are "GCA-TGCG" and "G-ATTACA" with a match of 1, and penalties of 1
+//  - When several alignments have the same score, matches and mismatches are preferred over gaps, and gaps in targetString over gaps in inputString
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  match (int): The score added for each matching rune
+//  mismatch (int): The penalty for each mismatched rune
+//  gap (int): The penalty for each rune skipped in either string
+//
+// # Returns
+//  string: inputString, with "-" for gaps
+//  string: targetString, with "-" for gaps
+func NeedlemanWunschAlignment(inputString, targetString string, match, mismatch, gap int) (string, string) {
+	_, alignedInput, alignedTarget := NeedlemanWunschWithMatrix(inputString, targetString, NewSubstitutionMatrix(match, mismatch), gap)
+	return alignedInput, alignedTarget
+}
+
+// Finds the Needleman-Wunsch global alignment of two strings, scoring aligned runes with a substitution matrix
+//
+// # Notes
+//  - Useful when some mismatches are better than others, i.e. similar looking characters, or vowels replacing vowels
+//  - The whole matrix is kept to trace back the alignment, so it uses O(m*n) memory
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  substitution (SubstitutionMatrix): Scores each pair of aligned runes (i.e. NewSubstitutionMatrix(1, 1))
+//  gap (int): The penalty for each rune skipped in either string
+//
+// # Returns
+//  int: The score of the best global alignment
+//  string: inputString, with "-" for gaps
+//  string: targetString, with "-" for gaps
+func NeedlemanWunschWithMatrix(inputString, targetString string, substitution SubstitutionMatrix, gap int) (int, string, string) {
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+
+	matrix := make([][]int, len(inputStringRunes)+1)
+	for i := range matrix {
+		matrix[i] = make([]int, len(targetStringRunes)+1)
+		matrix[i][0] = -i * gap
+	}
+	for j := range matrix[0] {
+		matrix[0][j] = -j * gap
+	}
+
+	for i := 1; i <= len(inputStringRunes); i++ {
+		for j := 1; j <= len(targetStringRunes); j++ {
+			matrix[i][j] = max(
+				matrix[i-1][j-1]+substitution(inputStringRunes[i-1], targetStringRunes[j-1]), // Match or mismatch
+				matrix[i-1][j]-gap, // Gap in targetString
+				matrix[i][j-1]-gap, // Gap in inputString
+			)
+		}
+	}
+
+	// Trace back from the bottom right corner to the top left, the aligned runes are found backwards
+	alignedInput := make([]rune, 0, len(inputStringRunes)+len(targetStringRunes))
+	alignedTarget := make([]rune, 0, len(inputStringRunes)+len(targetStringRunes))
+	i, j := len(inputStringRunes), len(targetStringRunes)
+	for i > 0 || j > 0 {
+		switch {
+		case i > 0 && j > 0 && matrix[i][j] == matrix[i-1][j-1]+substitution(inputStringRunes[i-1], targetStringRunes[j-1]):
+			alignedInput = append(alignedInput, inputStringRunes[i-1])
+			alignedTarget = append(alignedTarget, targetStringRunes[j-1])
+			i, j = i-1, j-1
+		case i > 0 && matrix[i][j] == matrix[i-1][j]-gap:
+			alignedInput = append(alignedInput, inputStringRunes[i-1])
+			alignedTarget = append(alignedTarget, '-')
+			i -= 1
+		default:
+			alignedInput = append(alignedInput, '-')
+			alignedTarget = append(alignedTarget, targetStringRunes[j-1])
+			j -= 1
+		}
+	}
+	slices.Reverse(alignedInput)
+	slices.Reverse(alignedTarget)
+
+	return matrix[len(inputStringRunes)][len(targetStringRunes)], string(alignedInput), string(alignedTarget)
+}
@@ -0,0 +1,113 @@
+package algorithms
+
+// This file implements the Bitap (shift-and) algorithm, for finding where a pattern approximately appears in a longer text
+//
+// # References
+//  - https://en.wikipedia.org/wiki/Bitap_algorithm
+//  - https://doi.org/10.1145/135239.135244 (Wu and Manber 1992, Fast text searching allowing errors)
+
+import "slices"
+
+// Finds every position in a text where a pattern starts, allowing for a number of errors
+//
+// # Notes
+//  - An error is an insertion, deletion, or substitution, so a match is a substring of text with a Levenshtein distance of at most maxErrors from pattern
+//  - Keeps one bit per rune of the pattern for each number of errors, and updates them with bitwise operations for each rune of the text, so it runs in O(n*ceil(m/64)*(k+1))
+//  - Patterns longer than 64 runes are split across multiple words
+//  - Matches with errors can start at several neighbouring positions (i.e. "xabc" matches "abc" with one error at 0 and 1), all of them are returned
+//  - An empty pattern has no matches, and a negative maxErrors is treated as 0
+//  - Operates on runes
+//
+// # Parameters
+//  text (string): The text to search
+//  pattern (string): The pattern to search for
+//  maxErrors (int): The maximum number of errors a match can have
+//
+// # Returns
+//  []int: The byte offsets in text where a match starts, in increasing order
+func BitapSearch(text, pattern string, maxErrors int) []int {
+	maxErrors = max(maxErrors, 0)
+	positions := []int{}
+
+	patternRunes := []rune(pattern)
+	patternLength := len(patternRunes)
+	if patternLength == 0 {
+		return positions
+	}
+
+	// Bitap finds where matches end, so searching the reversed text for the reversed pattern finds where they start
+	textRunes := []rune(text)
+	offsets := make([]int, 0, len(textRunes))
+	for offset := range text {
+		offsets = append(offsets, offset)
+	}
+	slices.Reverse(textRunes)
+	slices.Reverse(patternRunes)
+
+	// Bit i of a rune's mask is set if the rune is at index i of the pattern
+	blocks := (patternLength + 63) / 64
+	masks := make(map[rune][]uint64)
+	for i, currentRune := range patternRunes {
+		mask, exists := masks[currentRune]
+		if !exists {
+			mask = make([]uint64, blocks)
+			masks[currentRune] = mask
+		}
+		mask[i/64] |= 1 << (i % 64)
+	}
+	emptyMask := make([]uint64, blocks)
+
+	// Bit i of states[d] is set if the first i+1 runes of the pattern match the end of the text read so far with at most d errors
+	// Before any text is read, matching the first i+1 runes takes i+1 deletions, so the first d bits start set
+	states := make([][]uint64, maxErrors+1)
+	for errors := range states {
+		states[errors] = make([]uint64, blocks)
+		for i := range min(errors, patternLength) {
+			states[errors][i/64] |= 1 << (i % 64)
+		}
+	}
+	previousState := make([]uint64, blocks) // The state for one fewer errors, before reading the current rune
+	oldState := make([]uint64, blocks)
+	lastBlock, lastBit := (patternLength-1)/64, uint64(1)<<((patternLength-1)%64)
+
+	for textIndex, currentRune := range textRunes {
+		mask, exists := masks[currentRune]
+		if !exists {
+			mask = emptyMask
+		}
+
+		// Exact matches extend by one rune if the rune is next in the pattern, and a new match can start at any rune
+		copy(previousState, states[0])
+		carry := uint64(1)
+		for block := range blocks {
+			shifted := previousState[block]<<1 | carry
+			carry = previousState[block] >> 63
+			states[0][block] = shifted & mask[block]
+		}
+
+		for errors := 1; errors <= maxErrors; errors++ {
+			copy(oldState, states[errors])
+			matchCarry, editCarry := uint64(1), uint64(1)
+			for block := range blocks {
+				matched := oldState[block]<<1 | matchCarry
+				matchCarry = oldState[block] >> 63
+
+				// Substituting the rune, or skipping a rune of the pattern, both move one rune further into the pattern
+				edited := previousState[block] | states[errors-1][block]
+				shiftedEdited := edited<<1 | editCarry
+				editCarry = edited >> 63
+
+				// Inserting the rune stays at the same place in the pattern
+				states[errors][block] = matched&mask[block] | shiftedEdited | previousState[block]
+			}
+			previousState, oldState = oldState, previousState
+		}
+
+		if states[maxErrors][lastBlock]&lastBit != 0 {
+			positions = append(positions, offsets[len(textRunes)-1-textIndex])
+		}
+	}
+
+	slices.Reverse(positions)
+	return positions
+}
@@ -0,0 +1,165 @@
+package algorithms
+
+// This file implements a least recently used (LRU) cache for similarity algorithms, for when the same pairs of strings are compared repeatedly,
+// and memoization for recursive distance algorithms, for when the same subproblems are solved repeatedly within a call
+//
+// # References
+//  - https://en.wikipedia.org/wiki/Cache_replacement_policies#LRU
+//  - https://pkg.go.dev/container/list
+//  - https://en.wikipedia.org/wiki/Memoization
+
+import (
+	"container/list"
+	"sync"
+)
+
+// The number of entries a CachedAlgorithm keeps if the maximum isn't valid
+const DefaultCacheEntries = 1024
+
+// The pair of strings a result is cached under
+type cacheKey struct {
+	first  string
+	second string
+}
+
+// A cached similarity, stored in the recency list
+type cacheEntry struct {
+	key        cacheKey
+	similarity float32
+}
+
+// A similarity algorithm wrapped with an LRU cache of its results
+//
+// # Notes
+//  - Pass the Similarity method anywhere a SimilarityAlgorithm is expected (i.e. SuggestWord(word, validWords, cached.Similarity))
+//  - Pairs are cached regardless of order, so the wrapped algorithm must be symmetric (i.e. not MongeElkanSimilarity() or PartialSimilarity() with a query longer than the target)
+//  - When the cache is full, the least recently used pair is removed to make room
+//  - Safe to use from multiple goroutines
+type CachedAlgorithm struct {
+	algorithm  SimilarityAlgorithm
+	maxEntries int
+
+	lock    sync.Mutex
+	entries map[cacheKey]*list.Element // The list elements hold *cacheEntry
+	recency *list.List                 // Most recently used at the front
+	hits    uint64
+	misses  uint64
+}
+
+// Wraps a similarity algorithm with an LRU cache
+//
+// # Parameters
+//  algorithm (SimilarityAlgorithm): The algorithm to cache the results of
+//  maxEntries (int): The maximum number of pairs to cache, DefaultCacheEntries is used if it's < 1
+//
+// # Returns
+//  *CachedAlgorithm: The cached algorithm
+func NewCachedAlgorithm(algorithm SimilarityAlgorithm, maxEntries int) *CachedAlgorithm {
+	if maxEntries < 1 {
+		maxEntries = DefaultCacheEntries
+	}
+	return &CachedAlgorithm{
+		algorithm:  algorithm,
+		maxEntries: maxEntries,
+		entries:    make(map[cacheKey]*list.Element, maxEntries),
+		recency:    list.New(),
+	}
+}
+
+// Calculates the similarity of two strings, using the cached result if the pair has been compared before
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func (cache *CachedAlgorithm) Similarity(inputString, targetString string) float32 {
+	key := cacheKey{inputString, targetString}
+	if targetString < inputString {
+		key = cacheKey{targetString, inputString}
+	}
+
+	cache.lock.Lock()
+	if element, exists := cache.entries[key]; exists {
+		cache.recency.MoveToFront(element)
+		cache.hits += 1
+		similarity := element.Value.(*cacheEntry).similarity
+		cache.lock.Unlock()
+		return similarity
+	}
+	cache.misses += 1
+	cache.lock.Unlock()
+
+	// Calculated without holding the lock, so slow algorithms don't block other lookups
+	similarity := cache.algorithm(inputString, targetString)
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	if element, exists := cache.entries[key]; exists {
+		// Another goroutine calculated the same pair in the meantime
+		cache.recency.MoveToFront(element)
+		return similarity
+	}
+	cache.entries[key] = cache.recency.PushFront(&cacheEntry{key, similarity})
+	if cache.recency.Len() > cache.maxEntries {
+		oldest := cache.recency.Back()
+		cache.recency.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*cacheEntry).key)
+	}
+	return similarity
+}
+
+// Gets the number of lookups that were found in the cache, and the number that had to be calculated
+//
+// # Returns
+//  uint64: The number of lookups that used a cached result
+//  uint64: The number of lookups that called the wrapped algorithm
+func (cache *CachedAlgorithm) CacheStats() (hits, misses uint64) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return cache.hits, cache.misses
+}
+
+// Gets the number of pairs in the cache
+//
+// # Returns
+//  int: The number of cached pairs
+func (cache *CachedAlgorithm) Len() int {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return cache.recency.Len()
+}
+
+// A recursive distance algorithm that makes its recursive calls through recurse instead of calling itself, so they can be intercepted
+type RecursiveDistance func(inputString, targetString string, recurse DistanceAlgorithm) int
+
+// Memoizes a recursive distance algorithm, so each subproblem is only calculated once
+//
+// # Notes
+//  - The cache only lasts for one top-level call, so memory is freed as soon as the distance is returned, use NewCachedAlgorithm() to cache results across calls
+//  - i.e. MemoizedDistance(RecursiveLevenshteinStep) runs in O(m*n) instead of O(3^n), since there are only (m+1)*(n+1) pairs of suffixes
+//  - The recursion has to go through recurse, wrapping a DistanceAlgorithm that calls itself directly would only cache the top-level call
+//  - Each call has its own cache, so the result is safe to use from multiple goroutines
+//
+// # Parameters
+//  step (RecursiveDistance): The recursive algorithm to memoize (i.e. RecursiveLevenshteinStep or RecursiveIndelStep)
+//
+// # Returns
+//  DistanceAlgorithm: The memoized algorithm
+func MemoizedDistance(step RecursiveDistance) DistanceAlgorithm {
+	return func(inputString, targetString string) int {
+		memo := make(map[cacheKey]int)
+		var recurse DistanceAlgorithm
+		recurse = func(inputString, targetString string) int {
+			key := cacheKey{inputString, targetString}
+			if distance, exists := memo[key]; exists {
+				return distance
+			}
+			distance := step(inputString, targetString, recurse)
+			memo[key] = distance
+			return distance
+		}
+		return recurse(inputString, targetString)
+	}
+}
@@ -0,0 +1,442 @@
+package algorithms
+
+// This file implements reusable scratch buffers for the dynamic programming distance algorithms, so comparing many strings doesn't allocate for every pair
+//
+// # References
+//  - https://pkg.go.dev/sync#Pool
+
+import (
+	"sync"
+	"unicode/utf8"
+)
+
+// The most runes, ints or uint64s a Comparer's buffers can hold and still be put back in the pool, so one huge comparison doesn't keep its buffers alive forever
+const maxPooledComparerSize = 1 << 16
+
+// Holds the buffers the distance algorithms use, so they can be reused between calls
+//
+// # Notes
+//  - The methods return the same results as the functions with the same names (i.e. comparer.LevenshteinDistance() and LevenshteinDistance())
+//  - The buffers grow to fit the longest strings compared, so after the first few calls comparisons don't allocate
+//  - Not safe to use from multiple goroutines, create one Comparer per goroutine instead
+//  - The package level functions already borrow a Comparer from an internal sync.Pool, a Comparer of your own skips the pool in hot loops
+type Comparer struct {
+	inputRunes  []rune
+	targetRunes []rune
+	ints        []int           // The rows (or whole matrix) of the dynamic programming algorithms
+	bits        []uint64        // The bit vectors and masks of MyersLevenshtein
+	runeMasks   map[rune]uint64 // The masks of the runes outside of ASCII in MyersLevenshtein
+	runeBlocks  map[rune]int    // Where the masks of the runes outside of ASCII start in bits, for the blocked version of MyersLevenshtein
+}
+
+// Creates a Comparer with empty buffers
+//
+// # Returns
+//  *Comparer: The comparer
+func NewComparer() *Comparer {
+	return &Comparer{runeMasks: make(map[rune]uint64), runeBlocks: make(map[rune]int)}
+}
+
+// The comparers the package level distance functions borrow
+var comparerPool = sync.Pool{New: func() any { return NewComparer() }}
+
+// Borrows a Comparer from the pool, it must be returned with putComparer()
+func getComparer() *Comparer {
+	return comparerPool.Get().(*Comparer)
+}
+
+// Returns a Comparer to the pool, unless its buffers have grown too large to be worth keeping
+func putComparer(comparer *Comparer) {
+	if cap(comparer.inputRunes) > maxPooledComparerSize || cap(comparer.targetRunes) > maxPooledComparerSize ||
+		cap(comparer.ints) > maxPooledComparerSize || cap(comparer.bits) > maxPooledComparerSize {
+		return
+	}
+	comparerPool.Put(comparer)
+}
+
+// Decodes both strings into the Comparer's rune buffers
+func (comparer *Comparer) loadRunes(inputString, targetString string) ([]rune, []rune) {
+	comparer.inputRunes = appendRunes(comparer.inputRunes[:0], inputString)
+	comparer.targetRunes = appendRunes(comparer.targetRunes[:0], targetString)
+	return comparer.inputRunes, comparer.targetRunes
+}
+
+// Appends the runes of text to buffer, without the allocation of []rune(text)
+func appendRunes(buffer []rune, text string) []rune {
+	for _, currentRune := range text {
+		buffer = append(buffer, currentRune)
+	}
+	return buffer
+}
+
+// Gets a zeroed buffer of n ints
+func (comparer *Comparer) intBuffer(n int) []int {
+	if cap(comparer.ints) < n {
+		comparer.ints = make([]int, n)
+	}
+	buffer := comparer.ints[:n]
+	clear(buffer)
+	return buffer
+}
+
+// Gets a zeroed buffer of n uint64s
+func (comparer *Comparer) bitBuffer(n int) []uint64 {
+	if cap(comparer.bits) < n {
+		comparer.bits = make([]uint64, n)
+	}
+	buffer := comparer.bits[:n]
+	clear(buffer)
+	return buffer
+}
+
+// Calculates the Levenshtein distance of two strings, the same as LevenshteinDistance()
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  int: The Levenshtein distance (add, edit, delete distance)
+func (comparer *Comparer) LevenshteinDistance(inputString, targetString string) int {
+	return comparer.MyersLevenshtein(inputString, targetString)
+}
+
+// Calculates the Levenshtein similarity of two strings, the same as LevenshteinSimilarity()
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func (comparer *Comparer) LevenshteinSimilarity(inputString, targetString string) float32 {
+	if inputString == targetString {
+		return 1
+	}
+	distance := comparer.LevenshteinDistance(inputString, targetString)
+	return 1 - float32(distance)/(float32(len(inputString))+float32(len(targetString)))
+}
+
+// Calculates the Levenshtein distance of two strings with the whole Wagner–Fischer matrix, the same as DynamicLevenshtein()
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  int: The Levenshtein distance (add, edit, delete distance)
+func (comparer *Comparer) DynamicLevenshtein(inputString, targetString string) int {
+	inputStringRunes, targetStringRunes := comparer.loadRunes(inputString, targetString)
+	inputStringLength := len(inputStringRunes)
+	targetStringLength := len(targetStringRunes)
+
+	// The matrix is stored a row at a time in one buffer
+	width := targetStringLength + 1
+	matrix := comparer.intBuffer((inputStringLength + 1) * width)
+
+	// Initialize base cases
+	for i := 0; i <= inputStringLength; i++ {
+		matrix[i*width] = i
+	}
+	for j := 0; j <= targetStringLength; j++ {
+		matrix[j] = j
+	}
+
+	// Fill the matrix
+	for i := 1; i <= inputStringLength; i++ {
+		for j := 1; j <= targetStringLength; j++ {
+			if inputStringRunes[i-1] == targetStringRunes[j-1] {
+				// Characters match, no cost added
+				matrix[i*width+j] = matrix[(i-1)*width+j-1]
+			} else {
+				matrix[i*width+j] = 1 + min(
+					matrix[i*width+j-1],     // Add
+					matrix[(i-1)*width+j],   // Delete
+					matrix[(i-1)*width+j-1], // Edit/replace
+				)
+			}
+		}
+	}
+
+	return matrix[inputStringLength*width+targetStringLength]
+}
+
+// Calculates the Levenshtein distance of two strings keeping 2 rows of the matrix, the same as SpaceEfficientLevenshteinDistance()
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  int: The Levenshtein distance (add, edit, delete distance)
+func (comparer *Comparer) SpaceEfficientLevenshteinDistance(inputString, targetString string) int {
+	inputStringRunes, targetStringRunes := comparer.loadRunes(inputString, targetString)
+
+	// The distance is symmetric, so make the rows as short as possible
+	if len(targetStringRunes) > len(inputStringRunes) {
+		inputStringRunes, targetStringRunes = targetStringRunes, inputStringRunes
+	}
+
+	rows := comparer.intBuffer(2 * (len(targetStringRunes) + 1))
+	previousRow, currentRow := rows[:len(targetStringRunes)+1], rows[len(targetStringRunes)+1:]
+	for j := range previousRow {
+		previousRow[j] = j
+	}
+
+	for i := 1; i <= len(inputStringRunes); i++ {
+		currentRow[0] = i
+		for j := 1; j <= len(targetStringRunes); j++ {
+			if inputStringRunes[i-1] == targetStringRunes[j-1] {
+				// Characters match, no cost added
+				currentRow[j] = previousRow[j-1]
+			} else {
+				currentRow[j] = 1 + min(
+					currentRow[j-1],  // Add
+					previousRow[j],   // Delete
+					previousRow[j-1], // Edit/replace
+				)
+			}
+		}
+		previousRow, currentRow = currentRow, previousRow
+	}
+
+	return previousRow[len(targetStringRunes)]
+}
+
+// Calculates the Levenshtein distance of two strings with Myers' bit-parallel algorithm, the same as MyersLevenshtein()
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  int: The Levenshtein distance (add, edit, delete distance)
+func (comparer *Comparer) MyersLevenshtein(inputString, targetString string) int {
+	inputStringRunes, targetStringRunes := comparer.loadRunes(inputString, targetString)
+
+	// The distance is symmetric, so use the shorter string as the pattern
+	if len(targetStringRunes) > len(inputStringRunes) {
+		inputStringRunes, targetStringRunes = targetStringRunes, inputStringRunes
+	}
+	if len(targetStringRunes) == 0 {
+		return len(inputStringRunes)
+	}
+	if len(targetStringRunes) <= 64 {
+		return comparer.myersSingleBlock(targetStringRunes, inputStringRunes)
+	}
+	return comparer.myersBlocks(targetStringRunes, inputStringRunes)
+}
+
+// Calculates the Levenshtein distance of a pattern of at most 64 runes against a text with Myers' algorithm
+func (comparer *Comparer) myersSingleBlock(pattern, text []rune) int {
+	// The rows each rune appears in, ASCII is looked up in an array since it's by far the most common
+	var asciiMasks [utf8.RuneSelf]uint64
+	otherMasks := comparer.runeMasks
+	clear(otherMasks)
+	for i, character := range pattern {
+		if character < utf8.RuneSelf {
+			asciiMasks[character] |= 1 << i
+		} else {
+			otherMasks[character] |= 1 << i
+		}
+	}
+
+	lastRow := uint64(1) << (len(pattern) - 1)
+	positive, negative := ^uint64(0), uint64(0) // The first column counts up from 0, so every vertical difference is +1
+	distance := len(pattern)
+	for _, character := range text {
+		var equal uint64
+		if character < utf8.RuneSelf {
+			equal = asciiMasks[character]
+		} else {
+			equal = otherMasks[character]
+		}
+
+		var carry int
+		positive, negative, carry = myersAdvanceBlock(positive, negative, equal, 1, lastRow)
+		distance += carry
+	}
+	return distance
+}
+
+// Calculates the Levenshtein distance of a pattern of any length against a text with the blocked version of Myers' algorithm
+func (comparer *Comparer) myersBlocks(pattern, text []rune) int {
+	blocks := (len(pattern) + 63) / 64
+
+	// Count the runes outside of ASCII first, so all the bit vectors fit in one buffer
+	otherBlocks := comparer.runeBlocks
+	clear(otherBlocks)
+	for _, character := range pattern {
+		if _, found := otherBlocks[character]; character >= utf8.RuneSelf && !found {
+			otherBlocks[character] = 0
+		}
+	}
+	bits := comparer.bitBuffer((utf8.RuneSelf + len(otherBlocks) + 2) * blocks)
+	asciiMasks := bits[:utf8.RuneSelf*blocks]
+	positive := bits[utf8.RuneSelf*blocks : (utf8.RuneSelf+1)*blocks]
+	negative := bits[(utf8.RuneSelf+1)*blocks : (utf8.RuneSelf+2)*blocks]
+	next := (utf8.RuneSelf + 2) * blocks
+	for character := range otherBlocks {
+		otherBlocks[character] = next
+		next += blocks
+	}
+
+	for i, character := range pattern {
+		if character < utf8.RuneSelf {
+			asciiMasks[int(character)*blocks+i/64] |= 1 << (i % 64)
+		} else {
+			bits[otherBlocks[character]+i/64] |= 1 << (i % 64)
+		}
+	}
+	for block := range positive {
+		positive[block] = ^uint64(0)
+	}
+
+	lastRow := uint64(1) << ((len(pattern) - 1) % 64)
+	distance := len(pattern)
+	for _, character := range text {
+		var equal []uint64
+		if character < utf8.RuneSelf {
+			equal = asciiMasks[int(character)*blocks : int(character+1)*blocks]
+		} else if start, found := otherBlocks[character]; found {
+			equal = bits[start : start+blocks]
+		}
+
+		// The first row counts up from 0, so the difference coming into the top block is always +1
+		carry := 1
+		for block := range blocks {
+			var blockEqual uint64
+			if equal != nil {
+				blockEqual = equal[block]
+			}
+			outputRow := uint64(1) << 63
+			if block == blocks-1 {
+				outputRow = lastRow
+			}
+			positive[block], negative[block], carry = myersAdvanceBlock(positive[block], negative[block], blockEqual, carry, outputRow)
+		}
+		distance += carry
+	}
+	return distance
+}
+
+// Calculates the Levenshtein distance of two strings, stopping early once it's over a maximum, the same as BoundedLevenshteinDistance()
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  maxDistance (int): The largest distance to calculate exactly
+//
+// # Returns
+//  int: The Levenshtein distance (add, edit, delete distance), or maxDistance+1 if it's larger than maxDistance
+func (comparer *Comparer) BoundedLevenshteinDistance(inputString, targetString string, maxDistance int) int {
+	maxDistance = max(maxDistance, 0)
+	limit := maxDistance + 1
+
+	inputStringRunes, targetStringRunes := comparer.loadRunes(inputString, targetString)
+	inputStringLength := len(inputStringRunes)
+	targetStringLength := len(targetStringRunes)
+
+	// Each rune of difference in length needs at least one insertion or deletion
+	if inputStringLength-targetStringLength > maxDistance || targetStringLength-inputStringLength > maxDistance {
+		return limit
+	}
+
+	// Cells outside of the band are treated as limit, since they can't be within maxDistance
+	rows := comparer.intBuffer(2 * (targetStringLength + 1))
+	previousRow, currentRow := rows[:targetStringLength+1], rows[targetStringLength+1:]
+	for j := range previousRow {
+		previousRow[j] = min(j, limit)
+	}
+
+	for i := 1; i <= inputStringLength; i++ {
+		low := max(1, i-maxDistance)
+		high := min(targetStringLength, i+maxDistance)
+
+		currentRow[0] = min(i, limit)
+		rowMinimum := limit
+		if low == 1 {
+			rowMinimum = currentRow[0]
+		} else {
+			currentRow[low-1] = limit
+		}
+
+		for j := low; j <= high; j++ {
+			if inputStringRunes[i-1] == targetStringRunes[j-1] {
+				// Characters match, no cost added
+				currentRow[j] = previousRow[j-1]
+			} else {
+				currentRow[j] = min(limit, 1+min(
+					currentRow[j-1],  // Add
+					previousRow[j],   // Delete
+					previousRow[j-1], // Edit/replace
+				))
+			}
+			rowMinimum = min(rowMinimum, currentRow[j])
+		}
+		if high < targetStringLength {
+			currentRow[high+1] = limit
+		}
+
+		// Every path goes through this row, so the distance can't get any smaller
+		if rowMinimum > maxDistance {
+			return limit
+		}
+		previousRow, currentRow = currentRow, previousRow
+	}
+
+	return min(previousRow[targetStringLength], limit)
+}
+
+// Calculates the Indel distance of two strings, the same as IndelDistance()
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  int: The indel distance (insert, delete distance)
+func (comparer *Comparer) IndelDistance(inputString, targetString string) int {
+	inputStringRunes, targetStringRunes := comparer.loadRunes(inputString, targetString)
+
+	// Only the previous row of the matrix is needed to calculate the current one
+	rows := comparer.intBuffer(2 * (len(targetStringRunes) + 1))
+	previousRow, currentRow := rows[:len(targetStringRunes)+1], rows[len(targetStringRunes)+1:]
+	for j := range previousRow {
+		previousRow[j] = j
+	}
+
+	for i := 1; i <= len(inputStringRunes); i++ {
+		currentRow[0] = i
+		for j := 1; j <= len(targetStringRunes); j++ {
+			if inputStringRunes[i-1] == targetStringRunes[j-1] {
+				// Characters match, no cost added
+				currentRow[j] = previousRow[j-1]
+			} else {
+				currentRow[j] = 1 + min(
+					currentRow[j-1], // Add
+					previousRow[j],  // Delete
+				)
+			}
+		}
+		previousRow, currentRow = currentRow, previousRow
+	}
+
+	return previousRow[len(targetStringRunes)]
+}
+
+// Calculates the Indel similarity of two strings, the same as IndelSimilarity()
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func (comparer *Comparer) IndelSimilarity(inputString, targetString string) float32 {
+	if inputString == targetString {
+		return 1
+	}
+	distance := comparer.IndelDistance(inputString, targetString)
+	return 1 - float32(distance)/(float32(len(inputString))+float32(len(targetString)))
+}
@@ -0,0 +1,224 @@
+package algorithms
+
+// This file implements edit scripts, the sequence of operations that turns one string into another
+//
+// # References
+//  - https://en.wikipedia.org/wiki/Levenshtein_distance
+//  - https://en.wikipedia.org/wiki/Wagner%E2%80%93Fischer_algorithm
+//  - https://en.wikipedia.org/wiki/Edit_distance#Formal_definition_and_properties
+//  - https://en.wikipedia.org/wiki/Sequence_alignment#Representations
+
+// The kind of change an EditOp makes
+type EditOpKind int
+
+const (
+	EditEqual      EditOpKind = iota // The rune is kept as-is
+	EditInsert                       // A rune from the target is added
+	EditDelete                       // A rune from the input is removed
+	EditSubstitute                   // A rune from the input is replaced by a rune from the target
+)
+
+// Returns the name of the kind of edit (i.e. "Insert")
+func (kind EditOpKind) String() string {
+	switch kind {
+	case EditEqual:
+		return "Equal"
+	case EditInsert:
+		return "Insert"
+	case EditDelete:
+		return "Delete"
+	case EditSubstitute:
+		return "Substitute"
+	default:
+		return "Unknown"
+	}
+}
+
+// A single operation in an edit script
+type EditOp struct {
+	Kind           EditOpKind // The kind of change
+	InputPosition  int        // The rune index in the input string the operation applies to, for inserts it's the index the rune is added before
+	TargetPosition int        // The rune index in the target string the operation produces, for deletes it's the index the next rune will be at
+	InputRune      rune       // The rune from the input string, 0 for inserts
+	TargetRune     rune       // The rune from the target string, 0 for deletes
+}
+
+// Calculates the operations needed to turn inputString into targetString
+//
+// # Notes
+//  - Backtracks through the Levenshtein matrix, so the number of non-equal operations is always LevenshteinDistance(inputString, targetString)
+//  - Includes an EditEqual operation for each rune that's kept, so the script covers every rune of both strings in order
+//  - When there's more than one minimal script, substitutions are preferred over deletions, and deletions over insertions
+//  - Uses O(m*n) memory, since the whole matrix is needed to backtrack
+//
+// # Parameters
+//  inputString (string): The string to start from
+//  targetString (string): The string to end up with
+//
+// # Returns
+//  []EditOp: The operations, in the order they apply to the strings
+func LevenshteinEditScript(inputString, targetString string) []EditOp {
+	// Convert to runes to avoid weird encoding issues
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+	inputStringLength := len(inputStringRunes)
+	targetStringLength := len(targetStringRunes)
+
+	matrix := make([][]int, inputStringLength+1)
+	for i := range matrix {
+		matrix[i] = make([]int, targetStringLength+1)
+		matrix[i][0] = i
+	}
+	for j := range matrix[0] {
+		matrix[0][j] = j
+	}
+
+	for i := 1; i <= inputStringLength; i++ {
+		for j := 1; j <= targetStringLength; j++ {
+			if inputStringRunes[i-1] == targetStringRunes[j-1] {
+				matrix[i][j] = matrix[i-1][j-1]
+			} else {
+				matrix[i][j] = 1 + min(
+					matrix[i][j-1],   // Add
+					matrix[i-1][j],   // Delete
+					matrix[i-1][j-1], // Edit/replace
+				)
+			}
+		}
+	}
+
+	// Walk back from the bottom right corner, following the cells each distance came from
+	ops := make([]EditOp, 0, max(inputStringLength, targetStringLength))
+	i, j := inputStringLength, targetStringLength
+	for i > 0 || j > 0 {
+		switch {
+		case i > 0 && j > 0 && inputStringRunes[i-1] == targetStringRunes[j-1] && matrix[i][j] == matrix[i-1][j-1]:
+			ops = append(ops, EditOp{EditEqual, i - 1, j - 1, inputStringRunes[i-1], targetStringRunes[j-1]})
+			i, j = i-1, j-1
+		case i > 0 && j > 0 && matrix[i][j] == matrix[i-1][j-1]+1:
+			ops = append(ops, EditOp{EditSubstitute, i - 1, j - 1, inputStringRunes[i-1], targetStringRunes[j-1]})
+			i, j = i-1, j-1
+		case i > 0 && matrix[i][j] == matrix[i-1][j]+1:
+			ops = append(ops, EditOp{EditDelete, i - 1, j, inputStringRunes[i-1], 0})
+			i -= 1
+		default:
+			ops = append(ops, EditOp{EditInsert, i, j - 1, 0, targetStringRunes[j-1]})
+			j -= 1
+		}
+	}
+
+	// The operations were found backwards
+	for left, right := 0, len(ops)-1; left < right; left, right = left+1, right-1 {
+		ops[left], ops[right] = ops[right], ops[left]
+	}
+	return ops
+}
+
+// Applies an edit script to a string
+//
+// # Notes
+//  - Operations must be in order of their InputPosition, the same as LevenshteinEditScript returns them
+//  - Runes of inputString that aren't covered by an operation are kept as-is, so EditEqual operations are optional
+//  - Only the InputPosition, Kind and TargetRune of each operation are used, InputRune isn't checked against inputString
+//
+// # Parameters
+//  inputString (string): The string to apply the operations to
+//  ops ([]EditOp): The operations to apply
+//
+// # Returns
+//  string: The edited string, which is the target string if ops came from LevenshteinEditScript(inputString, target)
+func ApplyEditScript(inputString string, ops []EditOp) string {
+	inputStringRunes := []rune(inputString)
+	result := make([]rune, 0, len(inputStringRunes)+len(ops))
+
+	next := 0 // The next rune of inputString that hasn't been used
+	for _, op := range ops {
+		// Keep any runes before the operation
+		if op.InputPosition > next {
+			end := min(op.InputPosition, len(inputStringRunes))
+			result = append(result, inputStringRunes[next:end]...)
+			next = end
+		}
+
+		switch op.Kind {
+		case EditEqual:
+			if next < len(inputStringRunes) {
+				result = append(result, inputStringRunes[next])
+				next += 1
+			}
+		case EditInsert:
+			result = append(result, op.TargetRune)
+		case EditDelete:
+			next += 1
+		case EditSubstitute:
+			result = append(result, op.TargetRune)
+			next += 1
+		}
+	}
+
+	if next < len(inputStringRunes) {
+		result = append(result, inputStringRunes[next:]...)
+	}
+	return string(result)
+}
+
+// Formats an edit script as three aligned lines, like pairwise sequence alignment tools do
+//
+// # Notes
+//  - Each operation is one column, the input rune goes in inputLine, the target rune in targetLine, and matchLine shows how they line up
+//  - matchLine has a "|" for runes that are equal, and a " " for substitutions, inserts and deletes
+//  - Deleted runes have a "-" in targetLine, and inserted runes have a "+" in inputLine (i.e. "kitten" and "sitting" are "kitten+", " ||| | " and "sitting")
+//  - Runes are taken from the strings at each operation's positions, falling back to the operation's runes if the position is out of range
+//  - Columns only line up in a monospace font, and only for runes that are a single column wide
+//
+// # Parameters
+//  inputString (string): The string the edit script starts from
+//  targetString (string): The string the edit script ends up with
+//  ops ([]EditOp): The edit script, if it's nil LevenshteinEditScript(inputString, targetString) is used
+//
+// # Returns
+//  string: The input line, with "+" where runes were inserted
+//  string: The target line, with "-" where runes were deleted
+//  string: The match line, with "|" where the runes are equal
+func AlignmentString(inputString, targetString string, ops []EditOp) (string, string, string) {
+	if ops == nil {
+		ops = LevenshteinEditScript(inputString, targetString)
+	}
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+
+	inputLine := make([]rune, 0, len(ops))
+	targetLine := make([]rune, 0, len(ops))
+	matchLine := make([]rune, 0, len(ops))
+	for _, op := range ops {
+		inputRune := op.InputRune
+		if op.InputPosition >= 0 && op.InputPosition < len(inputStringRunes) {
+			inputRune = inputStringRunes[op.InputPosition]
+		}
+		targetRune := op.TargetRune
+		if op.TargetPosition >= 0 && op.TargetPosition < len(targetStringRunes) {
+			targetRune = targetStringRunes[op.TargetPosition]
+		}
+
+		switch op.Kind {
+		case EditEqual:
+			inputLine = append(inputLine, inputRune)
+			targetLine = append(targetLine, targetRune)
+			matchLine = append(matchLine, '|')
+		case EditSubstitute:
+			inputLine = append(inputLine, inputRune)
+			targetLine = append(targetLine, targetRune)
+			matchLine = append(matchLine, ' ')
+		case EditDelete:
+			inputLine = append(inputLine, inputRune)
+			targetLine = append(targetLine, '-')
+			matchLine = append(matchLine, ' ')
+		case EditInsert:
+			inputLine = append(inputLine, '+')
+			targetLine = append(targetLine, targetRune)
+			matchLine = append(matchLine, ' ')
+		}
+	}
+
+	return string(inputLine), string(targetLine), string(matchLine)
+}
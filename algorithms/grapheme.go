@@ -0,0 +1,87 @@
+package algorithms
+
+// This file implements the Levenshtein distance of two strings over grapheme clusters (user-perceived characters) instead of runes
+//
+// # References
+//  - https://unicode.org/reports/tr29/#Grapheme_Cluster_Boundaries
+//  - https://pkg.go.dev/github.com/rivo/uniseg
+
+import "github.com/rivo/uniseg"
+
+// Splits a string into its extended grapheme clusters
+func graphemeClusters(inputString string) []string {
+	clusters := make([]string, 0, len(inputString))
+	state := -1
+	for len(inputString) > 0 {
+		var cluster string
+		cluster, inputString, _, state = uniseg.FirstGraphemeClusterInString(inputString, state)
+		clusters = append(clusters, cluster)
+	}
+	return clusters
+}
+
+// Calculates the Levenshtein similarity of two strings over grapheme clusters
+//
+// # Notes
+//  - The distance is normalized by the number of grapheme clusters in each string, instead of the number of bytes
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func GraphemeLevenshteinSimilarity(inputString, targetString string) float32 {
+	if inputString == targetString {
+		return 1
+	}
+
+	inputStringLength := uniseg.GraphemeClusterCount(inputString)
+	targetStringLength := uniseg.GraphemeClusterCount(targetString)
+	distance := GraphemeLevenshteinDistance(inputString, targetString)
+	return 1 - float32(distance)/float32(inputStringLength+targetStringLength)
+}
+
+// Calculates the Levenshtein distance of two strings over grapheme clusters
+//
+// # Notes
+//  - A grapheme cluster is what a reader sees as one character, even if it's made of several runes
+//  - i.e. the flag "🇬🇧" is 2 regional indicator runes, "é" can be "e" followed by a combining accent, and Hangul can be written as separate jamo
+//  - Uses the same Wagner–Fischer algorithm as DynamicLevenshtein, so it runs in roughly O(m*n)
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  int: The Levenshtein distance (add, edit, delete distance) in grapheme clusters
+func GraphemeLevenshteinDistance(inputString, targetString string) int {
+	inputClusters := graphemeClusters(inputString)
+	targetClusters := graphemeClusters(targetString)
+
+	// Only the previous row of the matrix is needed to calculate the current one
+	previousRow := make([]int, len(targetClusters)+1)
+	currentRow := make([]int, len(targetClusters)+1)
+	for j := range previousRow {
+		previousRow[j] = j
+	}
+
+	for i := 1; i <= len(inputClusters); i++ {
+		currentRow[0] = i
+		for j := 1; j <= len(targetClusters); j++ {
+			if inputClusters[i-1] == targetClusters[j-1] {
+				// Clusters match, no cost added
+				currentRow[j] = previousRow[j-1]
+			} else {
+				currentRow[j] = 1 + min(
+					currentRow[j-1],  // Add
+					previousRow[j],   // Delete
+					previousRow[j-1], // Edit/replace
+				)
+			}
+		}
+		previousRow, currentRow = currentRow, previousRow
+	}
+
+	return previousRow[len(targetClusters)]
+}
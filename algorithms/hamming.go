@@ -0,0 +1,87 @@
+package algorithms
+
+// This file implements the Hamming distance of two strings
+//
+// # References
+//  - https://en.wikipedia.org/wiki/Hamming_distance
+
+import "errors"
+
+// Returned when comparing strings with a different number of runes using an algorithm that requires equal lengths
+var ErrUnequalLength = errors.New("strings must have the same number of runes")
+
+// Calculates the Hamming similarity of two strings
+//
+// # Notes
+//  - Strings with a different number of runes have a similarity of 0, since the Hamming distance isn't defined for them
+//  - Use HammingSimilarityWithError to tell unequal lengths apart from strings that share no runes
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func HammingSimilarity(inputString, targetString string) float32 {
+	similarity, err := HammingSimilarityWithError(inputString, targetString)
+	if err != nil {
+		return 0
+	}
+	return similarity
+}
+
+// Calculates the Hamming similarity of two strings, returning an error if it isn't defined for them
+//
+// # Notes
+//  - The distance is normalized by the number of runes, so the similarity is the fraction of positions with the same rune
+//  - Doesn't satisfy SimilarityAlgorithm because of the error, use HammingSimilarity to plug it into SuggestWord
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+//  error: ErrUnequalLength if the strings have a different number of runes
+func HammingSimilarityWithError(inputString, targetString string) (float32, error) {
+	distance, err := HammingDistance(inputString, targetString)
+	if err != nil {
+		return 0, err
+	}
+
+	length := len([]rune(inputString))
+	if length == 0 {
+		return 1, nil
+	}
+	return 1 - float32(distance)/float32(length), nil
+}
+
+// Calculates the Hamming distance of two strings
+//
+// # Notes
+//  - Compares runes, not bytes, so "héllo" and "hallo" have a distance of 1
+//  - Runs in O(n) time
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  int: The Hamming distance (number of positions with different runes)
+//  error: ErrUnequalLength if the strings have a different number of runes
+func HammingDistance(inputString, targetString string) (int, error) {
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+
+	if len(inputStringRunes) != len(targetStringRunes) {
+		return 0, ErrUnequalLength
+	}
+
+	distance := 0
+	for i := range inputStringRunes {
+		if inputStringRunes[i] != targetStringRunes[i] {
+			distance += 1
+		}
+	}
+	return distance, nil
+}
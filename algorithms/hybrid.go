@@ -0,0 +1,60 @@
+package algorithms
+
+// This file implements hybrid scorers, which blend a phonetic similarity with an edit based similarity
+//
+// # References
+//  - https://en.wikipedia.org/wiki/Phonetic_algorithm
+//  - https://en.wikipedia.org/wiki/Edit_distance
+
+// The default weight of the phonetic similarity in HybridSimilarity(), the rest of the weight goes to the edit similarity
+const DefaultHybridWeight float32 = 0.5
+
+// Calculates a blend of the Metaphone and Levenshtein similarities of two strings
+//
+// # Notes
+//  - Phonetic codes catch misspellings that sound right (i.e. "fone" and "phone"), and edit distances catch typos that don't (i.e. "definately" and "definitely")
+//  - Equivalent to NewHybridScorer(MetaphoneSimilarity, LevenshteinSimilarity, DefaultHybridWeight)
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func HybridSimilarity(inputString, targetString string) float32 {
+	return hybridSimilarity(inputString, targetString, MetaphoneSimilarity, LevenshteinSimilarity, DefaultHybridWeight)
+}
+
+// Creates a similarity algorithm that blends a phonetic similarity with an edit based similarity
+//
+// # Notes
+//  - The result is weight*phonetic + (1-weight)*edit, clamped to between 0-1
+//  - Any two similarity algorithms can be blended, the names are only a guide
+//  - A weight outside of 0-1 is clamped, so 1 only uses phonetic and 0 only uses edit
+//
+// # Parameters
+//  phonetic (SimilarityAlgorithm): The phonetic similarity algorithm (i.e. MetaphoneSimilarity)
+//  edit (SimilarityAlgorithm): The edit based similarity algorithm (i.e. LevenshteinSimilarity)
+//  weight (float32): The weight of the phonetic similarity
+//
+// # Returns
+//  SimilarityAlgorithm: The blended algorithm
+func NewHybridScorer(phonetic, edit SimilarityAlgorithm, weight float32) SimilarityAlgorithm {
+	weight = max(0, min(weight, 1))
+	return func(inputString, targetString string) float32 {
+		return hybridSimilarity(inputString, targetString, phonetic, edit, weight)
+	}
+}
+
+// Blends the phonetic and edit similarities of two strings, weight must already be between 0-1
+func hybridSimilarity(inputString, targetString string, phonetic, edit SimilarityAlgorithm, weight float32) float32 {
+	// Skip an algorithm that has no weight, so it doesn't cost anything
+	var similarity float32
+	if weight > 0 {
+		similarity += weight * phonetic(inputString, targetString)
+	}
+	if weight < 1 {
+		similarity += (1 - weight) * edit(inputString, targetString)
+	}
+	return max(0, min(similarity, 1))
+}
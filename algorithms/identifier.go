@@ -0,0 +1,113 @@
+package algorithms
+
+// This file implements splitting identifiers into words, so the same name written in different styles (i.e. camelCase and snake_case) can be compared
+//
+// # References
+//  - https://en.wikipedia.org/wiki/Camel_case
+//  - https://en.wikipedia.org/wiki/Snake_case
+//  - https://en.wikipedia.org/wiki/Naming_convention_(programming)#Examples_of_multiple-word_identifier_formats
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Splits a camelCase or PascalCase identifier into its words (i.e. "getUserName" becomes ["get", "User", "Name"])
+//
+// # Notes
+//  - A new word starts at an uppercase letter after a lowercase one, and at the last uppercase letter of an acronym
+//     followed by a lowercase one (i.e. "parseHTTPResponse" becomes ["parse", "HTTP", "Response"])
+//  - Runs of digits are their own words (i.e. "base64Encode" becomes ["base", "64", "Encode"])
+//  - Anything that isn't a letter or digit separates words and is dropped, so snake_case and kebab-case are split as well
+//  - The case of the words is kept
+//
+// # Parameters
+//  identifier (string): The identifier to split
+//
+// # Returns
+//  []string: The words of the identifier, in order
+func SegmentCamelCase(identifier string) []string {
+	var segments []string
+	runes := []rune(identifier)
+	start := 0
+	for i, currentRune := range runes {
+		if !unicode.IsLetter(currentRune) && !unicode.IsDigit(currentRune) {
+			if start < i {
+				segments = append(segments, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		}
+		if i > start && startsCamelCaseWord(runes, i) {
+			segments = append(segments, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		segments = append(segments, string(runes[start:]))
+	}
+	return segments
+}
+
+// Reports whether a new word starts at runes[i], given that runes[i-1] is a letter or digit in the same word
+func startsCamelCaseWord(runes []rune, i int) bool {
+	previous, current := runes[i-1], runes[i]
+	if unicode.IsDigit(previous) != unicode.IsDigit(current) {
+		return true
+	}
+	if !unicode.IsUpper(current) {
+		return false
+	}
+	if !unicode.IsUpper(previous) {
+		return true
+	}
+
+	// The last letter of an acronym belongs to the word after it (i.e. the "S" in "HTTPServer")
+	return i+1 < len(runes) && unicode.IsLower(runes[i+1])
+}
+
+// Splits a snake_case identifier into its words (i.e. "get_user_name" becomes ["get", "user", "name"])
+//
+// # Notes
+//  - Leading, trailing and repeated underscores don't make empty words (i.e. "__init__" becomes ["init"])
+//  - The case of the words is kept, so SCREAMING_SNAKE_CASE works as well
+//
+// # Parameters
+//  identifier (string): The identifier to split
+//
+// # Returns
+//  []string: The words of the identifier, in order
+func SegmentSnakeCase(identifier string) []string {
+	return strings.FieldsFunc(identifier, func(currentRune rune) bool {
+		return currentRune == '_'
+	})
+}
+
+// Calculates the similarity of two identifiers regardless of how their words are written (i.e. "getUserName" and "get_user_name" have a similarity of 1)
+//
+// # Notes
+//  - Identifiers with an underscore are split with SegmentSnakeCase(), anything else with SegmentCamelCase()
+//  - The words are lowercased and joined with spaces before being passed to algorithm (i.e. both examples become "get user name")
+//  - Useful for code search and refactoring tools, where the same name is often written in different styles
+//
+// # Parameters
+//  inputString (string): The first identifier to use for the comparison
+//  targetString (string): The second identifier to use for the comparison
+//  algorithm (SimilarityAlgorithm): The algorithm to compare the normalized identifiers with
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func IdentifierSimilarity(inputString, targetString string, algorithm SimilarityAlgorithm) float32 {
+	return algorithm(normalizeIdentifier(inputString), normalizeIdentifier(targetString))
+}
+
+// Splits an identifier in whichever style it's written in, and joins the lowercased words with spaces
+func normalizeIdentifier(identifier string) string {
+	var segments []string
+	if strings.ContainsRune(identifier, '_') {
+		segments = SegmentSnakeCase(identifier)
+	} else {
+		segments = SegmentCamelCase(identifier)
+	}
+	return strings.ToLower(strings.Join(segments, " "))
+}
@@ -26,14 +26,112 @@ func IndelSimilarity(inputString, targetString string) float32 {
 //
 // # Notes
 //  - Equivalent to the Levenshtein distance where the cost of substitution is 2, and the cost of insertion or deletion is 1
+//  - Uses DynamicIndelDistance, so it runs in O(m*n) time and only keeps 2 rows of the matrix in memory
 //
 // # Parameters
 //  inputString (string): The first string to use for the comparison
 //  targetString (string): The second string to use for the comparison
 //
 // # Returns
-//  int: The indel distance (edit, delete distance)
+//  int: The indel distance (insert, delete distance)
 func IndelDistance(inputString, targetString string) int {
+	return DynamicIndelDistance(inputString, targetString)
+}
+
+// Calculates the Indel distance of two strings with dynamic programming
+//
+// # Notes
+//  - Works like SpaceEfficientLevenshteinDistance, but a substitution is a deletion and an insertion, so it costs 2 and never needs its own case
+//  - Operates on runes, so a multi-byte character counts as one insertion or deletion
+//  - The rows are borrowed from an internal pool, see Comparer to reuse them without the pool
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  int: The indel distance (insert, delete distance)
+func DynamicIndelDistance(inputString, targetString string) int {
+	comparer := getComparer()
+	defer putComparer(comparer)
+	return comparer.IndelDistance(inputString, targetString)
+}
+
+// Calculates the Indel distance of two strings, with a custom function to decide which runes are equal
+//
+// # Notes
+//  - Useful for treating classes of runes as the same without changing the strings first (i.e. FoldCase, DigitsEqual or WhitespaceEqual)
+//  - A nil equal is exact equality, the same as IndelDistance
+//  - equal is always called with a rune from inputString first, and a rune from targetString second
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  equal (RuneEqualFunc): Reports whether two runes are equal, use AnyRuneEqual to combine several
+//
+// # Returns
+//  int: The indel distance (insert, delete distance)
+func IndelDistanceFunc(inputString, targetString string, equal RuneEqualFunc) int {
+	if equal == nil {
+		return IndelDistance(inputString, targetString)
+	}
+
+	// Convert to runes to avoid weird encoding issues
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+
+	// Only the previous row of the matrix is needed to calculate the current one
+	previousRow := make([]int, len(targetStringRunes)+1)
+	currentRow := make([]int, len(targetStringRunes)+1)
+	for j := range previousRow {
+		previousRow[j] = j
+	}
+
+	for i := 1; i <= len(inputStringRunes); i++ {
+		currentRow[0] = i
+		for j := 1; j <= len(targetStringRunes); j++ {
+			if equal(inputStringRunes[i-1], targetStringRunes[j-1]) {
+				// Characters match, no cost added
+				currentRow[j] = previousRow[j-1]
+			} else {
+				currentRow[j] = 1 + min(
+					currentRow[j-1], // Add
+					previousRow[j],  // Delete
+				)
+			}
+		}
+		previousRow, currentRow = currentRow, previousRow
+	}
+
+	return previousRow[len(targetStringRunes)]
+}
+
+// Calculates the Indel distance of two strings recursively
+//
+// # Notes
+//  - Very slow, roughly O(2^n), use IndelDistance or MemoizedDistance(RecursiveIndelStep) instead
+//  - Operates on bytes, so a multi-byte character counts as more than one insertion or deletion
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  int: The indel distance (insert, delete distance)
+func RecursiveIndelDistance(inputString, targetString string) int {
+	return RecursiveIndelStep(inputString, targetString, RecursiveIndelDistance)
+}
+
+// One step of RecursiveIndelDistance, which makes its recursive calls through recurse
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  recurse (DistanceAlgorithm): The function to calculate the distances of the shorter strings with
+//
+// # Returns
+//  int: The indel distance (insert, delete distance)
+func RecursiveIndelStep(inputString, targetString string, recurse DistanceAlgorithm) int {
 	// Base cases
 	if len(inputString) == 0 {
 		return len(targetString)
@@ -44,19 +142,19 @@ func IndelDistance(inputString, targetString string) int {
 
 	// If characters match, no cost, move to next characters
 	if inputString[0] == targetString[0] {
-		return IndelDistance(inputString[1:], targetString[1:])
+		return recurse(inputString[1:], targetString[1:])
 	}
 
 	// If characters do NOT match, we must perform an operation.
 	// We consider two options:
 	// 1. Delete inputString[0]: cost 1 + distance of remaining inputString vs targetString
 	//    (Effectively, we're removing the current mismatching character from inputString)
-	deleteCost := 1 + IndelDistance(inputString[1:], targetString)
+	deleteCost := 1 + recurse(inputString[1:], targetString)
 
 	// 2. Insert targetString[0] into inputString: cost 1 + distance of inputString vs remaining targetString
 	//    (Effectively, we're adding the current mismatching character from targetString to inputString,
 	//     and then we still need to align the rest of inputString)
-	insertCost := 1 + IndelDistance(inputString, targetString[1:])
+	insertCost := 1 + recurse(inputString, targetString[1:])
 
 	// Return the minimum of these two options
 	return min(deleteCost, insertCost)
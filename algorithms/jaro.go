@@ -23,6 +23,25 @@ import "math"
 // # Returns
 //  float32: A value between 0 and 1 representing the Jaro similarity score
 func JaroSimilarity(inputString, targetString string) float32 {
+	return JaroSimilarityWindow(inputString, targetString, -1)
+}
+
+// Calculates the Jaro similarity between two strings, with a custom matching window
+//
+// # Notes
+//  - Characters only count as matching if they're within window positions of each other
+//  - The standard window is floor(max(len(inputString), len(targetString))/2) - 1, which is used if window is < 0
+//  - The window is clamped to at least 0, so characters at the same position always count, even in 1 character strings
+//  - A wider window helps with noisy data (i.e. transcriptions) where matching characters can be further apart
+//
+// # Parameters
+//  inputString (string): The first string for comparison
+//  targetString (string): The second string for comparison
+//  window (int): How many positions apart characters can be and still match
+//
+// # Returns
+//  float32: A value between 0 and 1 representing the Jaro similarity score
+func JaroSimilarityWindow(inputString, targetString string, window int) float32 {
 	// If the strings are equal
 	if inputString == targetString {
 		return 1.0
@@ -31,8 +50,12 @@ func JaroSimilarity(inputString, targetString string) float32 {
 	inputStringLength := len(inputString)
 	targetStringLength := len(targetString)
 
-	// How far to consider a letter a match (half the longest string - 1)
-	max_match_distance := math.Floor(float64(max(inputStringLength, targetStringLength))/2.0) - 1
+	// How far to consider a letter a match (half the longest string - 1 by default)
+	max_match_distance := float64(window)
+	if window < 0 {
+		max_match_distance = math.Floor(float64(max(inputStringLength, targetStringLength))/2.0) - 1
+	}
+	max_match_distance = max(max_match_distance, 0)
 
 	matches := 0 // How many matches
 
@@ -71,6 +94,91 @@ func JaroSimilarity(inputString, targetString string) float32 {
 		3.0
 }
 
+// Creates a Jaro similarity algorithm with a custom matching window
+//
+// # Parameters
+//  window (int): How many positions apart characters can be and still match, the standard window is used if it's < 0
+//
+// # Returns
+//  SimilarityAlgorithm: The Jaro similarity algorithm, see JaroSimilarityWindow()
+func NewJaroSimilarityWindow(window int) SimilarityAlgorithm {
+	return func(inputString, targetString string) float32 {
+		return JaroSimilarityWindow(inputString, targetString, window)
+	}
+}
+
+// Calculates the Jaro-Winkler similarity between two strings
+//
+// The Jaro-Winkler similarity is the Jaro similarity, with a bonus for strings that share a common prefix.
+// This makes it better suited than the Jaro similarity for matching names, where typos are less common at the start
+//
+// # Notes
+//  - Uses the standard scaling factor of 0.1, and a maximum prefix length of 4
+//
+// # Parameters
+//  inputString (string): The first string for comparison
+//  targetString (string): The second string for comparison
+//
+// # Returns
+//  float32: A value between 0 and 1 representing the Jaro-Winkler similarity score
+func JaroWinklerSimilarity(inputString, targetString string) float32 {
+	return JaroWinklerSimilarityWithOptions(inputString, targetString, 0.1, 4)
+}
+
+// Calculates the Jaro-Winkler similarity between two strings with a custom prefix scale
+//
+// # Notes
+//  - prefixScale is clamped to [0, 0.25], since anything over 0.25 with a 4 character prefix would push the score over 1
+//  - Uses the standard maximum prefix length of 4
+//
+// # Parameters
+//  inputString (string): The first string for comparison
+//  targetString (string): The second string for comparison
+//  prefixScale (float32): How much each character of common prefix boosts the score (standard is 0.1)
+//
+// # Returns
+//  float32: A value between 0 and 1 representing the Jaro-Winkler similarity score
+func JaroWinklerSimilarityWithPrefix(inputString, targetString string, prefixScale float32) float32 {
+	const maxPrefixLength = 4
+	prefixScale = max(0, min(prefixScale, 1.0/maxPrefixLength))
+	return JaroWinklerSimilarityWithOptions(inputString, targetString, prefixScale, maxPrefixLength)
+}
+
+// Calculates the Jaro-Winkler similarity between two strings with a custom prefix bonus
+//
+// # Notes
+//  - The prefix is counted in runes, not bytes
+//  - The result is capped at 1, so a scalingFactor*maxPrefixLength over 1 can't produce an invalid score
+//  - A negative scalingFactor or maxPrefixLength is treated as 0, so the result never drops below the Jaro similarity
+//
+// # Parameters
+//  inputString (string): The first string for comparison
+//  targetString (string): The second string for comparison
+//  scalingFactor (float32): How much each character of common prefix boosts the score (standard is 0.1)
+//  maxPrefixLength (int): The maximum number of prefix characters that count towards the bonus (standard is 4)
+//
+// # Returns
+//  float32: A value between 0 and 1 representing the Jaro-Winkler similarity score
+func JaroWinklerSimilarityWithOptions(inputString, targetString string, scalingFactor float32, maxPrefixLength int) float32 {
+	scalingFactor = max(0, scalingFactor)
+	maxPrefixLength = max(0, maxPrefixLength)
+	similarity := JaroSimilarity(inputString, targetString)
+
+	// Count the common prefix
+	prefixLength := 0
+	targetStringRunes := []rune(targetString)
+	for i, currentRune := range []rune(inputString) {
+		if i >= maxPrefixLength || i >= len(targetStringRunes) || currentRune != targetStringRunes[i] {
+			break
+		}
+		prefixLength += 1
+	}
+
+	// sim_w = sim_j + l*p*(1-sim_j) SEE: https://en.wikipedia.org/wiki/Jaro%E2%80%93Winkler_distance#Jaro%E2%80%93Winkler_similarity
+	similarity += float32(prefixLength) * scalingFactor * (1 - similarity)
+	return min(similarity, 1)
+}
+
 // Calculates the number of transpositions between two strings
 //
 // A transposition is when two characters are in the wrong order. This function assumes
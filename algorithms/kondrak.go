@@ -0,0 +1,124 @@
+package algorithms
+
+// This file implements Kondrak's n-gram distance, a generalization of the edit distance that compares n-grams instead of single characters
+//
+// # References
+//  - https://webdocs.cs.ualberta.ca/~kondrak/papers/spire05.pdf
+//  - https://lucene.apache.org/core/9_0_0/suggest/org/apache/lucene/search/spell/NGramDistance.html
+
+// The default number of runes in each n-gram used by KondrakNGramSimilarity
+const DefaultKondrakNGramSize = 2
+
+// Marks the padding at the start of a string, it can't be confused with a rune in the string since it isn't a valid rune
+const kondrakPadding rune = -1
+
+// Calculates Kondrak's n-gram distance of two strings
+//
+// # Notes
+//  - The same dynamic programming as the Levenshtein distance, but instead of comparing the runes at each position, the n-grams ending at each position are compared
+//  - Substituting an n-gram costs the fraction of its runes that differ, so n-grams that partially match are cheaper to substitute
+//  - The start of each string is padded with n-1 characters, so the first runes are part of as many n-grams as the rest
+//  - The distance is normalized by the length of the longer string in runes, so it's between 0 and 1
+//  - With n=1 this is the Levenshtein distance divided by the length of the longer string
+//  - Strings shorter than n are compared by counting the runes that are the same at each position
+//  - Matches the NGramDistance in Apache Lucene's spell checker
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  n (int): The number of runes in each n-gram, DefaultKondrakNGramSize is used if it's < 1
+//
+// # Returns
+//  float32: The normalized distance (between 0-1, closer to 0 is more similar)
+func NGramDistance(inputString, targetString string, n int) float32 {
+	if n < 1 {
+		n = DefaultKondrakNGramSize
+	}
+
+	// Convert to runes to avoid weird encoding issues
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+	inputStringLength := len(inputStringRunes)
+	targetStringLength := len(targetStringRunes)
+	longestLength := max(inputStringLength, targetStringLength)
+	if inputStringLength == 0 || targetStringLength == 0 {
+		if longestLength == 0 {
+			return 0
+		}
+		return 1
+	}
+
+	// Too short to have a full n-gram, so count the runes that line up
+	if inputStringLength < n || targetStringLength < n {
+		matches := 0
+		for i := range min(inputStringLength, targetStringLength) {
+			if inputStringRunes[i] == targetStringRunes[i] {
+				matches += 1
+			}
+		}
+		return 1 - float32(matches)/float32(longestLength)
+	}
+
+	paddedInput := make([]rune, n-1, inputStringLength+n-1)
+	for i := range paddedInput {
+		paddedInput[i] = kondrakPadding
+	}
+	paddedInput = append(paddedInput, inputStringRunes...)
+	paddedTarget := make([]rune, n-1, targetStringLength+n-1)
+	for i := range paddedTarget {
+		paddedTarget[i] = kondrakPadding
+	}
+	paddedTarget = append(paddedTarget, targetStringRunes...)
+
+	// The n-gram ending at rune i of a string starts at index i-1 of the padded string
+	previousRow := make([]float32, inputStringLength+1)
+	currentRow := make([]float32, inputStringLength+1)
+	for i := range previousRow {
+		previousRow[i] = float32(i)
+	}
+
+	for j := 1; j <= targetStringLength; j++ {
+		targetGram := paddedTarget[j-1 : j-1+n]
+		currentRow[0] = float32(j)
+
+		for i := 1; i <= inputStringLength; i++ {
+			inputGram := paddedInput[i-1 : i-1+n]
+
+			// The cost is the fraction of the runes that differ, ignoring padding both n-grams have
+			differences := 0
+			comparedLength := n
+			for k := range n {
+				if inputGram[k] != targetGram[k] {
+					differences += 1
+				} else if inputGram[k] == kondrakPadding {
+					comparedLength -= 1
+				}
+			}
+			substitutionCost := float32(differences) / float32(comparedLength)
+
+			currentRow[i] = min(
+				currentRow[i-1]+1,                 // Deletion
+				previousRow[i]+1,                  // Insertion
+				previousRow[i-1]+substitutionCost, // Substitution
+			)
+		}
+		previousRow, currentRow = currentRow, previousRow
+	}
+
+	return previousRow[inputStringLength] / float32(longestLength)
+}
+
+// Calculates the similarity of two strings using Kondrak's n-gram distance with DefaultKondrakNGramSize
+//
+// # Notes
+//  - Named KondrakNGramSimilarity since NGramSimilarity() is the Sørensen–Dice coefficient of the n-grams
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func KondrakNGramSimilarity(inputString, targetString string) float32 {
+	return 1 - NGramDistance(inputString, targetString, DefaultKondrakNGramSize)
+}
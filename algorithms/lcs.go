@@ -0,0 +1,125 @@
+package algorithms
+
+// This file implements the Longest Common Subsequence (LCS) distance of two strings
+//
+// # References
+//  - https://en.wikipedia.org/wiki/Longest_common_subsequence
+//  - https://en.wikipedia.org/wiki/Edit_distance#Types_of_edit_distance
+
+// Calculates the LCS similarity of two strings
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func LCSSimilarity(inputString, targetString string) float32 {
+	similarity := CalculateSimilarity(inputString, targetString, LCSDistance)
+	return similarity
+}
+
+// Calculates the LCS distance of two strings
+//
+// # Notes
+//  - The number of insertions and deletions needed to turn one string into the other, a substitution counts as 2
+//  - Calculated as len(inputString) + len(targetString) - 2*LCSLength(inputString, targetString)
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  int: The LCS distance (insert, delete distance)
+func LCSDistance(inputString, targetString string) int {
+	inputStringLength := len([]rune(inputString))
+	targetStringLength := len([]rune(targetString))
+	return inputStringLength + targetStringLength - 2*LCSLength(inputString, targetString)
+}
+
+// Calculates the length of the longest common subsequence of two strings
+//
+// # Notes
+//  - A subsequence doesn't need to be contiguous, so "ace" is a subsequence of "abcde"
+//  - Uses the dynamic programming approach over runes, and runs in O(m*n) time
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  int: The number of runes in the longest common subsequence
+func LCSLength(inputString, targetString string) int {
+	// Convert to runes to avoid weird encoding issues
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+
+	// Only the previous row of the matrix is needed to calculate the current one
+	previousRow := make([]int, len(targetStringRunes)+1)
+	currentRow := make([]int, len(targetStringRunes)+1)
+
+	for i := 1; i <= len(inputStringRunes); i++ {
+		for j := 1; j <= len(targetStringRunes); j++ {
+			if inputStringRunes[i-1] == targetStringRunes[j-1] {
+				// Characters match, extend the subsequence
+				currentRow[j] = previousRow[j-1] + 1
+			} else {
+				currentRow[j] = max(previousRow[j], currentRow[j-1])
+			}
+		}
+		previousRow, currentRow = currentRow, previousRow
+	}
+
+	return previousRow[len(targetStringRunes)]
+}
+
+// Finds the longest common subsequence of two strings
+//
+// # Notes
+//  - Useful to visualize how two strings line up, i.e. "AGGTAB" and "GXTXAYB" have "GTAB" in common
+//  - When there are several longest common subsequences only one of them is returned
+//  - Keeps the whole matrix to trace back through, so it uses O(m*n) memory unlike LCSLength
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  string: The longest common subsequence
+func LCSString(inputString, targetString string) string {
+	// Convert to runes to avoid weird encoding issues
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+
+	matrix := make([][]int, len(inputStringRunes)+1)
+	for i := range matrix {
+		matrix[i] = make([]int, len(targetStringRunes)+1)
+	}
+	for i := 1; i <= len(inputStringRunes); i++ {
+		for j := 1; j <= len(targetStringRunes); j++ {
+			if inputStringRunes[i-1] == targetStringRunes[j-1] {
+				matrix[i][j] = matrix[i-1][j-1] + 1
+			} else {
+				matrix[i][j] = max(matrix[i-1][j], matrix[i][j-1])
+			}
+		}
+	}
+
+	// Trace back from the end, collecting the matching runes in reverse
+	subsequence := make([]rune, matrix[len(inputStringRunes)][len(targetStringRunes)])
+	i, j, k := len(inputStringRunes), len(targetStringRunes), len(subsequence)
+	for i > 0 && j > 0 {
+		switch {
+		case inputStringRunes[i-1] == targetStringRunes[j-1]:
+			k -= 1
+			subsequence[k] = inputStringRunes[i-1]
+			i, j = i-1, j-1
+		case matrix[i-1][j] >= matrix[i][j-1]:
+			i -= 1
+		default:
+			j -= 1
+		}
+	}
+
+	return string(subsequence)
+}
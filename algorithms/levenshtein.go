@@ -1,5 +1,7 @@
 package algorithms
 
+import "unicode/utf8"
+
 // Calculates the Levenshtein similarity of two strings
 //
 // # Parameters
@@ -18,6 +20,7 @@ func LevenshteinSimilarity(inputString, targetString string) float32 {
 //
 // # Notes
 //  - This solution utilizes the dynamic programming approach, not the recursive one
+//  - Uses MyersLevenshtein, which computes 64 cells of the matrix at a time with bitwise operations
 //
 // # Parameters
 //  inputString (string): The first string to use for the comparison
@@ -26,14 +29,16 @@ func LevenshteinSimilarity(inputString, targetString string) float32 {
 // # Returns
 //  int: The Levenshtein distance (add, edit, delete distance)
 func LevenshteinDistance(inputString, targetString string) int {
-	return DynamicLevenshtein(inputString, targetString)
+	return MyersLevenshtein(inputString, targetString)
 }
 
 // Calculates the Levenshtein distance of two strings recursively
 //
 // # Notes
 //  - Heavily inspired by the recursive haskel implementation on wikipedia https://en.wikipedia.org/wiki/Levenshtein_distance#Recursive
-//  - Very slow, roughly O(3^n)
+//  - Each pair of suffixes is only calculated once, so it runs in O(m*n) instead of the O(3^n) of the plain recursion (see RecursiveLevenshteinStep)
+//  - Recurses once per rune of both strings, so it's still slower than LevenshteinDistance and uses more memory
+//  - Operates on runes, so it returns the same distance as DynamicLevenshtein
 //
 // # Parameters
 //  inputString (string): The first string to use for the comparison
@@ -42,26 +47,80 @@ func LevenshteinDistance(inputString, targetString string) int {
 // # Returns
 //  int: The Levenshtein distance (add, edit, delete distance)
 func RecursiveLevenshtein(inputString, targetString string) int {
+	// Convert to runes to avoid weird encoding issues
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+
+	// The distance of the suffixes starting at i and j, or -1 if it hasn't been calculated yet
+	width := len(targetStringRunes) + 1
+	memo := make([]int, (len(inputStringRunes)+1)*width)
+	for index := range memo {
+		memo[index] = -1
+	}
+
+	var distance func(i, j int) int
+	distance = func(i, j int) int {
+		if i == len(inputStringRunes) {
+			return len(targetStringRunes) - j
+		}
+		if j == len(targetStringRunes) {
+			return len(inputStringRunes) - i
+		}
+		if memo[i*width+j] >= 0 {
+			return memo[i*width+j]
+		}
+
+		var result int
+		if inputStringRunes[i] == targetStringRunes[j] {
+			result = distance(i+1, j+1)
+		} else {
+			result = 1 + min(
+				distance(i, j+1),   // Add
+				distance(i+1, j),   // Delete
+				distance(i+1, j+1), // Edit/replace
+			)
+		}
+		memo[i*width+j] = result
+		return result
+	}
+
+	return distance(0, 0)
+}
+
+// One step of the recursive Levenshtein distance, which makes its recursive calls through recurse
+//
+// # Notes
+//  - Calling it with itself as recurse is the plain recursion, which is very slow, roughly O(3^n), MemoizedDistance(RecursiveLevenshteinStep) brings it down to O(m*n)
+//  - Operates on runes, so a multi-byte character counts as one edit
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  recurse (DistanceAlgorithm): The function to calculate the distances of the shorter strings with
+//
+// # Returns
+//  int: The Levenshtein distance (add, edit, delete distance)
+func RecursiveLevenshteinStep(inputString, targetString string, recurse DistanceAlgorithm) int {
 	if len(inputString) == 0 {
-		return len(targetString)
+		return utf8.RuneCountInString(targetString)
 	}
 	if len(targetString) == 0 {
-		return len(inputString)
+		return utf8.RuneCountInString(inputString)
 	}
 
-	firstInputChar := inputString[0]
-	firstTargetChar := targetString[0]
-	restInputString := inputString[1:]
-	restTargetString := targetString[1:]
+	firstInputChar, inputSize := utf8.DecodeRuneInString(inputString)
+	firstTargetChar, targetSize := utf8.DecodeRuneInString(targetString)
+	restInputString := inputString[inputSize:]
+	restTargetString := targetString[targetSize:]
 
 	if firstInputChar == firstTargetChar {
-		return RecursiveLevenshtein(restInputString, restTargetString)
+		return recurse(restInputString, restTargetString)
 	}
 
 	return 1 + min(
-		RecursiveLevenshtein(inputString, restTargetString),     // Add
-		RecursiveLevenshtein(restInputString, targetString),     // Delete
-		RecursiveLevenshtein(restInputString, restTargetString), // Edit/replace
+		recurse(inputString, restTargetString),     // Add
+		recurse(restInputString, targetString),     // Delete
+		recurse(restInputString, restTargetString), // Edit/replace
 	)
 
 }
@@ -77,6 +136,7 @@ func RecursiveLevenshteinSimilarity(inputString, targetString string) float32 {
 //  - Relies on Wagner–Fischer algorithm https://en.wikipedia.org/wiki/Wagner%E2%80%93Fischer_algorithm#Calculating_distance
 //  - More details: https://gist.github.com/Descent098/401c2ca6bdf3fa655738e7a1ddf1aeee
 //  - Faster than the recursive solution, runs in roughly O(m*n) where m and n are the size of strings
+//  - The matrix is borrowed from an internal pool instead of allocated for every call, see Comparer to manage the buffers yourself
 //
 // # Parameters
 //  inputString (string): The first string to use for the comparison
@@ -85,52 +145,205 @@ func RecursiveLevenshteinSimilarity(inputString, targetString string) float32 {
 // # Returns
 //  int: The Levenshtein distance (add, edit, delete distance)
 func DynamicLevenshtein(inputString, targetString string) int {
-	// Convert to runes to avoid weird encoding issues
-	inputStringRunes := []rune(inputString)
-	targetStringRunes := []rune(targetString)
+	comparer := getComparer()
+	defer putComparer(comparer)
+	return comparer.DynamicLevenshtein(inputString, targetString)
+}
 
-	inputStringLength := len(inputStringRunes)
-	targetStringLength := len(targetStringRunes)
+// A dynamic-programming based implementation of Levenshtein distance that only keeps 2 rows of the matrix
+//
+// # Notes
+//  - Each row of the Wagner–Fischer matrix only depends on the one before it, so the rest of the matrix doesn't need to be kept
+//  - The rows are as long as the shorter string, so it uses O(min(m,n)) memory instead of the O(m*n) of DynamicLevenshtein
+//  - Returns the same distance as DynamicLevenshtein, and still runs in roughly O(m*n)
+//  - The rows are borrowed from an internal pool, so steady-state calls don't allocate them
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  int: The Levenshtein distance (add, edit, delete distance)
+func SpaceEfficientLevenshteinDistance(inputString, targetString string) int {
+	comparer := getComparer()
+	defer putComparer(comparer)
+	return comparer.SpaceEfficientLevenshteinDistance(inputString, targetString)
+}
 
-	// Create a 2D matrix
-	matrix := make([][]int, inputStringLength+1)
-	for i := range matrix {
-		matrix[i] = make([]int, targetStringLength+1)
+// A bit-parallel implementation of Levenshtein distance using Myers' algorithm
+//
+// # Notes
+//  - Relies on Myers' bit-vector algorithm https://doi.org/10.1145/316542.316550, in the formulation by Hyyrö https://doi.org/10.1007/978-3-540-24580-3_2
+//  - Stores each column of the Wagner–Fischer matrix as bit vectors of the +1/-1 differences between neighbouring cells,
+//     so 64 cells are updated at once with a handful of bitwise operations
+//  - The shorter string is used as the pattern, which fits in a single uint64 for strings up to 64 runes, longer
+//     strings are split into blocks of 64 runes that pass the difference at their last row on to the next block
+//  - Returns the same distance as DynamicLevenshtein, and runs in roughly O(ceil(m/64)*n) without allocating a matrix
+//  - The bit vectors are borrowed from an internal pool, so it only allocates when it needs larger buffers than before
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  int: The Levenshtein distance (add, edit, delete distance)
+func MyersLevenshtein(inputString, targetString string) int {
+	comparer := getComparer()
+	defer putComparer(comparer)
+	return comparer.MyersLevenshtein(inputString, targetString)
+}
+
+// Moves one block of Myers' algorithm to the next column
+//
+// # Parameters
+//  positive (uint64): The rows of the block where the vertical difference is +1
+//  negative (uint64): The rows of the block where the vertical difference is -1
+//  equal (uint64): The rows of the block where the pattern matches the current rune of the text
+//  carry (int): The horizontal difference (-1, 0 or +1) coming in from the row above the block
+//  outputRow (uint64): The bit of the row whose horizontal difference is returned
+//
+// # Returns
+//  uint64: The new rows where the vertical difference is +1
+//  uint64: The new rows where the vertical difference is -1
+//  int: The horizontal difference at outputRow
+func myersAdvanceBlock(positive, negative, equal uint64, carry int, outputRow uint64) (uint64, uint64, int) {
+	vertical := equal | negative
+	if carry < 0 {
+		equal |= 1
 	}
+	horizontal := (((equal & positive) + positive) ^ positive) | equal
+	positiveHorizontal := negative | ^(horizontal | positive)
+	negativeHorizontal := positive & horizontal
 
-	// Initialize base cases
-	for i := 0; i <= inputStringLength; i++ {
-		matrix[i][0] = i
+	output := 0
+	if positiveHorizontal&outputRow != 0 {
+		output = 1
+	} else if negativeHorizontal&outputRow != 0 {
+		output = -1
 	}
-	for j := 0; j <= targetStringLength; j++ {
-		matrix[0][j] = j
+
+	positiveHorizontal <<= 1
+	negativeHorizontal <<= 1
+	if carry < 0 {
+		negativeHorizontal |= 1
+	} else if carry > 0 {
+		positiveHorizontal |= 1
 	}
+	return negativeHorizontal | ^(vertical | positiveHorizontal), positiveHorizontal & vertical, output
+}
 
-	// Fill the matrix
-	for i := 1; i <= inputStringLength; i++ {
-		for j := 1; j <= targetStringLength; j++ {
-			if inputStringRunes[i-1] == targetStringRunes[j-1] {
+// Calculates the Levenshtein distance of two strings, with a custom function to decide which runes are equal
+//
+// # Notes
+//  - Useful for treating classes of runes as the same without changing the strings first (i.e. FoldCase, DigitsEqual or WhitespaceEqual)
+//  - A nil equal is exact equality, the same as LevenshteinDistance
+//  - equal is always called with a rune from inputString first, and a rune from targetString second
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  equal (RuneEqualFunc): Reports whether two runes are equal, use AnyRuneEqual to combine several
+//
+// # Returns
+//  int: The Levenshtein distance (add, edit, delete distance)
+func LevenshteinDistanceFunc(inputString, targetString string, equal RuneEqualFunc) int {
+	if equal == nil {
+		return LevenshteinDistance(inputString, targetString)
+	}
+
+	// Convert to runes to avoid weird encoding issues
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+
+	// Only the previous row of the matrix is needed to calculate the current one
+	previousRow := make([]int, len(targetStringRunes)+1)
+	currentRow := make([]int, len(targetStringRunes)+1)
+	for j := range previousRow {
+		previousRow[j] = j
+	}
+
+	for i := 1; i <= len(inputStringRunes); i++ {
+		currentRow[0] = i
+		for j := 1; j <= len(targetStringRunes); j++ {
+			if equal(inputStringRunes[i-1], targetStringRunes[j-1]) {
 				// Characters match, no cost added
-				matrix[i][j] = matrix[i-1][j-1]
+				currentRow[j] = previousRow[j-1]
 			} else {
-				matrix[i][j] = 1 + min(
-					matrix[i][j-1],   // Add
-					matrix[i-1][j],   // Delete
-					matrix[i-1][j-1], // Edit/replace
+				currentRow[j] = 1 + min(
+					currentRow[j-1],  // Add
+					previousRow[j],   // Delete
+					previousRow[j-1], // Edit/replace
 				)
 			}
 		}
+		previousRow, currentRow = currentRow, previousRow
 	}
 
-	return matrix[inputStringLength][targetStringLength]
+	return previousRow[len(targetStringRunes)]
 }
 
-// A recursive Levenshtein distance using the Damerau–Levenshtein distance
+// Calculates the Levenshtein distance of two strings, stopping early once it's over a maximum
+//
+// # Notes
+//  - Returns maxDistance+1 for any strings further apart than maxDistance, which is enough to filter out candidates
+//  - Only fills in the band of the matrix within maxDistance of the diagonal (Ukkonen's cutoff), so it runs in O(k*n) instead of O(m*n)
+//  - Stops as soon as every cell in a row is over maxDistance, and skips the matrix entirely if the lengths differ by more than maxDistance
+//  - A negative maxDistance is treated as 0
+//  - The rows are borrowed from an internal pool, the same as SpaceEfficientLevenshteinDistance
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  maxDistance (int): The largest distance to calculate exactly
+//
+// # Returns
+//  int: The Levenshtein distance (add, edit, delete distance), or maxDistance+1 if it's larger than maxDistance
+func BoundedLevenshteinDistance(inputString, targetString string, maxDistance int) int {
+	comparer := getComparer()
+	defer putComparer(comparer)
+	return comparer.BoundedLevenshteinDistance(inputString, targetString, maxDistance)
+}
+
+// Calculates the Levenshtein distance of two strings, stopping early once it's over maxDistance
+//
+// # Notes
+//  - An alias of BoundedLevenshteinDistance, which is the canonical name, see it for the details
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  maxDistance (int): The largest distance to calculate exactly
+//
+// # Returns
+//  int: The Levenshtein distance (add, edit, delete distance), or maxDistance+1 if it's larger than maxDistance
+func LevenshteinDistanceMax(inputString, targetString string, maxDistance int) int {
+	return BoundedLevenshteinDistance(inputString, targetString, maxDistance)
+}
+
+// Checks if the Levenshtein distance of two strings is at most maxDistance
+//
+// # Notes
+//  - Uses BoundedLevenshteinDistance, so it stops as soon as the distance is known to be over maxDistance
+//  - Nothing is within a negative maxDistance
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  maxDistance (int): The largest distance the strings can be apart
+//
+// # Returns
+//  bool: True if the Levenshtein distance between the strings is at most maxDistance
+func LevenshteinWithin(inputString, targetString string, maxDistance int) bool {
+	return maxDistance >= 0 && BoundedLevenshteinDistance(inputString, targetString, maxDistance) <= maxDistance
+}
+
+// Calculates the Damerau–Levenshtein distance of two strings
 //
 // # Notes
 //  - Relies on Damerau–Levenshtein distance, which is the Levenshtein distance + transpositions
+//  - Transposed characters can't be edited again (i.e. "ca" to "abc" is 3), so it's the same as OptimalStringAlignmentDistance, use DamerauLevenshteinDP for the unrestricted distance
+//  - Uses dynamic programming on runes, so it returns the same results as RecursiveDamerauLevenshtein for ASCII strings, without allocating a cache key for every pair of suffixes or overflowing the stack on long strings
 //  - More details: https://en.wikipedia.org/wiki/Damerau%E2%80%93Levenshtein_distance
-//  - Relies on memoization for performance and accuracy: https://en.wikipedia.org/wiki/Memoization
 //
 // # Parameters
 //  inputString (string): The first string to use for the comparison
@@ -139,6 +352,27 @@ func DynamicLevenshtein(inputString, targetString string) int {
 // # Returns
 //  int: The Damerau–Levenshtein distance (add, edit, delete, transpose distance)
 func DamerauLevenshtein(input, target string) int {
+	return OptimalStringAlignmentDistance(input, target)
+}
+
+// A recursive Levenshtein distance using the Damerau–Levenshtein distance
+//
+// Deprecated: Use DamerauLevenshtein, which gives the same results without recursing.
+//
+// # Notes
+//  - This is the original implementation of DamerauLevenshtein, kept so existing callers don't break
+//  - Transposed characters can't be edited again, so it's the optimal string alignment distance (i.e. "ca" to "abc" is 3)
+//  - Compares bytes rather than runes, so a multi-byte character counts as several edits
+//  - Relies on memoization for performance and accuracy: https://en.wikipedia.org/wiki/Memoization
+//  - The cache is keyed by the remaining suffixes, so it allocates O(m*n*(m+n)) bytes and recurses once per byte of both strings
+//
+// # Parameters
+//  input (string): The first string to use for the comparison
+//  target (string): The second string to use for the comparison
+//
+// # Returns
+//  int: The optimal string alignment distance (add, edit, delete, transpose distance)
+func RecursiveDamerauLevenshtein(input, target string) int {
 	// Create a memoization cache
 	cache := make(map[string]int)
 
@@ -191,7 +425,331 @@ func DamerauLevenshtein(input, target string) int {
 	return calculateDamerauLevenshteinDistance(input, target)
 }
 
+// Calculates the (unrestricted) Damerau–Levenshtein distance of two strings
+//
+// # Notes
+//  - The Damerau–Levenshtein distance is the Levenshtein distance + transpositions of adjacent characters
+//  - Unlike DamerauLevenshtein, characters can still be edited after they've been transposed (i.e. "ca" to "abc" is 2, "ca" -> "ac" -> "abc")
+//  - Uses the Lowrance–Wagner algorithm, a dynamic programming solution which remembers the last row each character was seen in to find transpositions
+//  - More details: https://en.wikipedia.org/wiki/Damerau%E2%80%93Levenshtein_distance
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  int: The Damerau–Levenshtein distance (add, edit, delete, transpose distance)
+func DamerauLevenshteinDP(input, target string) int {
+	// Convert to runes to avoid weird encoding issues
+	inputRunes := []rune(input)
+	targetRunes := []rune(target)
+	inputLength := len(inputRunes)
+	targetLength := len(targetRunes)
+
+	// The matrix has an extra row and column of maxDistance, so transpositions can't reach past the start of the strings
+	maxDistance := inputLength + targetLength
+	matrix := make([][]int, inputLength+2)
+	for i := range matrix {
+		matrix[i] = make([]int, targetLength+2)
+		matrix[i][0] = maxDistance
+		if i > 0 {
+			matrix[i][1] = i - 1
+		}
+	}
+	for j := 1; j <= targetLength+1; j++ {
+		matrix[0][j] = maxDistance
+		matrix[1][j] = j - 1
+	}
+
+	// The last row each rune of input was seen in
+	lastRow := make(map[rune]int)
+
+	for i := 1; i <= inputLength; i++ {
+		// The last column in this row where the runes matched
+		lastMatchingColumn := 0
+		for j := 1; j <= targetLength; j++ {
+			k := lastRow[targetRunes[j-1]]
+			l := lastMatchingColumn
+			cost := 1
+			if inputRunes[i-1] == targetRunes[j-1] {
+				cost = 0
+				lastMatchingColumn = j
+			}
+
+			matrix[i+1][j+1] = min(
+				matrix[i][j]+cost,              // Edit/replace
+				matrix[i+1][j]+1,               // Add
+				matrix[i][j+1]+1,               // Delete
+				matrix[k][l]+(i-k-1)+1+(j-l-1), // Transpose, deleting and adding the runes in between
+			)
+		}
+		lastRow[inputRunes[i-1]] = i
+	}
+
+	return matrix[inputLength+1][targetLength+1]
+}
+
 func DamerauLevenshteinSimilarity(inputString, targetString string) float32 {
 	similarity := CalculateSimilarity(inputString, targetString, DamerauLevenshtein)
 	return similarity
 }
+
+// Calculates the (unrestricted) Damerau–Levenshtein distance of two strings, with a different cost for transpositions
+//
+// # Notes
+//  - Useful for typos, where swapping two adjacent characters is more common than other mistakes (i.e. a transposeCost of 0.6)
+//  - editCost is the cost of adding, deleting or replacing a rune, and transposeCost is the cost of swapping two adjacent runes
+//  - With both costs set to 1 it's the same as DamerauLevenshteinDP (i.e. "ca" to "abc" is 2)
+//  - Negative costs are treated as 0
+//  - The Lowrance–Wagner algorithm is only guaranteed to find the lowest cost when transposeCost >= editCost, for cheaper transpositions the result is the cost of a valid sequence of edits, but may not be the lowest one when runes are added between transposed runes
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  transposeCost (float32): The cost of swapping two adjacent runes
+//  editCost (float32): The cost of adding, deleting or replacing a rune
+//
+// # Returns
+//  float32: The Damerau–Levenshtein distance with the given costs
+func DamerauLevenshteinWeighted(inputString, targetString string, transposeCost, editCost float32) float32 {
+	transposeCost = max(0, transposeCost)
+	editCost = max(0, editCost)
+
+	// Convert to runes to avoid weird encoding issues
+	inputRunes := []rune(inputString)
+	targetRunes := []rune(targetString)
+	inputLength := len(inputRunes)
+	targetLength := len(targetRunes)
+
+	// The matrix has an extra row and column of maxDistance, so transpositions can't reach past the start of the strings
+	maxDistance := float32(inputLength+targetLength)*editCost + transposeCost + 1
+	matrix := make([][]float32, inputLength+2)
+	for i := range matrix {
+		matrix[i] = make([]float32, targetLength+2)
+		matrix[i][0] = maxDistance
+		if i > 0 {
+			matrix[i][1] = float32(i-1) * editCost
+		}
+	}
+	for j := 1; j <= targetLength+1; j++ {
+		matrix[0][j] = maxDistance
+		matrix[1][j] = float32(j-1) * editCost
+	}
+
+	// The last row each rune of input was seen in
+	lastRow := make(map[rune]int)
+
+	for i := 1; i <= inputLength; i++ {
+		// The last column in this row where the runes matched
+		lastMatchingColumn := 0
+		for j := 1; j <= targetLength; j++ {
+			k := lastRow[targetRunes[j-1]]
+			l := lastMatchingColumn
+			cost := editCost
+			if inputRunes[i-1] == targetRunes[j-1] {
+				cost = 0
+				lastMatchingColumn = j
+			}
+
+			matrix[i+1][j+1] = min(
+				matrix[i][j]+cost,       // Edit/replace
+				matrix[i+1][j]+editCost, // Add
+				matrix[i][j+1]+editCost, // Delete
+				matrix[k][l]+float32(i-k-1)*editCost+transposeCost+float32(j-l-1)*editCost, // Transpose, deleting and adding the runes in between
+			)
+		}
+		lastRow[inputRunes[i-1]] = i
+	}
+
+	return matrix[inputLength+1][targetLength+1]
+}
+
+// Creates a similarity algorithm from DamerauLevenshteinWeighted() with fixed costs
+//
+// # Notes
+//  - The distance is never more than editCost times the length of the longer string (replacing every rune, then adding the rest), so the similarity is normalized by that to stay between 0-1
+//  - If editCost is 0 every string is free to reach, so everything has a similarity of 1
+//
+// # Parameters
+//  transposeCost (float32): The cost of swapping two adjacent runes
+//  editCost (float32): The cost of adding, deleting or replacing a rune
+//
+// # Returns
+//  SimilarityAlgorithm: The similarity algorithm
+func NewDamerauLevenshteinWeightedSimilarity(transposeCost, editCost float32) SimilarityAlgorithm {
+	return func(inputString, targetString string) float32 {
+		if inputString == targetString {
+			return 1
+		}
+		longest := float32(max(utf8.RuneCountInString(inputString), utf8.RuneCountInString(targetString))) * max(0, editCost)
+		if longest == 0 {
+			return 1
+		}
+		distance := DamerauLevenshteinWeighted(inputString, targetString, transposeCost, editCost)
+		return max(0, min(1-distance/longest, 1))
+	}
+}
+
+// Calculates the Optimal String Alignment distance (restricted Damerau–Levenshtein distance) of two strings
+//
+// # Notes
+//  - Like DamerauLevenshteinDP, it counts adds, edits, deletes and transpositions of adjacent characters
+//  - Unlike DamerauLevenshteinDP, no substring can be edited more than once, so transposed characters can't have anything added between them (i.e. "ca" to "abc" is 3 instead of 2)
+//  - DamerauLevenshtein uses this, RecursiveDamerauLevenshtein gives the same results but compares bytes instead of runes
+//  - Doesn't satisfy the triangle inequality, but the recurrence is simpler, and it only needs 3 rows of the matrix
+//  - Always greater than or equal to DamerauLevenshteinDP, and less than or equal to LevenshteinDistance
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  int: The optimal string alignment distance (add, edit, delete, transpose distance)
+func OptimalStringAlignmentDistance(inputString, targetString string) int {
+	// Convert to runes to avoid weird encoding issues
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+
+	// Transpositions look back 2 rows, so keep one more row than SpaceEfficientLevenshteinDistance
+	twoRowsBack := make([]int, len(targetStringRunes)+1)
+	previousRow := make([]int, len(targetStringRunes)+1)
+	currentRow := make([]int, len(targetStringRunes)+1)
+	for j := range previousRow {
+		previousRow[j] = j
+	}
+
+	for i := 1; i <= len(inputStringRunes); i++ {
+		currentRow[0] = i
+		for j := 1; j <= len(targetStringRunes); j++ {
+			cost := 1
+			if inputStringRunes[i-1] == targetStringRunes[j-1] {
+				// Characters match, no cost added
+				cost = 0
+			}
+			currentRow[j] = min(
+				currentRow[j-1]+1,     // Add
+				previousRow[j]+1,      // Delete
+				previousRow[j-1]+cost, // Edit/replace
+			)
+
+			if i > 1 && j > 1 && inputStringRunes[i-1] == targetStringRunes[j-2] && inputStringRunes[i-2] == targetStringRunes[j-1] {
+				currentRow[j] = min(currentRow[j], twoRowsBack[j-2]+1) // Transpose
+			}
+		}
+		twoRowsBack, previousRow, currentRow = previousRow, currentRow, twoRowsBack
+	}
+
+	return previousRow[len(targetStringRunes)]
+}
+
+// Calculates the Optimal String Alignment similarity (restricted Damerau–Levenshtein similarity) of two strings
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func OptimalStringAlignmentSimilarity(inputString, targetString string) float32 {
+	return CalculateSimilarity(inputString, targetString, OptimalStringAlignmentDistance)
+}
+
+// Calculates the Damerau–Levenshtein distance of two strings the same way as DamerauLevenshtein, stopping early once it's over a maximum
+//
+// # Notes
+//  - Returns maxDistance+1 for any strings further apart than maxDistance, the same as BoundedLevenshteinDistance
+//  - Transposed characters can't be edited again (i.e. "ca" to "abc" is 3), use DamerauLevenshteinDP for the unrestricted distance
+//  - Only fills in the band of the matrix within maxDistance of the diagonal, and stops as soon as every cell in a row is over maxDistance
+//  - Transpositions skip a row, but the cell they skip over is one edit away, so every row still has a cell no larger than the final distance
+//  - A negative maxDistance is treated as 0
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  maxDistance (int): The largest distance to calculate exactly
+//
+// # Returns
+//  int: The optimal string alignment distance (add, edit, delete, transpose distance), or maxDistance+1 if it's larger than maxDistance
+func BoundedDamerauLevenshteinDistance(inputString, targetString string, maxDistance int) int {
+	maxDistance = max(maxDistance, 0)
+	limit := maxDistance + 1
+
+	// Convert to runes to avoid weird encoding issues
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+	inputStringLength := len(inputStringRunes)
+	targetStringLength := len(targetStringRunes)
+
+	// Each rune of difference in length needs at least one insertion or deletion
+	if inputStringLength-targetStringLength > maxDistance || targetStringLength-inputStringLength > maxDistance {
+		return limit
+	}
+
+	// Laid out the same as BoundedLevenshteinDistance, with the extra row OptimalStringAlignmentDistance needs for transpositions
+	twoRowsBack := make([]int, targetStringLength+1)
+	previousRow := make([]int, targetStringLength+1)
+	currentRow := make([]int, targetStringLength+1)
+	for j := range previousRow {
+		previousRow[j] = min(j, limit)
+	}
+
+	for i := 1; i <= inputStringLength; i++ {
+		low := max(1, i-maxDistance)
+		high := min(targetStringLength, i+maxDistance)
+
+		currentRow[0] = min(i, limit)
+		rowMinimum := limit
+		if low == 1 {
+			rowMinimum = currentRow[0]
+		} else {
+			currentRow[low-1] = limit
+		}
+
+		for j := low; j <= high; j++ {
+			cost := 1
+			if inputStringRunes[i-1] == targetStringRunes[j-1] {
+				// Characters match, no cost added
+				cost = 0
+			}
+			currentRow[j] = min(
+				limit,
+				currentRow[j-1]+1,     // Add
+				previousRow[j]+1,      // Delete
+				previousRow[j-1]+cost, // Edit/replace
+			)
+
+			if i > 1 && j > 1 && inputStringRunes[i-1] == targetStringRunes[j-2] && inputStringRunes[i-2] == targetStringRunes[j-1] {
+				currentRow[j] = min(currentRow[j], twoRowsBack[j-2]+1) // Transpose
+			}
+			rowMinimum = min(rowMinimum, currentRow[j])
+		}
+		if high < targetStringLength {
+			currentRow[high+1] = limit
+		}
+
+		// Every path goes through this row, or transposes over it from a cell one edit away, so the distance can't get any smaller
+		if rowMinimum > maxDistance {
+			return limit
+		}
+		twoRowsBack, previousRow, currentRow = previousRow, currentRow, twoRowsBack
+	}
+
+	return min(previousRow[targetStringLength], limit)
+}
+
+// Checks if the Damerau–Levenshtein distance of two strings (the same as DamerauLevenshtein) is at most maxDistance
+//
+// # Notes
+//  - Uses BoundedDamerauLevenshteinDistance, so it stops as soon as the distance is known to be over maxDistance
+//  - Nothing is within a negative maxDistance
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  maxDistance (int): The largest distance the strings can be apart
+//
+// # Returns
+//  bool: True if the Damerau–Levenshtein distance between the strings is at most maxDistance
+func DamerauLevenshteinWithin(inputString, targetString string, maxDistance int) bool {
+	return maxDistance >= 0 && BoundedDamerauLevenshteinDistance(inputString, targetString, maxDistance) <= maxDistance
+}
@@ -0,0 +1,692 @@
+package algorithms
+
+// This file implements normalizers that strip HTML and Markdown markup from strings, so rich text can be compared to plain text
+//
+// # References
+//  - https://html.spec.whatwg.org/multipage/parsing.html#tokenization
+//  - https://spec.commonmark.org/
+//  - https://spec.commonmark.org/0.31.2/#left-flanking-delimiter-run
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Builds up the visible text of a marked up string, while tracking where each byte came from
+//
+// # Notes
+//   - Runs of whitespace are collapsed to a single space, and leading/trailing whitespace is dropped
+//   - Verbatim text (i.e. code spans) is written as-is, without collapsing it's whitespace
+type markupBuilder struct {
+	text         []byte
+	offsets      []int
+	pendingSpace int // Offset of the whitespace waiting to be written, or -1 if there is none
+}
+
+func newMarkupBuilder(capacity int) *markupBuilder {
+	return &markupBuilder{
+		text:         make([]byte, 0, capacity),
+		offsets:      make([]int, 0, capacity),
+		pendingSpace: -1,
+	}
+}
+
+// Writes a rune that came from offset in the original string
+func (builder *markupBuilder) writeRune(currentRune rune, offset int) {
+	if unicode.IsSpace(currentRune) {
+		// Only keep whitespace that separates visible text
+		if len(builder.text) > 0 && builder.pendingSpace < 0 {
+			builder.pendingSpace = offset
+		}
+		return
+	}
+	builder.writeVerbatimRune(currentRune, offset)
+}
+
+// Writes a rune that came from offset in the original string without collapsing whitespace
+func (builder *markupBuilder) writeVerbatimRune(currentRune rune, offset int) {
+	if builder.pendingSpace >= 0 {
+		builder.text = append(builder.text, ' ')
+		builder.offsets = append(builder.offsets, builder.pendingSpace)
+		builder.pendingSpace = -1
+	}
+	previousLength := len(builder.text)
+	builder.text = utf8.AppendRune(builder.text, currentRune)
+	for range len(builder.text) - previousLength {
+		builder.offsets = append(builder.offsets, offset)
+	}
+}
+
+// Writes a string that started at offset in the original string
+func (builder *markupBuilder) writeString(text string, offset int, verbatim bool) {
+	for i, currentRune := range text {
+		if verbatim {
+			builder.writeVerbatimRune(currentRune, offset+i)
+		} else {
+			builder.writeRune(currentRune, offset+i)
+		}
+	}
+}
+
+// Marks a boundary between blocks of text (i.e. paragraphs), so their words don't run together
+func (builder *markupBuilder) writeBreak(offset int) {
+	builder.writeRune(' ', offset)
+}
+
+func (builder *markupBuilder) result() (string, []int) {
+	return string(builder.text), builder.offsets
+}
+
+// Tags that separate blocks of text, and should be replaced with whitespace
+var htmlBlockTags = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "br": true, "dd": true, "div": true,
+	"dl": true, "dt": true, "figcaption": true, "figure": true, "footer": true, "form": true, "h1": true,
+	"h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "header": true, "hr": true, "li": true,
+	"main": true, "nav": true, "ol": true, "p": true, "pre": true, "section": true, "table": true,
+	"tbody": true, "td": true, "tfoot": true, "th": true, "thead": true, "title": true, "tr": true, "ul": true,
+}
+
+// Tags whose contents are never visible, and should be removed along with the tag
+var htmlRawTextTags = map[string]bool{
+	"script": true,
+	"style":  true,
+}
+
+// Removes HTML tags, comments and entities from a string, leaving only the visible text
+//
+// # Notes
+//   - Entities (i.e. &amp; or &#39;) are decoded, and the contents of <script> and <style> are removed
+//   - Block level tags (i.e. <p>, <br/>, <li>) are replaced with a space, inline tags (i.e. <b>) are removed
+//   - Whitespace is collapsed to single spaces, and trimmed from the start and end
+//   - A '<' that doesn't start a valid tag (i.e. "a < b", or a tag that is never closed) is kept as text
+//
+// # Parameters
+//  s (string): The string to remove the HTML from
+//
+// # Returns
+//  string: The visible text of s
+func StripHTML(s string) string {
+	result, _ := StripHTMLWithOffsets(s)
+	return result
+}
+
+// Removes HTML tags, comments and entities from a string, and maps the result back to the original string
+//
+// # Notes
+//   - Works the same as StripHTML()
+//   - Every byte of a decoded entity maps to the '&' that started it, and every collapsed space maps to the first whitespace character
+//
+// # Parameters
+//  s (string): The string to remove the HTML from
+//
+// # Returns
+//  string: The visible text of s
+//  []int: The byte offset in s that each byte of the visible text came from
+func StripHTMLWithOffsets(s string) (string, []int) {
+	builder := newMarkupBuilder(len(s))
+
+	i := 0
+	for i < len(s) {
+		switch s[i] {
+		case '<':
+			if end, ok := skipHTMLComment(s, i); ok {
+				i = end
+				continue
+			}
+			name, closing, selfClosing, end, ok := parseHTMLTag(s, i)
+			if !ok {
+				// Not a tag, so it's a literal '<'
+				builder.writeRune('<', i)
+				i += 1
+				continue
+			}
+			if htmlBlockTags[name] {
+				builder.writeBreak(i)
+			}
+			i = end
+			if htmlRawTextTags[name] && !closing && !selfClosing {
+				i = skipHTMLRawText(s, i, name)
+			}
+		case '&':
+			decoded, end, ok := decodeHTMLEntity(s, i)
+			if !ok {
+				builder.writeRune('&', i)
+				i += 1
+				continue
+			}
+			for _, currentRune := range decoded {
+				builder.writeRune(currentRune, i)
+			}
+			i = end
+		default:
+			currentRune, size := utf8.DecodeRuneInString(s[i:])
+			builder.writeRune(currentRune, i)
+			i += size
+		}
+	}
+
+	return builder.result()
+}
+
+// Skips an HTML comment, doctype or processing instruction starting at start
+//
+// # Returns
+//  int: The index just after the end of the comment
+//  bool: If there was a comment at start
+func skipHTMLComment(s string, start int) (int, bool) {
+	rest := s[start:]
+	switch {
+	case strings.HasPrefix(rest, "<!--"):
+		end := strings.Index(rest[4:], "-->")
+		if end < 0 {
+			// Unterminated comments run to the end of the string
+			return len(s), true
+		}
+		return start + 4 + end + 3, true
+	case strings.HasPrefix(rest, "<!"), strings.HasPrefix(rest, "<?"):
+		end := strings.IndexByte(rest, '>')
+		if end < 0 {
+			return 0, false
+		}
+		return start + end + 1, true
+	}
+	return 0, false
+}
+
+// Parses an opening or closing HTML tag starting at start, skipping over it's attributes
+//
+// # Returns
+//  string: The lowercased name of the tag
+//  bool: If the tag is a closing tag (i.e. </p>)
+//  bool: If the tag is self closing (i.e. <br/>)
+//  int: The index just after the '>' that ends the tag
+//  bool: If there was a valid tag at start
+func parseHTMLTag(s string, start int) (name string, closing, selfClosing bool, end int, ok bool) {
+	i := start + 1
+	if i < len(s) && s[i] == '/' {
+		closing = true
+		i += 1
+	}
+
+	// Tag names must start with a letter
+	nameStart := i
+	for i < len(s) && (isASCIILetter(s[i]) || (i > nameStart && (isASCIIDigit(s[i]) || s[i] == '-'))) {
+		i += 1
+	}
+	if i == nameStart {
+		return "", false, false, 0, false
+	}
+	name = strings.ToLower(s[nameStart:i])
+
+	// Skip attributes, quoted values may contain '>'
+	var quote byte
+	for ; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == '<':
+			// Another tag started before this one ended, so this one is malformed
+			return "", false, false, 0, false
+		case s[i] == '>':
+			selfClosing = s[i-1] == '/'
+			return name, closing, selfClosing, i + 1, true
+		}
+	}
+	return "", false, false, 0, false
+}
+
+// Skips the contents of a raw text tag (i.e. <script>) up to, and including, it's closing tag
+//
+// # Returns
+//  int: The index just after the closing tag, or the end of the string if it was never closed
+func skipHTMLRawText(s string, start int, name string) int {
+	closingTag := "</" + name
+	for i := start; i < len(s); i++ {
+		if s[i] != '<' || !strings.EqualFold(s[i:min(len(s), i+len(closingTag))], closingTag) {
+			continue
+		}
+		if _, closing, _, end, ok := parseHTMLTag(s, i); ok && closing {
+			return end
+		}
+	}
+	return len(s)
+}
+
+// Decodes a named or numeric HTML entity (i.e. &amp; or &#x27;) starting at start
+//
+// # Returns
+//  string: The decoded text
+//  int: The index just after the ';' that ends the entity
+//  bool: If there was a valid entity at start
+func decodeHTMLEntity(s string, start int) (string, int, bool) {
+	// The longest entity names are ~30 characters
+	const maxEntityLength = 32
+
+	for i := start + 1; i < len(s) && i-start <= maxEntityLength; i++ {
+		if s[i] == ';' {
+			entity := s[start : i+1]
+			decoded := html.UnescapeString(entity)
+			// Legacy entities without a ';' (i.e. "&not") only decode part of the string, which isn't this entity
+			if decoded == entity || utf8.RuneCountInString(decoded) > 2 {
+				return "", 0, false
+			}
+			return decoded, i + 1, true
+		}
+		if !isASCIILetter(s[i]) && !isASCIIDigit(s[i]) && s[i] != '#' {
+			break
+		}
+	}
+	return "", 0, false
+}
+
+// Removes Markdown formatting from a string, leaving only the visible text
+//
+// # Notes
+//   - Removes emphasis (*, _, ~~), headings, block quotes, list markers, thematic breaks and code fences
+//   - Links and images are replaced with their text (i.e. "[speyl](https://...)" becomes "speyl")
+//   - The contents of code spans and code blocks are kept exactly as written, including their whitespace
+//   - Emphasis markers are only removed in matching pairs, the same as CommonMark (i.e. "2*3", "2 * 3" and "**bold" are kept), and underscores inside words (i.e. snake_case) are kept
+//   - Backslash escapes (i.e. \*) are replaced with the escaped character
+//   - Whitespace outside of code is collapsed to single spaces, and trimmed from the start and end
+//
+// # Parameters
+//  s (string): The string to remove the Markdown from
+//
+// # Returns
+//  string: The visible text of s
+func StripMarkdown(s string) string {
+	result, _ := StripMarkdownWithOffsets(s)
+	return result
+}
+
+// Removes Markdown formatting from a string, and maps the result back to the original string
+//
+// # Notes
+//   - Works the same as StripMarkdown()
+//
+// # Parameters
+//  s (string): The string to remove the Markdown from
+//
+// # Returns
+//  string: The visible text of s
+//  []int: The byte offset in s that each byte of the visible text came from
+func StripMarkdownWithOffsets(s string) (string, []int) {
+	builder := newMarkupBuilder(len(s))
+
+	var fence string // The fence of the code block we're in, if any
+	lineStart := 0
+	for lineStart < len(s) {
+		lineEnd := strings.IndexByte(s[lineStart:], '\n')
+		if lineEnd < 0 {
+			lineEnd = len(s)
+		} else {
+			lineEnd += lineStart
+		}
+		line := s[lineStart:lineEnd]
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		switch {
+		case fence != "":
+			if indent < 4 && strings.HasPrefix(strings.TrimSpace(line), fence) {
+				fence = ""
+				builder.writeBreak(lineStart)
+			} else {
+				builder.writeString(line, lineStart, true)
+				if lineEnd < len(s) {
+					builder.writeVerbatimRune('\n', lineEnd)
+				}
+			}
+		case indent < 4 && (strings.HasPrefix(line[indent:], "```") || strings.HasPrefix(line[indent:], "~~~")):
+			trimmed := line[indent:]
+			fence = trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, trimmed[:1]))]
+			builder.writeBreak(lineStart)
+		case isMarkdownThematicBreak(line):
+			builder.writeBreak(lineStart)
+		default:
+			contentStart := lineStart + markdownBlockPrefixLength(line)
+			stripMarkdownInline(s, contentStart, lineEnd, builder)
+			builder.writeBreak(lineEnd)
+		}
+
+		lineStart = lineEnd + 1
+	}
+
+	return builder.result()
+}
+
+// Checks if a line is a thematic break (i.e. "---" or "* * *"), or a setext heading underline (i.e. "===")
+func isMarkdownThematicBreak(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if len(trimmed) < 3 || !strings.ContainsRune("-*_=", rune(trimmed[0])) {
+		return false
+	}
+	count := 0
+	for i := range len(trimmed) {
+		switch trimmed[i] {
+		case trimmed[0]:
+			count += 1
+		case ' ', '\t':
+		default:
+			return false
+		}
+	}
+	return count >= 3
+}
+
+// Finds the length of the block markers (headings, block quotes and list markers) at the start of a line
+func markdownBlockPrefixLength(line string) int {
+	i := 0
+	for {
+		start := i
+		for i < len(line) && i-start < 3 && line[i] == ' ' {
+			i += 1
+		}
+		switch {
+		case i < len(line) && line[i] == '>':
+			// Block quotes can be nested, and contain other blocks
+			i += 1
+			if i < len(line) && line[i] == ' ' {
+				i += 1
+			}
+			continue
+		case i < len(line) && line[i] == '#':
+			level := len(line[i:]) - len(strings.TrimLeft(line[i:], "#"))
+			if level <= 6 && (i+level == len(line) || line[i+level] == ' ') {
+				return i + level
+			}
+		case i+1 < len(line) && strings.ContainsRune("-*+", rune(line[i])) && line[i+1] == ' ':
+			return i + 2
+		case i < len(line) && isASCIIDigit(line[i]):
+			digits := len(line[i:]) - len(strings.TrimLeft(line[i:], "0123456789"))
+			marker := i + digits
+			if digits <= 9 && marker+1 < len(line) && (line[marker] == '.' || line[marker] == ')') && line[marker+1] == ' ' {
+				return marker + 2
+			}
+		}
+		return start
+	}
+}
+
+// Writes the visible text of the inline Markdown in s[start:end] to builder
+func stripMarkdownInline(s string, start, end int, builder *markupBuilder) {
+	emphasis := matchMarkdownEmphasis(s, start, end)
+	i := start
+	for i < end {
+		switch s[i] {
+		case '\\':
+			// Escaped punctuation is written literally
+			if i+1 < end && isASCIIPunctuation(s[i+1]) {
+				builder.writeRune(rune(s[i+1]), i+1)
+				i += 2
+				continue
+			}
+		case '`':
+			runLength := len(s[i:end]) - len(strings.TrimLeft(s[i:end], "`"))
+			if contentStart, contentEnd, spanEnd, ok := findMarkdownCodeSpan(s, i, end, runLength); ok {
+				builder.writeString(s[contentStart:contentEnd], contentStart, true)
+				i = spanEnd
+				continue
+			}
+			// No closing run, so the backticks are literal
+			builder.writeString(s[i:i+runLength], i, false)
+			i += runLength
+			continue
+		case '!', '[':
+			textStart := i + 1
+			if s[i] == '!' {
+				textStart += 1
+				if i+1 >= end || s[i+1] != '[' {
+					break
+				}
+			}
+			if textEnd, linkEnd, ok := findMarkdownLink(s, textStart, end); ok {
+				stripMarkdownInline(s, textStart, textEnd, builder)
+				i = linkEnd
+				continue
+			}
+		case '*', '_', '~':
+			// Delimiters without a match are kept as text
+			runLength := len(s[i:end]) - len(strings.TrimLeft(s[i:end], s[i:i+1]))
+			builder.writeString(s[i:i+runLength-emphasis[i]], i, false)
+			i += runLength
+			continue
+		}
+
+		currentRune, size := utf8.DecodeRuneInString(s[i:end])
+		builder.writeRune(currentRune, i)
+		i += size
+	}
+}
+
+// Finds the closing backtick run of a code span in s[start:end] that opens with runLength backticks
+//
+// # Returns
+//  int: The start of the code span's content
+//  int: The end of the code span's content
+//  int: The index just after the closing backticks
+//  bool: If a closing run was found
+func findMarkdownCodeSpan(s string, start, end, runLength int) (int, int, int, bool) {
+	for i := start + runLength; i < end; {
+		if s[i] != '`' {
+			i += 1
+			continue
+		}
+		closingLength := len(s[i:end]) - len(strings.TrimLeft(s[i:end], "`"))
+		if closingLength == runLength {
+			contentStart, contentEnd := start+runLength, i
+			// A single space on both sides is padding (i.e. "`` `a` ``"), not content
+			content := s[contentStart:contentEnd]
+			if len(content) > 2 && content[0] == ' ' && content[len(content)-1] == ' ' && strings.Trim(content, " ") != "" {
+				contentStart += 1
+				contentEnd -= 1
+			}
+			return contentStart, contentEnd, i + closingLength, true
+		}
+		i += closingLength
+	}
+	return 0, 0, 0, false
+}
+
+// Finds the end of a link or image (i.e. "[text](url)" or "[text][ref]") whose text starts at textStart
+//
+// # Returns
+//  int: The end of the link's text
+//  int: The index just after the end of the link
+//  bool: If there was a valid link
+func findMarkdownLink(s string, textStart, end int) (int, int, bool) {
+	// Find the matching ']', allowing for nested brackets
+	depth := 1
+	textEnd := -1
+	for i := textStart; i < end && textEnd < 0; i++ {
+		switch s[i] {
+		case '\\':
+			i += 1
+		case '[':
+			depth += 1
+		case ']':
+			depth -= 1
+			if depth == 0 {
+				textEnd = i
+			}
+		}
+	}
+	if textEnd < 0 || textEnd+1 >= end {
+		return 0, 0, false
+	}
+
+	// The destination must follow the text immediately
+	var closing byte
+	switch s[textEnd+1] {
+	case '(':
+		closing = ')'
+	case '[':
+		closing = ']'
+	default:
+		return 0, 0, false
+	}
+	depth = 1
+	for i := textEnd + 2; i < end; i++ {
+		switch s[i] {
+		case '\\':
+			i += 1
+		case s[textEnd+1]:
+			depth += 1
+		case closing:
+			depth -= 1
+			if depth == 0 {
+				return textEnd, i + 1, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// A run of emphasis delimiters (i.e. "**") in inline Markdown
+type markdownDelimiterRun struct {
+	start     int  // The index of the first delimiter
+	length    int  // The number of delimiters in the run
+	canOpen   bool // If the run can start emphasis
+	canClose  bool // If the run can end emphasis
+	remaining int  // The number of delimiters that haven't been matched yet
+}
+
+// Finds the emphasis delimiters in s[start:end] that pair up with each other
+//
+// # Notes
+//   - Follows CommonMark's rules, runs that aren't followed by whitespace can open emphasis, and runs that don't follow whitespace can close it
+//   - Delimiters are only removed when an opener has a matching closer, so "2*3" and "**bold" are kept as they are
+//   - Underscores can't open or close emphasis inside a word (i.e. snake_case)
+//   - A single tilde is not emphasis, strikethrough needs at least two (i.e. ~~text~~), and the same number to close it
+//   - Code spans, escapes and links are skipped, the text of a link is matched when it's written
+//
+// # Returns
+//  map[int]int: The number of delimiters to remove from each run, by the index the run starts at
+func matchMarkdownEmphasis(s string, start, end int) map[int]int {
+	var runs []markdownDelimiterRun
+	for i := start; i < end; {
+		switch s[i] {
+		case '\\':
+			i += 2
+			continue
+		case '`':
+			runLength := len(s[i:end]) - len(strings.TrimLeft(s[i:end], "`"))
+			if _, _, spanEnd, ok := findMarkdownCodeSpan(s, i, end, runLength); ok {
+				i = spanEnd
+			} else {
+				i += runLength
+			}
+			continue
+		case '!', '[':
+			textStart := i + 1
+			if s[i] == '!' {
+				textStart += 1
+				if i+1 >= end || s[i+1] != '[' {
+					break
+				}
+			}
+			if _, linkEnd, ok := findMarkdownLink(s, textStart, end); ok {
+				i = linkEnd
+				continue
+			}
+		case '*', '_', '~':
+			runLength := len(s[i:end]) - len(strings.TrimLeft(s[i:end], s[i:i+1]))
+			runs = append(runs, newMarkdownDelimiterRun(s, i, i+runLength, start, end))
+			i += runLength
+			continue
+		}
+		i += 1
+	}
+
+	// Each closer is matched with the closest opener of the same character before it
+	matched := make(map[int]int)
+	for closer := range runs {
+		for runs[closer].canClose && runs[closer].remaining > 0 {
+			opener := closer - 1
+			for ; opener >= 0; opener-- {
+				if runs[opener].canOpen && runs[opener].remaining > 0 && isMarkdownEmphasisPair(s, runs[opener], runs[closer]) {
+					break
+				}
+			}
+			if opener < 0 {
+				break
+			}
+
+			used := 1
+			if s[runs[closer].start] == '~' {
+				used = runs[closer].length
+			} else if runs[opener].remaining >= 2 && runs[closer].remaining >= 2 {
+				used = 2
+			}
+			runs[opener].remaining -= used
+			runs[closer].remaining -= used
+			matched[runs[opener].start] += used
+			matched[runs[closer].start] += used
+
+			// Emphasis can't overlap, so anything left between the pair is kept as text
+			for between := opener + 1; between < closer; between++ {
+				runs[between].remaining = 0
+			}
+		}
+	}
+	return matched
+}
+
+// Finds if a delimiter run in s[runStart:runEnd] can open or close emphasis, based on the text around it
+func newMarkdownDelimiterRun(s string, runStart, runEnd, start, end int) markdownDelimiterRun {
+	before, after := ' ', ' '
+	if runStart > start {
+		before, _ = utf8.DecodeLastRuneInString(s[start:runStart])
+	}
+	if runEnd < end {
+		after, _ = utf8.DecodeRuneInString(s[runEnd:end])
+	}
+
+	isPunctuation := func(r rune) bool { return unicode.IsPunct(r) || unicode.IsSymbol(r) }
+	leftFlanking := !unicode.IsSpace(after) && (!isPunctuation(after) || unicode.IsSpace(before) || isPunctuation(before))
+	rightFlanking := !unicode.IsSpace(before) && (!isPunctuation(before) || unicode.IsSpace(after) || isPunctuation(after))
+
+	run := markdownDelimiterRun{start: runStart, length: runEnd - runStart, canOpen: leftFlanking, canClose: rightFlanking, remaining: runEnd - runStart}
+	switch s[runStart] {
+	case '_':
+		run.canOpen = leftFlanking && (!rightFlanking || isPunctuation(before))
+		run.canClose = rightFlanking && (!leftFlanking || isPunctuation(after))
+	case '~':
+		if run.length < 2 {
+			run.canOpen, run.canClose = false, false
+		}
+	}
+	return run
+}
+
+// Checks if an opening and closing delimiter run can be matched with each other
+func isMarkdownEmphasisPair(s string, opener, closer markdownDelimiterRun) bool {
+	if s[opener.start] != s[closer.start] {
+		return false
+	}
+	if s[opener.start] == '~' {
+		return opener.length == closer.length
+	}
+	// Runs that can both open and close only match if their lengths don't add to a multiple of 3 (i.e. "*foo**bar*" is one emphasis)
+	if (opener.canClose || closer.canOpen) && (opener.length+closer.length)%3 == 0 {
+		return opener.length%3 == 0 && closer.length%3 == 0
+	}
+	return true
+}
+
+func isASCIILetter(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+func isASCIIDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+func isASCIIPunctuation(b byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", b) >= 0
+}
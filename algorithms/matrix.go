@@ -0,0 +1,138 @@
+package algorithms
+
+// This file implements pairwise matrices, which compare every string in a list to every other string
+//
+// # References
+//  - https://en.wikipedia.org/wiki/Distance_matrix
+//  - https://en.wikipedia.org/wiki/Triangular_array
+
+import (
+	"runtime"
+	"sync"
+)
+
+// A symmetric matrix of the distances (or similarities) between every pair of strings in a list
+//
+// # Notes
+//  - Only the upper triangle (including the diagonal) is stored, Get() mirrors it for the lower triangle
+type DistanceMatrix[T int | float32] struct {
+	size   int
+	values []T // The upper triangle, one row after another
+}
+
+// Creates an empty matrix for size strings
+func newDistanceMatrix[T int | float32](size int) *DistanceMatrix[T] {
+	return &DistanceMatrix[T]{
+		size:   size,
+		values: make([]T, size*(size+1)/2),
+	}
+}
+
+// The index of row i, column j (where i <= j) in values
+func (matrix *DistanceMatrix[T]) index(i, j int) int {
+	return i*matrix.size - i*(i-1)/2 + (j - i)
+}
+
+// Gets the value for the ith and jth strings
+//
+// # Notes
+//  - Get(i, j) and Get(j, i) are the same, since only one triangle is calculated
+//  - Panics if i or j are out of range, like indexing a slice
+//
+// # Parameters
+//  i (int): The index of the first string
+//  j (int): The index of the second string
+//
+// # Returns
+//  T: The distance or similarity of the strings
+func (matrix *DistanceMatrix[T]) Get(i, j int) T {
+	if i < 0 || j < 0 || i >= matrix.size || j >= matrix.size {
+		panic("algorithms: DistanceMatrix index out of range")
+	}
+	if i > j {
+		i, j = j, i
+	}
+	return matrix.values[matrix.index(i, j)]
+}
+
+// The number of strings in the matrix
+//
+// # Returns
+//  int: The number of rows (and columns) in the matrix
+func (matrix *DistanceMatrix[T]) Len() int {
+	return matrix.size
+}
+
+// Gets the full matrix, with the upper triangle mirrored into the lower triangle
+//
+// # Returns
+//  [][]T: A Len() x Len() matrix, where the value at [i][j] is Get(i, j)
+func (matrix *DistanceMatrix[T]) Rows() [][]T {
+	rows := make([][]T, matrix.size)
+	for i := range rows {
+		rows[i] = make([]T, matrix.size)
+		for j := range rows[i] {
+			rows[i][j] = matrix.Get(i, j)
+		}
+	}
+	return rows
+}
+
+// Fills in the upper triangle of a matrix, calculating the rows in parallel
+func fillDistanceMatrix[T int | float32](strings []string, compare func(inputString, targetString string) T) *DistanceMatrix[T] {
+	matrix := newDistanceMatrix[T](len(strings))
+
+	// Rows get shorter as they go down, so workers take the next row instead of a fixed chunk
+	rows := make(chan int)
+	wg := sync.WaitGroup{}
+	for range max(1, min(runtime.NumCPU(), len(strings))) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range rows {
+				for j := i; j < len(strings); j++ {
+					matrix.values[matrix.index(i, j)] = compare(strings[i], strings[j])
+				}
+			}
+		}()
+	}
+	for i := range strings {
+		rows <- i
+	}
+	close(rows)
+	wg.Wait()
+
+	return matrix
+}
+
+// Calculates the distance between every pair of strings in a list
+//
+// # Notes
+//  - Only calculates algorithm(strings[i], strings[j]) for i <= j, so algorithm should be symmetric
+//  - The rows are calculated in parallel, so algorithm must be safe to call from multiple goroutines
+//
+// # Parameters
+//  strings ([]string): The strings to compare
+//  algorithm (DistanceAlgorithm): The algorithm to calculate the distances with
+//
+// # Returns
+//  *DistanceMatrix[int]: The distances, where Get(i, j) is the distance between strings[i] and strings[j]
+func PairwiseDistanceMatrix(strings []string, algorithm DistanceAlgorithm) *DistanceMatrix[int] {
+	return fillDistanceMatrix(strings, algorithm)
+}
+
+// Calculates the similarity of every pair of strings in a list
+//
+// # Notes
+//  - Only calculates algorithm(strings[i], strings[j]) for i <= j, so algorithm should be symmetric
+//  - The rows are calculated in parallel, so algorithm must be safe to call from multiple goroutines
+//
+// # Parameters
+//  strings ([]string): The strings to compare
+//  algorithm (SimilarityAlgorithm): The algorithm to calculate the similarities with
+//
+// # Returns
+//  *DistanceMatrix[float32]: The similarities, where Get(i, j) is the similarity of strings[i] and strings[j]
+func PairwiseSimilarityMatrix(strings []string, algorithm SimilarityAlgorithm) *DistanceMatrix[float32] {
+	return fillDistanceMatrix(strings, algorithm)
+}
@@ -0,0 +1,93 @@
+package algorithms
+
+// This file implements the Monge–Elkan similarity, which compares multi-word strings by matching each word to its most similar word in the other string
+//
+// # References
+//  - https://www.cs.cmu.edu/~wcohen/postscript/ijcai-ws-2003.pdf
+
+import "strings"
+
+// Calculates the Monge–Elkan similarity of two strings
+//
+// # Notes
+//  - Both strings are split into tokens on whitespace, each token in inputString is matched to the token in targetString it's most similar to, and these similarities are averaged
+//  - Typos inside words and the order of the words are both handled (i.e. "univrsity of calgary" and "calgary university" are similar)
+//  - Asymmetric, extra tokens in targetString aren't penalized, use SymmetricMongeElkanSimilarity() if that matters
+//  - Two strings without tokens have a similarity of 1, and a string without tokens against one with tokens has a similarity of 0
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  inner (SimilarityAlgorithm): The algorithm to compare the tokens with, JaroSimilarity is used if it's nil
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func MongeElkanSimilarity(inputString, targetString string, inner SimilarityAlgorithm) float32 {
+	if inner == nil {
+		inner = JaroSimilarity
+	}
+	return mongeElkan(strings.Fields(inputString), strings.Fields(targetString), inner)
+}
+
+// Calculates the symmetric Monge–Elkan similarity of two strings
+//
+// # Notes
+//  - The average of MongeElkanSimilarity() in both directions, so extra tokens in either string lower the similarity
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  inner (SimilarityAlgorithm): The algorithm to compare the tokens with, JaroSimilarity is used if it's nil
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func SymmetricMongeElkanSimilarity(inputString, targetString string, inner SimilarityAlgorithm) float32 {
+	if inner == nil {
+		inner = JaroSimilarity
+	}
+	inputTokens := strings.Fields(inputString)
+	targetTokens := strings.Fields(targetString)
+	return (mongeElkan(inputTokens, targetTokens, inner) + mongeElkan(targetTokens, inputTokens, inner)) / 2
+}
+
+// Creates a Monge–Elkan similarity algorithm with a custom inner algorithm
+//
+// # Parameters
+//  inner (SimilarityAlgorithm): The algorithm to compare the tokens with, JaroSimilarity is used if it's nil
+//  symmetric (bool): Whether to use SymmetricMongeElkanSimilarity() instead of MongeElkanSimilarity()
+//
+// # Returns
+//  SimilarityAlgorithm: The Monge–Elkan similarity algorithm
+func NewMongeElkanSimilarity(inner SimilarityAlgorithm, symmetric bool) SimilarityAlgorithm {
+	if symmetric {
+		return func(inputString, targetString string) float32 {
+			return SymmetricMongeElkanSimilarity(inputString, targetString, inner)
+		}
+	}
+	return func(inputString, targetString string) float32 {
+		return MongeElkanSimilarity(inputString, targetString, inner)
+	}
+}
+
+// Averages the best similarity of each input token against the target tokens
+func mongeElkan(inputTokens, targetTokens []string, inner SimilarityAlgorithm) float32 {
+	if len(inputTokens) == 0 && len(targetTokens) == 0 {
+		return 1
+	}
+	if len(inputTokens) == 0 || len(targetTokens) == 0 {
+		return 0
+	}
+
+	var total float32
+	for _, inputToken := range inputTokens {
+		var best float32
+		for _, targetToken := range targetTokens {
+			best = max(best, inner(inputToken, targetToken))
+			if best == 1 {
+				break
+			}
+		}
+		total += best
+	}
+	return total / float32(len(inputTokens))
+}
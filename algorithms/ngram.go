@@ -0,0 +1,494 @@
+package algorithms
+
+// This file implements similarities based on the character n-grams (overlapping runs of n runes) of two strings
+//
+// # References
+//  - https://en.wikipedia.org/wiki/N-gram
+//  - https://en.wikipedia.org/wiki/Trigram_search
+//  - https://www.postgresql.org/docs/current/pgtrgm.html
+//  - https://en.wikipedia.org/wiki/S%C3%B8rensen%E2%80%93Dice_coefficient
+//  - https://en.wikipedia.org/wiki/Overlap_coefficient
+
+import (
+	"math"
+	"strings"
+)
+
+// Calculates the n-gram similarity of two strings
+//
+// # Notes
+//  - Uses the Sørensen–Dice coefficient 2|A∩B| / (|A|+|B|) on the padded n-grams of each string, counting repeated n-grams
+//  - The padding lets the first and last characters count as much as the ones in the middle
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  n (int): The number of runes in each n-gram, values < 1 are treated as 1
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func NGramSimilarity(inputString, targetString string, n int) float32 {
+	if inputString == targetString {
+		return 1
+	}
+	if len(inputString) == 0 || len(targetString) == 0 {
+		return 0
+	}
+
+	inputGrams := ExtractNGrams(inputString, n)
+	targetGrams := ExtractNGrams(targetString, n)
+
+	overlap := countOverlap(countNGrams(inputGrams), countNGrams(targetGrams))
+	return 2 * float32(overlap) / float32(len(inputGrams)+len(targetGrams))
+}
+
+// Calculates the trigram similarity of two strings
+//
+// # Notes
+//  - The same as NGramSimilarity() with n=3
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func TrigramSimilarity(inputString, targetString string) float32 {
+	return NGramSimilarity(inputString, targetString, 3)
+}
+
+// Calculates the cosine similarity of the character bigram frequencies of two strings
+//
+// # Notes
+//  - Each string is turned into a vector of how often each bigram occurs, and the score is the cosine of the angle between them
+//  - Better than edit distances at capturing the structure of longer strings (i.e. sentences), since the order of the bigrams doesn't matter
+//  - A string with a single rune is treated as a single bigram
+//  - Runs in O(m+n) time
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func CosineBigramSimilarity(inputString, targetString string) float32 {
+	if inputString == targetString {
+		return 1
+	}
+	if len(inputString) == 0 || len(targetString) == 0 {
+		return 0
+	}
+
+	inputCounts := countNGrams(nGrams([]rune(inputString), 2))
+	targetCounts := countNGrams(nGrams([]rune(targetString), 2))
+
+	var dotProduct, inputMagnitude, targetMagnitude float64
+	for gram, count := range inputCounts {
+		dotProduct += float64(count * targetCounts[gram])
+		inputMagnitude += float64(count * count)
+	}
+	for _, count := range targetCounts {
+		targetMagnitude += float64(count * count)
+	}
+
+	similarity := dotProduct / (math.Sqrt(inputMagnitude) * math.Sqrt(targetMagnitude))
+	return float32(min(similarity, 1))
+}
+
+// Calculates the Jaccard similarity of the character n-grams of two strings
+//
+// # Notes
+//  - Calculated as |A∩B| / |A∪B|, where A and B are the sets of unpadded n-grams (repeats are only counted once)
+//  - A string shorter than n is treated as a single n-gram
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  n (int): The number of runes in each n-gram, values < 1 are treated as 1
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func JaccardSimilarity(inputString, targetString string, n int) float32 {
+	if inputString == targetString {
+		return 1
+	}
+	if len(inputString) == 0 || len(targetString) == 0 {
+		return 0
+	}
+
+	n = max(n, 1)
+	inputSet := countNGrams(nGrams([]rune(inputString), n))
+	targetSet := countNGrams(nGrams([]rune(targetString), n))
+
+	intersection := countSetOverlap(inputSet, targetSet)
+	union := len(inputSet) + len(targetSet) - intersection
+	return float32(intersection) / float32(union)
+}
+
+// Calculates the Jaccard distance of the character n-grams of two strings
+//
+// # Notes
+//  - Calculated as 1 - JaccardSimilarity(), which is a true metric
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  n (int): The number of runes in each n-gram, values < 1 are treated as 1
+//
+// # Returns
+//  float32: The distance (between 0-1, closer to 0 is more similar)
+func JaccardDistance(inputString, targetString string, n int) float32 {
+	return 1 - JaccardSimilarity(inputString, targetString, n)
+}
+
+// Calculates the overlap coefficient (Szymkiewicz–Simpson coefficient) of the character n-grams of two strings
+//
+// # Notes
+//  - Calculated as |A∩B| / min(|A|,|B|), where A and B are the sets of unpadded n-grams (repeats are only counted once)
+//  - Scores 1 whenever one set is a subset of the other, so abbreviations like "intl" score much higher against
+//     "international" than they do with JaccardSimilarity(), at the cost of also scoring any substring as a perfect match
+//  - A string shorter than n is treated as a single n-gram
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  n (int): The number of runes in each n-gram, values < 1 are treated as 1
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func OverlapCoefficient(inputString, targetString string, n int) float32 {
+	if inputString == targetString {
+		return 1
+	}
+	if len(inputString) == 0 || len(targetString) == 0 {
+		return 0
+	}
+
+	n = max(n, 1)
+	inputSet := countNGrams(nGrams([]rune(inputString), n))
+	targetSet := countNGrams(nGrams([]rune(targetString), n))
+
+	intersection := countSetOverlap(inputSet, targetSet)
+	return float32(intersection) / float32(min(len(inputSet), len(targetSet)))
+}
+
+// Calculates the overlap distance of the character n-grams of two strings
+//
+// # Notes
+//  - Calculated as 1 - OverlapCoefficient(), which is not a metric (a substring is at distance 0 from its superstring)
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  n (int): The number of runes in each n-gram, values < 1 are treated as 1
+//
+// # Returns
+//  float32: The distance (between 0-1, closer to 0 is more similar)
+func OverlapCoefficientDistance(inputString, targetString string, n int) float32 {
+	return 1 - OverlapCoefficient(inputString, targetString, n)
+}
+
+// Calculates the Jaccard similarity of the character bigrams of two strings
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func JaccardBigramSimilarity(inputString, targetString string) float32 {
+	return JaccardSimilarity(inputString, targetString, 2)
+}
+
+// Calculates the Jaccard similarity of the character trigrams of two strings
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func JaccardTrigramSimilarity(inputString, targetString string) float32 {
+	return JaccardSimilarity(inputString, targetString, 3)
+}
+
+// Calculates the Tversky index of the character n-grams of two strings
+//
+// # Notes
+//  - Calculated as |A∩B| / (|A∩B| + alpha*|A-B| + beta*|B-A|), where A and B are the sets of unpadded n-grams
+//  - alpha weights the n-grams only inputString has, beta weights the n-grams only targetString has, so the index is asymmetric unless alpha == beta
+//  - alpha = beta = 1 is the Jaccard similarity, alpha = beta = 0.5 is the Sørensen–Dice coefficient
+//  - When the strings share no n-grams and the weighted differences are 0 (i.e. alpha = beta = 0) the index is 0/0, which returns 0
+//  - Panics if alpha or beta is negative
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison (the "prototype")
+//  targetString (string): The second string to use for the comparison (the "variant")
+//  alpha (float32): The weight of n-grams only in inputString, must be >= 0
+//  beta (float32): The weight of n-grams only in targetString, must be >= 0
+//  n (int): The number of runes in each n-gram, values < 1 are treated as 1
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func TverskySimilarity(inputString, targetString string, alpha, beta float32, n int) float32 {
+	if alpha < 0 || beta < 0 {
+		panic("algorithms: TverskySimilarity alpha and beta must be non-negative")
+	}
+	if inputString == targetString {
+		return 1
+	}
+	if len(inputString) == 0 || len(targetString) == 0 {
+		return 0
+	}
+
+	n = max(n, 1)
+	inputSet := countNGrams(nGrams([]rune(inputString), n))
+	targetSet := countNGrams(nGrams([]rune(targetString), n))
+
+	intersection := float32(countSetOverlap(inputSet, targetSet))
+	inputOnly := float32(len(inputSet)) - intersection
+	targetOnly := float32(len(targetSet)) - intersection
+
+	denominator := intersection + alpha*inputOnly + beta*targetOnly
+	if denominator == 0 {
+		return 0
+	}
+	return intersection / denominator
+}
+
+// Creates a SimilarityAlgorithm that calculates the Tversky index with fixed weights
+//
+// # Notes
+//  - Panics if alpha or beta is negative
+//
+// # Parameters
+//  alpha (float32): The weight of n-grams only in inputString, must be >= 0
+//  beta (float32): The weight of n-grams only in targetString, must be >= 0
+//  n (int): The number of runes in each n-gram, values < 1 are treated as 1
+//
+// # Returns
+//  SimilarityAlgorithm: The algorithm, which can be passed to SuggestWord()
+func NewTverskySimilarity(alpha, beta float32, n int) SimilarityAlgorithm {
+	if alpha < 0 || beta < 0 {
+		panic("algorithms: NewTverskySimilarity alpha and beta must be non-negative")
+	}
+	return func(inputString, targetString string) float32 {
+		return TverskySimilarity(inputString, targetString, alpha, beta, n)
+	}
+}
+
+// Calculates the Sørensen–Dice coefficient of the character n-grams of two strings
+//
+// # Notes
+//  - Calculated as 2|A∩B| / (|A|+|B|), where A and B are the multisets of unpadded n-grams, so it weights the intersection more than JaccardSimilarity
+//  - Repeated n-grams are counted, but each one can only match once (i.e. "aaa" and "aa" share one "aa" bigram, not 2)
+//  - Unlike NGramSimilarity() the strings aren't padded, and a string shorter than n is treated as a single n-gram
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  n (int): The number of runes in each n-gram, values < 1 are treated as 1
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func SorensenDiceSimilarity(inputString, targetString string, n int) float32 {
+	if inputString == targetString {
+		return 1
+	}
+	if len(inputString) == 0 || len(targetString) == 0 {
+		return 0
+	}
+
+	n = max(n, 1)
+	inputGrams := nGrams([]rune(inputString), n)
+	targetGrams := nGrams([]rune(targetString), n)
+
+	overlap := countOverlap(countNGrams(inputGrams), countNGrams(targetGrams))
+	return 2 * float32(overlap) / float32(len(inputGrams)+len(targetGrams))
+}
+
+// Calculates the Sørensen–Dice distance of the character n-grams of two strings
+//
+// # Notes
+//  - Calculated as 1 - SorensenDiceSimilarity(), it isn't a true metric since it doesn't satisfy the triangle inequality
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  n (int): The number of runes in each n-gram, values < 1 are treated as 1
+//
+// # Returns
+//  float32: The distance (between 0-1, closer to 0 is more similar)
+func SorensenDiceDistance(inputString, targetString string, n int) float32 {
+	return 1 - SorensenDiceSimilarity(inputString, targetString, n)
+}
+
+// Calculates the Sørensen–Dice coefficient of the character bigrams of two strings
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func DiceBigramSimilarity(inputString, targetString string) float32 {
+	return SorensenDiceSimilarity(inputString, targetString, 2)
+}
+
+// How many times each q-gram occurs in a string, which can be built once and compared against many others
+type QGramProfile struct {
+	q      int
+	counts map[string]int
+	total  int
+}
+
+// Creates the q-gram profile of a string
+//
+// # Notes
+//  - Uses the unpadded q-grams of the string, and a string shorter than q is treated as a single q-gram
+//  - Build the profile of a query once, and compare it against the profiles of each candidate with Distance()
+//
+// # Parameters
+//  s (string): The string to build the profile of
+//  q (int): The number of runes in each q-gram, values < 1 are treated as 1
+//
+// # Returns
+//  QGramProfile: The q-gram counts of s
+func NewQGramProfile(s string, q int) QGramProfile {
+	q = max(q, 1)
+	grams := nGrams([]rune(s), q)
+	return QGramProfile{q, countNGrams(grams), len(grams)}
+}
+
+// Calculates the q-gram distance between two profiles
+//
+// # Notes
+//  - The L1 distance of the counts, which is the number of q-grams one string has that the other doesn't
+//  - Both profiles need to be built with the same q
+//
+// # Parameters
+//  other (QGramProfile): The profile to compare against
+//
+// # Returns
+//  int: The q-gram distance
+func (profile QGramProfile) Distance(other QGramProfile) int {
+	return profile.total + other.total - 2*countOverlap(profile.counts, other.counts)
+}
+
+// Calculates the q-gram distance of two strings
+//
+// # Notes
+//  - The L1 distance between how often each q-gram occurs in each string, using the unpadded q-grams
+//  - A string shorter than q is treated as a single q-gram
+//  - Each edit changes at most 2q q-grams, so the Levenshtein distance is at least QGramDistance/(2q), which makes it a cheap prefilter
+//  - Runs in O(m+n) time, use NewQGramProfile() to avoid rebuilding the profile of the same string
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  q (int): The number of runes in each q-gram, values < 1 are treated as 1
+//
+// # Returns
+//  int: The q-gram distance
+func QGramDistance(inputString, targetString string, q int) int {
+	return NewQGramProfile(inputString, q).Distance(NewQGramProfile(targetString, q))
+}
+
+// Calculates the q-gram similarity of two strings
+//
+// # Notes
+//  - Normalized by the total number of q-grams in both strings
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  q (int): The number of runes in each q-gram, values < 1 are treated as 1
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func QGramSimilarity(inputString, targetString string, q int) float32 {
+	if inputString == targetString {
+		return 1
+	}
+
+	inputProfile := NewQGramProfile(inputString, q)
+	targetProfile := NewQGramProfile(targetString, q)
+	return 1 - float32(inputProfile.Distance(targetProfile))/float32(inputProfile.total+targetProfile.total)
+}
+
+// Extracts the padded n-grams of a string
+//
+// # Notes
+//  - The string is padded with n-1 spaces on each side, so "ab" has the trigrams "  a", " ab", "ab ", and "b  "
+//  - Operates on runes, so multibyte characters are never split
+//  - An empty string has no n-grams
+//
+// # Parameters
+//  s (string): The string to extract the n-grams from
+//  n (int): The number of runes in each n-gram, values < 1 are treated as 1
+//
+// # Returns
+//  []string: The n-grams, in the order they appear in s (including repeats)
+func ExtractNGrams(s string, n int) []string {
+	n = max(n, 1)
+	if len(s) == 0 {
+		return []string{}
+	}
+	padding := strings.Repeat(" ", n-1)
+	return nGrams([]rune(padding+s+padding), n)
+}
+
+// Extracts the unpadded n-grams of a string
+//
+// # Notes
+//  - A string shorter than n is treated as a single n-gram, so short strings can still be compared
+//
+// # Parameters
+//  runes ([]rune): The runes of the string to extract the n-grams from
+//  n (int): The number of runes in each n-gram, must be >= 1
+//
+// # Returns
+//  []string: The n-grams, in the order they appear (including repeats)
+func nGrams(runes []rune, n int) []string {
+	if len(runes) == 0 {
+		return []string{}
+	}
+	if len(runes) < n {
+		return []string{string(runes)}
+	}
+
+	grams := make([]string, 0, len(runes)-n+1)
+	for i := 0; i+n <= len(runes); i++ {
+		grams = append(grams, string(runes[i:i+n]))
+	}
+	return grams
+}
+
+// Counts how many times each n-gram occurs
+func countNGrams(grams []string) map[string]int {
+	counts := make(map[string]int, len(grams))
+	for _, gram := range grams {
+		counts[gram] += 1
+	}
+	return counts
+}
+
+// Counts the number of distinct n-grams two sets have in common
+func countSetOverlap(inputSet, targetSet map[string]int) int {
+	overlap := 0
+	for gram := range inputSet {
+		if _, exists := targetSet[gram]; exists {
+			overlap += 1
+		}
+	}
+	return overlap
+}
+
+// Counts the size of the intersection of two multisets of n-grams
+func countOverlap(inputCounts, targetCounts map[string]int) int {
+	overlap := 0
+	for gram, count := range inputCounts {
+		overlap += min(count, targetCounts[gram])
+	}
+	return overlap
+}
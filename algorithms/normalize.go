@@ -0,0 +1,292 @@
+package algorithms
+
+// This file implements wrappers that normalize strings before they're passed to an algorithm
+//
+// # References
+//  - https://pkg.go.dev/strings#ToLower
+//  - https://unicode.org/reports/tr15/
+//  - https://pkg.go.dev/golang.org/x/text/unicode/norm
+//  - https://pkg.go.dev/strings#Fields
+//  - https://www.unicode.org/reports/tr44/#General_Category_Values
+//  - https://www.w3.org/TR/charmod-norm/#definitionCaseFolding
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Wraps a similarity algorithm so it ignores case
+//
+// # Notes
+//  - Both strings are lowercased with strings.ToLower before being passed to algorithm (i.e. "Hello" and "hello" have a similarity of 1)
+//  - Wrappers can be composed, since the result is also a SimilarityAlgorithm
+//
+// # Parameters
+//  algorithm (SimilarityAlgorithm): The algorithm to wrap
+//
+// # Returns
+//  SimilarityAlgorithm: The case insensitive version of algorithm
+func CaseInsensitive(algorithm SimilarityAlgorithm) SimilarityAlgorithm {
+	return func(inputString, targetString string) float32 {
+		return algorithm(strings.ToLower(inputString), strings.ToLower(targetString))
+	}
+}
+
+// Wraps a distance algorithm so it ignores case
+//
+// # Notes
+//  - Both strings are lowercased with strings.ToLower before being passed to algorithm (i.e. "Hello" and "hello" have a distance of 0)
+//  - Wrappers can be composed, since the result is also a DistanceAlgorithm
+//
+// # Parameters
+//  algorithm (DistanceAlgorithm): The algorithm to wrap
+//
+// # Returns
+//  DistanceAlgorithm: The case insensitive version of algorithm
+func CaseInsensitiveDistance(algorithm DistanceAlgorithm) DistanceAlgorithm {
+	return func(inputString, targetString string) int {
+		return algorithm(strings.ToLower(inputString), strings.ToLower(targetString))
+	}
+}
+
+// Wraps a similarity algorithm so it compares strings in Unicode Normalization Form C (NFC)
+//
+// # Notes
+//  - Visually identical strings can be made of different runes, i.e. "café" can end with U+00E9, or with "e" followed by U+0301
+//  - Both strings are converted to NFC with norm.NFC before being passed to algorithm, so these have a similarity of 1
+//  - Wrappers can be composed, since the result is also a SimilarityAlgorithm
+//
+// # Parameters
+//  algorithm (SimilarityAlgorithm): The algorithm to wrap
+//
+// # Returns
+//  SimilarityAlgorithm: The normalized version of algorithm
+func NormalizedUnicode(algorithm SimilarityAlgorithm) SimilarityAlgorithm {
+	return func(inputString, targetString string) float32 {
+		return algorithm(norm.NFC.String(inputString), norm.NFC.String(targetString))
+	}
+}
+
+// Wraps a distance algorithm so it compares strings in Unicode Normalization Form C (NFC)
+//
+// # Notes
+//  - Both strings are converted to NFC with norm.NFC before being passed to algorithm, so "café" has a distance of 0 regardless of how the é is encoded
+//  - Wrappers can be composed, since the result is also a DistanceAlgorithm
+//
+// # Parameters
+//  algorithm (DistanceAlgorithm): The algorithm to wrap
+//
+// # Returns
+//  DistanceAlgorithm: The normalized version of algorithm
+func NormalizedUnicodeDistance(algorithm DistanceAlgorithm) DistanceAlgorithm {
+	return func(inputString, targetString string) int {
+		return algorithm(norm.NFC.String(inputString), norm.NFC.String(targetString))
+	}
+}
+
+// Collapses each run of whitespace to a single space and trims the ends, or removes all whitespace if removeAll is true
+func normalizeWhitespace(inputString string, removeAll bool) string {
+	separator := " "
+	if removeAll {
+		separator = ""
+	}
+	return strings.Join(strings.Fields(inputString), separator)
+}
+
+// Wraps a similarity algorithm so it ignores differences in whitespace
+//
+// # Notes
+//  - Whitespace is anything unicode.IsSpace() matches, so tabs, newlines and non-breaking spaces are included
+//  - By default runs of whitespace are collapsed to a single space and the ends are trimmed (i.e. "new  york" and " new york" have a similarity of 1)
+//  - If removeAll is true whitespace is removed entirely, so "newyork" and "new york" have a similarity of 1 as well
+//  - Only the copies passed to algorithm are changed, so SuggestWord() still returns the word from the corpus as it was written
+//  - Wrappers can be composed, since the result is also a SimilarityAlgorithm
+//
+// # Parameters
+//  algorithm (SimilarityAlgorithm): The algorithm to wrap
+//  removeAll (bool): Whether to remove whitespace entirely instead of collapsing it
+//
+// # Returns
+//  SimilarityAlgorithm: The whitespace insensitive version of algorithm
+func WhitespaceInsensitive(algorithm SimilarityAlgorithm, removeAll bool) SimilarityAlgorithm {
+	return func(inputString, targetString string) float32 {
+		return algorithm(normalizeWhitespace(inputString, removeAll), normalizeWhitespace(targetString, removeAll))
+	}
+}
+
+// Wraps a distance algorithm so it ignores differences in whitespace
+//
+// # Notes
+//  - Whitespace is collapsed or removed the same way as WhitespaceInsensitive (i.e. "new\tyork" and "new york" have a distance of 0)
+//  - Wrappers can be composed, since the result is also a DistanceAlgorithm
+//
+// # Parameters
+//  algorithm (DistanceAlgorithm): The algorithm to wrap
+//  removeAll (bool): Whether to remove whitespace entirely instead of collapsing it
+//
+// # Returns
+//  DistanceAlgorithm: The whitespace insensitive version of algorithm
+func WhitespaceInsensitiveDistance(algorithm DistanceAlgorithm, removeAll bool) DistanceAlgorithm {
+	return func(inputString, targetString string) int {
+		return algorithm(normalizeWhitespace(inputString, removeAll), normalizeWhitespace(targetString, removeAll))
+	}
+}
+
+// Removes the accents from Latin letters (i.e. "résumé" becomes "resume" and "Ångström" becomes "Angstrom")
+//
+// # Notes
+//  - The string is decomposed with norm.NFD, the non-spacing marks (Unicode category Mn) are dropped, and the result is recomposed with norm.NFC
+//  - Marks are only dropped when they follow a Latin letter, other scripts use them for vowels and voicing (i.e. the virama in "हिन्दी" or the dakuten in "が"), so they're left as is
+//  - Letters that don't decompose into a base letter and a mark aren't changed (i.e. "ß", "ø" and "æ")
+//
+// # Parameters
+//  inputString (string): The string to remove the accents from
+//
+// # Returns
+//  string: The string without accents on Latin letters
+func StripDiacritics(inputString string) string {
+	var result strings.Builder
+	result.Grow(len(inputString))
+
+	afterLatin := false
+	for _, currentRune := range norm.NFD.String(inputString) {
+		if unicode.Is(unicode.Mn, currentRune) {
+			if !afterLatin {
+				result.WriteRune(currentRune)
+			}
+			continue
+		}
+		afterLatin = unicode.Is(unicode.Latin, currentRune)
+		result.WriteRune(currentRune)
+	}
+	return norm.NFC.String(result.String())
+}
+
+// Wraps a similarity algorithm so it ignores accents on Latin letters
+//
+// # Notes
+//  - Both strings are passed through StripDiacritics before being passed to algorithm (i.e. "naïve" and "naive" have a similarity of 1)
+//  - Wrappers can be composed, since the result is also a SimilarityAlgorithm
+//
+// # Parameters
+//  algorithm (SimilarityAlgorithm): The algorithm to wrap
+//
+// # Returns
+//  SimilarityAlgorithm: The accent insensitive version of algorithm
+func WithDiacriticStripping(algorithm SimilarityAlgorithm) SimilarityAlgorithm {
+	return func(inputString, targetString string) float32 {
+		return algorithm(StripDiacritics(inputString), StripDiacritics(targetString))
+	}
+}
+
+// A list of normalizers that are applied to strings in order before they're compared
+//
+// # Notes
+//  - Any func(string) string can be used as a normalizer, the built-in ones are FoldCaseString, StripDiacritics, CollapseWhitespace, StripPunctuation and TrimSpace
+//  - The built-in normalizers are idempotent (applying one twice is the same as applying it once)
+//  - The order matters, i.e. StripPunctuation can leave an accent on a letter that StripDiacritics has already run on, so put StripDiacritics after it
+type NormalizationPipeline struct {
+	normalizers []func(string) string
+}
+
+// Creates a pipeline that applies normalizers in the order they're passed
+//
+// # Parameters
+//  normalizers (...func(string) string): The normalizers to apply (i.e. FoldCaseString, StripDiacritics)
+//
+// # Returns
+//  *NormalizationPipeline: The pipeline
+func NewPipeline(normalizers ...func(string) string) *NormalizationPipeline {
+	return &NormalizationPipeline{normalizers: normalizers}
+}
+
+// Normalizes a string by applying each normalizer in the pipeline in order
+//
+// # Parameters
+//  inputString (string): The string to normalize
+//
+// # Returns
+//  string: The normalized string
+func (pipeline *NormalizationPipeline) Apply(inputString string) string {
+	for _, normalizer := range pipeline.normalizers {
+		inputString = normalizer(inputString)
+	}
+	return inputString
+}
+
+// Wraps a similarity algorithm so both strings are normalized by the pipeline before they're compared
+//
+// # Notes
+//  - Only the copies passed to algorithm are changed, so SuggestWord() still returns the word from the corpus as it was written
+//  - Wrappers can be composed, since the result is also a SimilarityAlgorithm
+//
+// # Parameters
+//  algorithm (SimilarityAlgorithm): The algorithm to wrap
+//
+// # Returns
+//  SimilarityAlgorithm: The normalized version of algorithm
+func (pipeline *NormalizationPipeline) WrapAlgorithm(algorithm SimilarityAlgorithm) SimilarityAlgorithm {
+	return func(inputString, targetString string) float32 {
+		return algorithm(pipeline.Apply(inputString), pipeline.Apply(targetString))
+	}
+}
+
+// Case folds a string, so strings that only differ in case are equal (i.e. "Straße" and "STRASSE" both become "strasse")
+//
+// # Notes
+//  - Uses full Unicode case folding from golang.org/x/text/cases, which handles more than strings.ToLower (i.e. "ß" becomes "ss" and "ς" becomes "σ")
+//  - Named FoldCaseString since FoldCase() compares two runes
+//
+// # Parameters
+//  inputString (string): The string to case fold
+//
+// # Returns
+//  string: The case folded string
+func FoldCaseString(inputString string) string {
+	return cases.Fold().String(inputString)
+}
+
+// Collapses each run of whitespace to a single space and trims the ends (i.e. " new\t york " becomes "new york")
+//
+// # Parameters
+//  inputString (string): The string to collapse the whitespace of
+//
+// # Returns
+//  string: The string with its whitespace collapsed
+func CollapseWhitespace(inputString string) string {
+	return normalizeWhitespace(inputString, false)
+}
+
+// Removes the punctuation from a string (i.e. "o'neil-smith, jr." becomes "oneilsmith jr")
+//
+// # Notes
+//  - Punctuation is anything unicode.IsPunct() matches, symbols like "$" and "+" are kept
+//  - Whitespace around the punctuation is kept, so use CollapseWhitespace after it to clean up any double spaces
+//
+// # Parameters
+//  inputString (string): The string to remove the punctuation from
+//
+// # Returns
+//  string: The string without punctuation
+func StripPunctuation(inputString string) string {
+	return strings.Map(func(currentRune rune) rune {
+		if unicode.IsPunct(currentRune) {
+			return -1
+		}
+		return currentRune
+	}, inputString)
+}
+
+// Removes the whitespace from the start and end of a string
+//
+// # Parameters
+//  inputString (string): The string to trim
+//
+// # Returns
+//  string: The trimmed string
+func TrimSpace(inputString string) string {
+	return strings.TrimSpace(inputString)
+}
@@ -0,0 +1,134 @@
+package algorithms
+
+// This file implements a numeric aware similarity, which compares runs of digits as numbers instead of as text
+//
+// # References
+//  - https://en.wikipedia.org/wiki/Natural_sort_order
+//  - https://en.wikipedia.org/wiki/Relative_change
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// A run of text or digits in a string
+type numericSegment struct {
+	text      string
+	isNumeric bool
+}
+
+// Splits a string into alternating runs of text and ASCII digits (i.e. "Invoice 1024" is "Invoice " and "1024")
+func numericSegments(inputString string) []numericSegment {
+	var segments []numericSegment
+	start := 0
+	for i, r := range inputString {
+		// Only ASCII digits count, since they're the only ones strconv can parse
+		isDigit := r >= '0' && r <= '9'
+		if i > start && isDigit != segments[len(segments)-1].isNumeric {
+			segments[len(segments)-1].text = inputString[start:i]
+			start = i
+		}
+		if i == start {
+			segments = append(segments, numericSegment{isNumeric: isDigit})
+		}
+	}
+	if len(segments) > 0 {
+		segments[len(segments)-1].text = inputString[start:]
+	}
+	return segments
+}
+
+// Calculates the similarity of two strings, comparing runs of digits by how far apart the numbers are, and the rest with LevenshteinSimilarity
+//
+// # Notes
+//  - Equivalent to NewNumericAwareSimilarity(LevenshteinSimilarity)
+//  - "Invoice 1024" is much closer to "Invoice 1025" than to "Invoice 9999", where LevenshteinSimilarity only sees one digit against four
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func NumericAwareSimilarity(inputString, targetString string) float32 {
+	return numericAwareSimilarity(inputString, targetString, LevenshteinSimilarity)
+}
+
+// Creates a numeric aware similarity algorithm that compares the text between numbers with a custom algorithm
+//
+// # Notes
+//  - Both strings are split into alternating runs of text and digits, and the runs are compared in order
+//  - Numbers are scored by their relative difference, 1 - |a-b|/max(a, b), so leading zeros are ignored ("007" and "7" are the same number)
+//  - Numbers too large for a uint64 are compared as text instead
+//  - Runs that are text in one string and a number in the other are compared as text, and runs with nothing to compare to score 0
+//  - The scores are weighted by the length of the longer run in each pair, in runes
+//  - Strings without any digits get the same similarity as textAlgorithm
+//  - Only ASCII digits count as numbers, signs and decimal points are treated as text
+//
+// # Parameters
+//  textAlgorithm (SimilarityAlgorithm): The algorithm to compare the text between numbers with (i.e. LevenshteinSimilarity)
+//
+// # Returns
+//  SimilarityAlgorithm: The numeric aware similarity algorithm
+func NewNumericAwareSimilarity(textAlgorithm SimilarityAlgorithm) SimilarityAlgorithm {
+	return func(inputString, targetString string) float32 {
+		return numericAwareSimilarity(inputString, targetString, textAlgorithm)
+	}
+}
+
+// Calculates the numeric aware similarity of two strings
+func numericAwareSimilarity(inputString, targetString string, textAlgorithm SimilarityAlgorithm) float32 {
+	if inputString == targetString {
+		return 1
+	}
+	if !strings.ContainsAny(inputString, "0123456789") && !strings.ContainsAny(targetString, "0123456789") {
+		return textAlgorithm(inputString, targetString)
+	}
+
+	inputSegments := numericSegments(inputString)
+	targetSegments := numericSegments(targetString)
+
+	var totalWeight, totalSimilarity float32
+	for i := range max(len(inputSegments), len(targetSegments)) {
+		// A run with nothing to compare to counts against the similarity
+		if i >= len(inputSegments) || i >= len(targetSegments) {
+			if i < len(inputSegments) {
+				totalWeight += float32(utf8.RuneCountInString(inputSegments[i].text))
+			} else {
+				totalWeight += float32(utf8.RuneCountInString(targetSegments[i].text))
+			}
+			continue
+		}
+
+		inputSegment, targetSegment := inputSegments[i], targetSegments[i]
+		weight := float32(max(utf8.RuneCountInString(inputSegment.text), utf8.RuneCountInString(targetSegment.text)))
+		totalWeight += weight
+		totalSimilarity += weight * compareSegments(inputSegment, targetSegment, textAlgorithm)
+	}
+
+	if totalWeight == 0 {
+		return 0
+	}
+	return max(0, min(totalSimilarity/totalWeight, 1))
+}
+
+// Compares two runs of a string, as numbers if they're both numbers that fit in a uint64, and as text otherwise
+func compareSegments(inputSegment, targetSegment numericSegment, textAlgorithm SimilarityAlgorithm) float32 {
+	if !inputSegment.isNumeric || !targetSegment.isNumeric {
+		return textAlgorithm(inputSegment.text, targetSegment.text)
+	}
+
+	inputNumber, inputErr := strconv.ParseUint(inputSegment.text, 10, 64)
+	targetNumber, targetErr := strconv.ParseUint(targetSegment.text, 10, 64)
+	if inputErr != nil || targetErr != nil {
+		return textAlgorithm(inputSegment.text, targetSegment.text)
+	}
+
+	if inputNumber == targetNumber {
+		return 1
+	}
+	largest := max(inputNumber, targetNumber)
+	difference := largest - min(inputNumber, targetNumber)
+	return 1 - float32(float64(difference)/float64(largest))
+}
@@ -0,0 +1,222 @@
+package algorithms
+
+// This file implements configurable algorithms, which take their optional parameters as functional options so they still fit SimilarityAlgorithm
+//
+// # References
+//  - https://dave.cheney.net/2014/10/17/functional-options-for-friendly-apis
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// The optional parameters of a configurable algorithm, each algorithm only uses the ones that apply to it
+type AlgorithmConfig struct {
+	PrefixScale float32 // How much each rune of common prefix boosts a Jaro-Winkler score, clamped to 0-0.25 (default 0.1)
+	NGramSize   int     // The number of runes in each n-gram (default 3)
+	CaseFold    bool    // Whether to lowercase both strings before comparing them (default false)
+
+	// The cost of replacing one rune with another in WeightedLevenshteinFunc, between 0-1 (default nil, which costs 1 for every substitution)
+	SubstitutionCost func(inputRune, targetRune rune) float64
+}
+
+// The config used by NewConfiguredAlgorithm() before any options are applied
+var DefaultAlgorithmConfig = AlgorithmConfig{
+	PrefixScale: 0.1,
+	NGramSize:   3,
+	CaseFold:    false,
+}
+
+// Changes one parameter of an AlgorithmConfig
+type AlgorithmOption func(config *AlgorithmConfig)
+
+// A similarity algorithm that reads it's optional parameters from a config
+type AlgorithmFunc func(inputString, targetString string, config AlgorithmConfig) float32
+
+// Sets how much each rune of common prefix boosts a Jaro-Winkler score
+//
+// # Parameters
+//  prefixScale (float32): The prefix scale, clamped to 0-0.25 by JaroWinklerFunc
+//
+// # Returns
+//  AlgorithmOption: The option
+func WithPrefixScale(prefixScale float32) AlgorithmOption {
+	return func(config *AlgorithmConfig) {
+		config.PrefixScale = prefixScale
+	}
+}
+
+// Sets the number of runes in each n-gram
+//
+// # Parameters
+//  n (int): The n-gram size, values < 1 are treated as 1
+//
+// # Returns
+//  AlgorithmOption: The option
+func WithNGramSize(n int) AlgorithmOption {
+	return func(config *AlgorithmConfig) {
+		config.NGramSize = max(1, n)
+	}
+}
+
+// Sets whether both strings are lowercased before they're compared
+//
+// # Notes
+//  - Lowercases with strings.ToLower, the same as CaseInsensitive()
+//
+// # Parameters
+//  caseFold (bool): Whether to ignore case
+//
+// # Returns
+//  AlgorithmOption: The option
+func WithCaseFold(caseFold bool) AlgorithmOption {
+	return func(config *AlgorithmConfig) {
+		config.CaseFold = caseFold
+	}
+}
+
+// Sets the cost of replacing one rune with another
+//
+// # Parameters
+//  substitutionCost (func(rune, rune) float64): The cost of replacing a rune, between 0-1, or nil to cost 1 for every substitution
+//
+// # Returns
+//  AlgorithmOption: The option
+func WithSubstitutionCost(substitutionCost func(inputRune, targetRune rune) float64) AlgorithmOption {
+	return func(config *AlgorithmConfig) {
+		config.SubstitutionCost = substitutionCost
+	}
+}
+
+// Sets the cost of replacing one rune with another to how far apart their keys are on the keyboard
+//
+// # Notes
+//  - The same as WithSubstitutionCost(QWERTYDistance), change QWERTYKeyCoordinates to use another layout
+//
+// # Returns
+//  AlgorithmOption: The option
+func WithQWERTYDistance() AlgorithmOption {
+	return WithSubstitutionCost(QWERTYDistance)
+}
+
+// Sets the cost of replacing one rune with another to how alike they look
+//
+// # Notes
+//  - The same as WithSubstitutionCost(VisualSubstitutionCost), add entries to VisualConfusables to cover other confusions
+//
+// # Returns
+//  AlgorithmOption: The option
+func WithVisualSimilarity() AlgorithmOption {
+	return WithSubstitutionCost(VisualSubstitutionCost)
+}
+
+// Creates a similarity algorithm from a configurable algorithm and options
+//
+// # Notes
+//  - Starts from DefaultAlgorithmConfig and applies opts in order, so later options win
+//  - The config is captured when the algorithm is created, so it's safe to use from multiple goroutines
+//  - CaseFold is applied here for every algorithm, so base doesn't need to handle it
+//  - i.e. NewConfiguredAlgorithm(JaroWinklerFunc, WithPrefixScale(0.2), WithCaseFold(true)) can be passed to SuggestWordWithSpecificAlgorithm()
+//
+// # Parameters
+//  base (AlgorithmFunc): The configurable algorithm (i.e. JaroWinklerFunc, or IgnoreConfig(LevenshteinSimilarity))
+//  opts (...AlgorithmOption): The options to configure it with
+//
+// # Returns
+//  SimilarityAlgorithm: The configured algorithm
+func NewConfiguredAlgorithm(base AlgorithmFunc, opts ...AlgorithmOption) SimilarityAlgorithm {
+	config := DefaultAlgorithmConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return func(inputString, targetString string) float32 {
+		if config.CaseFold {
+			inputString = strings.ToLower(inputString)
+			targetString = strings.ToLower(targetString)
+		}
+		return base(inputString, targetString, config)
+	}
+}
+
+// Turns a similarity algorithm into a configurable one that ignores the config
+//
+// # Notes
+//  - Lets options that NewConfiguredAlgorithm() handles itself, like WithCaseFold(), be used with any algorithm
+//
+// # Parameters
+//  algorithm (SimilarityAlgorithm): The algorithm
+//
+// # Returns
+//  AlgorithmFunc: The configurable version of algorithm
+func IgnoreConfig(algorithm SimilarityAlgorithm) AlgorithmFunc {
+	return func(inputString, targetString string, config AlgorithmConfig) float32 {
+		return algorithm(inputString, targetString)
+	}
+}
+
+// Calculates the Jaro-Winkler similarity of two strings, using config.PrefixScale
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  config (AlgorithmConfig): The config to read the prefix scale from
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func JaroWinklerFunc(inputString, targetString string, config AlgorithmConfig) float32 {
+	return JaroWinklerSimilarityWithPrefix(inputString, targetString, config.PrefixScale)
+}
+
+// Calculates the n-gram similarity of two strings, using config.NGramSize
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  config (AlgorithmConfig): The config to read the n-gram size from
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func NGramFunc(inputString, targetString string, config AlgorithmConfig) float32 {
+	return NGramSimilarity(inputString, targetString, config.NGramSize)
+}
+
+// Calculates the Jaccard similarity of two strings, using config.NGramSize
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  config (AlgorithmConfig): The config to read the n-gram size from
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func JaccardFunc(inputString, targetString string, config AlgorithmConfig) float32 {
+	return JaccardSimilarity(inputString, targetString, config.NGramSize)
+}
+
+// Calculates the weighted Levenshtein similarity of two strings, using config.SubstitutionCost
+//
+// # Notes
+//  - Inserting and deleting runes cost 1, so it's normalized by the length of the longer string in runes like KeyboardSimilarity()
+//  - i.e. NewConfiguredAlgorithm(WeightedLevenshteinFunc, WithQWERTYDistance()) scores typos of neighbouring keys higher than other substitutions
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  config (AlgorithmConfig): The config to read the substitution cost from
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func WeightedLevenshteinFunc(inputString, targetString string, config AlgorithmConfig) float32 {
+	if inputString == targetString {
+		return 1
+	}
+
+	substitutionCost := config.SubstitutionCost
+	if substitutionCost == nil {
+		substitutionCost = func(inputRune, targetRune rune) float64 { return 1 }
+	}
+	distance := WeightedLevenshteinDistance(inputString, targetString, 1, 1, substitutionCost)
+	longest := max(utf8.RuneCountInString(inputString), utf8.RuneCountInString(targetString))
+	return float32(max(1-distance/float64(longest), 0))
+}
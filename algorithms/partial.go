@@ -0,0 +1,67 @@
+package algorithms
+
+// This file implements the partial ratio of two strings, which scores how well the shorter string matches part of the longer one
+//
+// # References
+//  - https://github.com/seatgeek/fuzzywuzzy#partial-ratio
+//  - https://rapidfuzz.github.io/RapidFuzz/Usage/fuzz.html#partial-ratio
+
+// Calculates the partial ratio similarity of two strings
+//
+// # Notes
+//  - Slides the shorter string across the longer one, and returns the best similarity of any window of the same length
+//  - Each window is scored with the Indel (insert, delete) distance, calculated with LCSDistance
+//  - The shorter string is figured out automatically, so the order of the arguments doesn't matter
+//  - Returns 1 when one string is a contiguous substring of the other (i.e. "New York" and "New York City, NY, USA")
+//  - Operates on runes, and runs in O(m*n*(n-m)) time, where m is the length of the shorter string
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func PartialRatioSimilarity(inputString, targetString string) float32 {
+	return PartialSimilarity(inputString, targetString, indelRatio)
+}
+
+// Calculates the best similarity of a query and any part of a target string with the same length as the query
+//
+// # Notes
+//  - Slides a window the length of query across target, scores each window with algorithm, and returns the highest score
+//  - If query is longer than target they're swapped, so the shorter string is always the one slid across the longer one
+//  - Stops early once a window scores 1 (i.e. "Ford" in "Ford Motor Company")
+//  - Operates on runes, and calls algorithm n-m+1 times, where m is the length of the shorter string and n the longer one
+//
+// # Parameters
+//  query (string): The string to look for, usually the shorter one
+//  target (string): The string to look in
+//  algorithm (SimilarityAlgorithm): The algorithm to score each window with (i.e. LevenshteinSimilarity)
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func PartialSimilarity(query, target string, algorithm SimilarityAlgorithm) float32 {
+	if query == target {
+		return 1
+	}
+
+	queryRunes := []rune(query)
+	targetRunes := []rune(target)
+	if len(queryRunes) > len(targetRunes) {
+		return PartialSimilarity(target, query, algorithm)
+	}
+	if len(queryRunes) == 0 {
+		return 0
+	}
+
+	windowLength := len(queryRunes)
+	var highest float32
+	for start := 0; start+windowLength <= len(targetRunes); start++ {
+		highest = max(highest, algorithm(query, string(targetRunes[start:start+windowLength])))
+		if highest >= 1 {
+			break
+		}
+	}
+
+	return highest
+}
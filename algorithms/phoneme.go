@@ -0,0 +1,423 @@
+package algorithms
+
+// This file implements a phoneme-level distance, which converts words to approximate pronunciations and compares them with a weighted edit distance
+//
+// # References
+//  - http://www.speech.cs.cmu.edu/cgi-bin/cmudict
+//  - https://en.wikipedia.org/wiki/ARPABET
+//  - https://doi.org/10.1121/1.1907526 (Miller & Nicely 1955, An analysis of perceptual confusions among some English consonants)
+//  - https://en.wikipedia.org/wiki/Vowel_diagram
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// How a consonant is articulated, consonants that share more features are easier to mistake for each other
+type consonantFeatures struct {
+	voiced bool   // Whether the vocal cords vibrate (i.e. B is voiced and P isn't)
+	place  string // Where the airflow is blocked (i.e. "bilabial" for P, B and M)
+	manner string // How the airflow is blocked (i.e. "stop" for P, T and K)
+}
+
+// The consonants of the CMU Pronouncing Dictionary (ARPAbet) and their features
+var consonantPhonemes = map[string]consonantFeatures{
+	"P":  {false, "bilabial", "stop"},
+	"B":  {true, "bilabial", "stop"},
+	"T":  {false, "alveolar", "stop"},
+	"D":  {true, "alveolar", "stop"},
+	"K":  {false, "velar", "stop"},
+	"G":  {true, "velar", "stop"},
+	"CH": {false, "postalveolar", "affricate"},
+	"JH": {true, "postalveolar", "affricate"},
+	"F":  {false, "labiodental", "fricative"},
+	"V":  {true, "labiodental", "fricative"},
+	"TH": {false, "dental", "fricative"},
+	"DH": {true, "dental", "fricative"},
+	"S":  {false, "alveolar", "fricative"},
+	"Z":  {true, "alveolar", "fricative"},
+	"SH": {false, "postalveolar", "fricative"},
+	"ZH": {true, "postalveolar", "fricative"},
+	"HH": {false, "glottal", "fricative"},
+	"M":  {true, "bilabial", "nasal"},
+	"N":  {true, "alveolar", "nasal"},
+	"NG": {true, "velar", "nasal"},
+	"L":  {true, "alveolar", "lateral"},
+	"R":  {true, "postalveolar", "approximant"},
+	"W":  {true, "bilabial", "approximant"},
+	"Y":  {true, "palatal", "approximant"},
+}
+
+// Where a vowel is made in the mouth, vowels that are closer together are easier to mistake for each other
+type vowelFeatures struct {
+	height    float64 // How close the tongue is to the roof of the mouth, from 0 (high, i.e. IY) to 2 (low, i.e. AA)
+	backness  float64 // How far back the tongue is, from 0 (front, i.e. IY) to 2 (back, i.e. UW)
+	diphthong bool    // Whether the vowel glides into another one (i.e. AY), the position is where it starts
+}
+
+// The vowels of the CMU Pronouncing Dictionary (ARPAbet) and their features
+var vowelPhonemes = map[string]vowelFeatures{
+	"IY": {0, 0, false},
+	"IH": {0.5, 0.25, false},
+	"EY": {1, 0, true},
+	"EH": {1.5, 0, false},
+	"AE": {2, 0, false},
+	"ER": {1, 1, false},
+	"AH": {1.5, 1, false},
+	"AY": {2, 1, true},
+	"AW": {2, 1.5, true},
+	"AA": {2, 2, false},
+	"AO": {1.5, 2, false},
+	"OY": {1.5, 2, true},
+	"OW": {1, 2, true},
+	"UH": {0.5, 1.75, false},
+	"UW": {0, 2, false},
+}
+
+// The vowel each approximant (semivowel) is closest to
+var approximantVowels = map[string]string{"W": "UW", "Y": "IY", "R": "ER"}
+
+// The costs of each difference between two phonemes in PhonemeSubstitutionCost()
+const (
+	phonemeVoicingCost     = 0.4 // Voicing is rarely misheard, even in noise
+	phonemePlaceCost       = 0.2 // The place of articulation is misheard the most
+	phonemeMannerCost      = 0.5
+	phonemeVowelStepCost   = 0.2 // For each step of height or backness
+	phonemeDiphthongCost   = 0.2
+	phonemeApproximantCost = 0.5 // Between an approximant and the vowel it's closest to
+)
+
+// Converts a word into an approximate English pronunciation, as CMU Pronouncing Dictionary (ARPAbet) phonemes without stress markers
+//
+// # Notes
+//  - Uses a small set of letter-to-sound rules instead of a dictionary, so it's close for regular spellings but wrong for many irregular ones (i.e. "knight" is N AY T, but "one" is OW N)
+//  - Handles silent letters (i.e. "kn", "wr", final "e" and "gh"), consonant digraphs (i.e. "ch", "sh", "th", "ph", "ng"), soft "c" and "g", common vowel digraphs, r-coloured vowels, and long vowels before a silent "e"
+//  - Accents are removed before converting, anything else that isn't an ASCII letter is ignored
+//
+// # Parameters
+//  word (string): The word to convert
+//
+// # Returns
+//  []string: The phonemes of the word (i.e. "phone" is F OW N), empty if it has no ASCII letters
+func Phonemes(word string) []string {
+	letters := make([]byte, 0, len(word))
+	for _, currentRune := range norm.NFD.String(word) {
+		currentRune = unicode.ToLower(currentRune)
+		if currentRune >= 'a' && currentRune <= 'z' {
+			letters = append(letters, byte(currentRune))
+		}
+	}
+	full := string(letters)
+	at := func(index int) byte {
+		if index < 0 || index >= len(full) {
+			return 0
+		}
+		return full[index]
+	}
+
+	// A final e is silent (and makes the vowel before it long) unless it's the only vowel, and a final "le" after a consonant is AH L
+	end := len(full)
+	silentE, finalLE := false, false
+	if end > 2 && full[end-1] == 'e' {
+		if full[end-2] == 'l' && end > 3 && !isPhonemeVowelLetter(full[end-3]) {
+			end -= 2
+			silentE, finalLE = true, true
+		} else if strings.ContainsAny(full[:end-1], "aeiouy") {
+			end -= 1
+			silentE = true
+		}
+	}
+	word = full[:end]
+
+	phonemes := make([]string, 0, len(word))
+	for i := 0; i < len(word); {
+		rest := word[i:]
+		letter := word[i]
+
+		// Silent letters at the start of the word
+		if i == 0 {
+			if start, found := phonemeStartRules[rest[:min(2, len(rest))]]; found {
+				phonemes = append(phonemes, start)
+				i += 2
+				continue
+			}
+		}
+
+		// Runs of letters that make one sound, longest first
+		if matched, length := matchPhonemeRule(rest); length > 0 {
+			phonemes = append(phonemes, matched...)
+			i += length
+			continue
+		}
+
+		// A soft g after a d is one sound (i.e. "judge"), and gh is only pronounced at the start of a word (i.e. "ghost" but not "night")
+		if letter == 'd' && at(i+1) == 'g' && isSoftening(at(i+2)) {
+			phonemes = append(phonemes, "JH")
+			i += 2
+			continue
+		}
+		if letter == 'g' && at(i+1) == 'h' {
+			if i == 0 {
+				phonemes = append(phonemes, "G")
+			}
+			i += 2
+			continue
+		}
+
+		// Vowels before an r that isn't followed by another vowel are r-coloured
+		if isPhonemeVowelLetter(letter) && at(i+1) == 'r' && i+1 < len(word) && !isPhonemeVowelLetter(at(i+2)) && at(i+2) != 'y' {
+			switch letter {
+			case 'a':
+				phonemes = append(phonemes, "AA", "R")
+			case 'o':
+				phonemes = append(phonemes, "AO", "R")
+			default:
+				phonemes = append(phonemes, "ER")
+			}
+			i += 2
+			continue
+		}
+
+		switch {
+		case isPhonemeVowelLetter(letter):
+			// Vowels are long before a consonant and a silent e, or at the end of the word, and short otherwise
+			long := (silentE && i+2 == len(word) && !isPhonemeVowelLetter(word[i+1])) || (!silentE && i == len(word)-1)
+			if long {
+				phonemes = append(phonemes, longVowelPhonemes[letter])
+			} else {
+				phonemes = append(phonemes, shortVowelPhonemes[letter])
+			}
+		case letter == 'y':
+			switch {
+			case i == 0:
+				phonemes = append(phonemes, "Y")
+			case i == len(word)-1 && !strings.ContainsAny(word[:i], "aeiou"):
+				phonemes = append(phonemes, "AY")
+			case i == len(word)-1:
+				phonemes = append(phonemes, "IY")
+			default:
+				phonemes = append(phonemes, "IH")
+			}
+		case i > 0 && letter == word[i-1]:
+			// Doubled consonants are only pronounced once
+		case letter == 'c' && isSoftening(at(i+1)):
+			phonemes = append(phonemes, "S")
+		case letter == 'c':
+			phonemes = append(phonemes, "K")
+		case letter == 'g' && isSoftening(at(i+1)):
+			phonemes = append(phonemes, "JH")
+		case letter == 's' && i > 0 && isPhonemeVowelLetter(at(i-1)) && isPhonemeVowelLetter(at(i+1)):
+			phonemes = append(phonemes, "Z")
+		case letter == 'x' && i == 0:
+			phonemes = append(phonemes, "Z")
+		case letter == 'x':
+			phonemes = append(phonemes, "K", "S")
+		case letter == 'h':
+			// Only pronounced before a vowel (i.e. "oh")
+			if isPhonemeVowelLetter(at(i+1)) || at(i+1) == 'y' {
+				phonemes = append(phonemes, "HH")
+			}
+		default:
+			phonemes = append(phonemes, consonantLetterPhonemes[letter])
+		}
+		i += 1
+	}
+
+	if finalLE {
+		phonemes = append(phonemes, "AH", "L")
+	}
+	return phonemes
+}
+
+// The sounds of words starting with a silent letter
+var phonemeStartRules = map[string]string{"kn": "N", "gn": "N", "wr": "R", "ps": "S"}
+
+// Runs of letters that make one sound (or a fixed series of sounds), in the order they're checked
+var phonemeRules = []struct {
+	letters  string
+	phonemes []string
+}{
+	{"tion", []string{"SH", "AH", "N"}},
+	{"sion", []string{"ZH", "AH", "N"}},
+	{"tch", []string{"CH"}},
+	{"igh", []string{"AY"}},
+	{"ch", []string{"CH"}},
+	{"sh", []string{"SH"}},
+	{"th", []string{"TH"}},
+	{"ph", []string{"F"}},
+	{"wh", []string{"W"}},
+	{"ck", []string{"K"}},
+	{"ng", []string{"NG"}},
+	{"nk", []string{"NG", "K"}},
+	{"qu", []string{"K", "W"}},
+	{"ee", []string{"IY"}},
+	{"ea", []string{"IY"}},
+	{"ie", []string{"IY"}},
+	{"ei", []string{"EY"}},
+	{"ey", []string{"EY"}},
+	{"ai", []string{"EY"}},
+	{"ay", []string{"EY"}},
+	{"oo", []string{"UW"}},
+	{"ou", []string{"AW"}},
+	{"ow", []string{"AW"}},
+	{"oi", []string{"OY"}},
+	{"oy", []string{"OY"}},
+	{"au", []string{"AO"}},
+	{"aw", []string{"AO"}},
+	{"oa", []string{"OW"}},
+	{"ue", []string{"UW"}},
+	{"ew", []string{"UW"}},
+	{"ui", []string{"UW"}},
+}
+
+// Finds the first rule in phonemeRules that text starts with
+func matchPhonemeRule(text string) ([]string, int) {
+	for _, rule := range phonemeRules {
+		if strings.HasPrefix(text, rule.letters) {
+			return rule.phonemes, len(rule.letters)
+		}
+	}
+	return nil, 0
+}
+
+// The sounds of vowel letters when they're long (i.e. "a" in "cake") and short (i.e. "a" in "cat")
+var (
+	longVowelPhonemes  = map[byte]string{'a': "EY", 'e': "IY", 'i': "AY", 'o': "OW", 'u': "UW"}
+	shortVowelPhonemes = map[byte]string{'a': "AE", 'e': "EH", 'i': "IH", 'o': "AA", 'u': "AH"}
+)
+
+// The sounds of the consonant letters that don't depend on the letters around them
+var consonantLetterPhonemes = map[byte]string{
+	'b': "B", 'd': "D", 'f': "F", 'g': "G", 'j': "JH", 'k': "K", 'l': "L", 'm': "M", 'n': "N",
+	'p': "P", 'q': "K", 'r': "R", 's': "S", 't': "T", 'v': "V", 'w': "W", 'z': "Z",
+}
+
+// Checks if a letter makes a c or g before it soft (i.e. "city" and "gem")
+func isSoftening(letter byte) bool {
+	return letter != 0 && strings.IndexByte("eiy", letter) >= 0
+}
+
+// Checks if a lowercase letter is a vowel, y is handled separately since it can be either
+func isPhonemeVowelLetter(letter byte) bool {
+	return letter != 0 && strings.IndexByte("aeiou", letter) >= 0
+}
+
+// The cost of replacing one phoneme with another, based on how similar they sound
+//
+// # Notes
+//  - Consonants cost 0.4 if their voicing differs, 0.2 if their place of articulation differs, and 0.5 if their manner differs (i.e. P and B are 0.4, P and T are 0.2, and P and Z are 1), which follows Miller & Nicely's finding that voicing is rarely misheard and place often is
+//  - Vowels cost 0.2 for each step of height or backness between them, and 0.2 if only one is a diphthong (i.e. IY and IH are 0.15, IY and AA are 0.8)
+//  - Approximants cost 0.5 against the vowel they're closest to (W and UW, Y and IY, R and ER), any other consonant and vowel cost 1, as do unknown phonemes
+//  - The weights are hand-tuned from the ordering in perception studies, not fitted confusion probabilities
+//
+// # Parameters
+//  inputPhoneme (string): The phoneme being replaced, an ARPAbet symbol without stress (i.e. "AA")
+//  targetPhoneme (string): The phoneme replacing it
+//
+// # Returns
+//  float64: The cost of the substitution (between 0-1)
+func PhonemeSubstitutionCost(inputPhoneme, targetPhoneme string) float64 {
+	if inputPhoneme == targetPhoneme {
+		return 0
+	}
+
+	inputConsonant, inputIsConsonant := consonantPhonemes[inputPhoneme]
+	targetConsonant, targetIsConsonant := consonantPhonemes[targetPhoneme]
+	if inputIsConsonant && targetIsConsonant {
+		cost := 0.0
+		if inputConsonant.voiced != targetConsonant.voiced {
+			cost += phonemeVoicingCost
+		}
+		if inputConsonant.place != targetConsonant.place {
+			cost += phonemePlaceCost
+		}
+		if inputConsonant.manner != targetConsonant.manner {
+			cost += phonemeMannerCost
+		}
+		return min(cost, 1)
+	}
+
+	inputVowel, inputIsVowel := vowelPhonemes[inputPhoneme]
+	targetVowel, targetIsVowel := vowelPhonemes[targetPhoneme]
+	if inputIsVowel && targetIsVowel {
+		steps := math.Abs(inputVowel.height-targetVowel.height) + math.Abs(inputVowel.backness-targetVowel.backness)
+		cost := phonemeVowelStepCost * steps
+		if inputVowel.diphthong != targetVowel.diphthong {
+			cost += phonemeDiphthongCost
+		}
+		return min(cost, 1)
+	}
+
+	if approximantVowels[inputPhoneme] == targetPhoneme || approximantVowels[targetPhoneme] == inputPhoneme {
+		return phonemeApproximantCost
+	}
+	return 1
+}
+
+// Calculates the distance between the pronunciations of two words
+//
+// # Notes
+//  - Converts both words with Phonemes(), then finds the weighted edit distance of the phonemes, where inserting or deleting a phoneme costs 1 and substituting one costs PhonemeSubstitutionCost()
+//  - Spellings of the same sounds are 0 apart (i.e. "phone" and "fone"), and similar sounds are close (i.e. "bat" and "pat" are 0.4)
+//
+// # Parameters
+//  inputString (string): The first word to use for the comparison
+//  targetString (string): The second word to use for the comparison
+//
+// # Returns
+//  float64: The distance between the pronunciations
+func PhonemeDistance(inputString, targetString string) float64 {
+	return phonemeSequenceDistance(Phonemes(inputString), Phonemes(targetString))
+}
+
+// Calculates the similarity of the pronunciations of two words
+//
+// # Notes
+//  - Normalized by the number of phonemes in the longer pronunciation, since no edit costs more than 1
+//  - Words with no ASCII letters have no phonemes, and a similarity of 0 unless the words are identical
+//
+// # Parameters
+//  inputString (string): The first word to use for the comparison
+//  targetString (string): The second word to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func PhonemeSimilarity(inputString, targetString string) float32 {
+	if inputString == targetString {
+		return 1
+	}
+
+	inputPhonemes := Phonemes(inputString)
+	targetPhonemes := Phonemes(targetString)
+	if len(inputPhonemes) == 0 || len(targetPhonemes) == 0 {
+		return 0
+	}
+	longest := max(len(inputPhonemes), len(targetPhonemes))
+	return float32(1 - phonemeSequenceDistance(inputPhonemes, targetPhonemes)/float64(longest))
+}
+
+// The weighted edit distance of two phoneme sequences, the same as WeightedLevenshteinDistance() but over phonemes instead of runes
+func phonemeSequenceDistance(inputPhonemes, targetPhonemes []string) float64 {
+	previousRow := make([]float64, len(targetPhonemes)+1)
+	currentRow := make([]float64, len(targetPhonemes)+1)
+	for j := range previousRow {
+		previousRow[j] = float64(j)
+	}
+
+	for i := 1; i <= len(inputPhonemes); i++ {
+		currentRow[0] = float64(i)
+		for j := 1; j <= len(targetPhonemes); j++ {
+			currentRow[j] = min(
+				currentRow[j-1]+1, // Add
+				previousRow[j]+1,  // Delete
+				previousRow[j-1]+PhonemeSubstitutionCost(inputPhonemes[i-1], targetPhonemes[j-1]), // Edit/replace
+			)
+		}
+		previousRow, currentRow = currentRow, previousRow
+	}
+
+	return previousRow[len(targetPhonemes)]
+}
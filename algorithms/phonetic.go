@@ -0,0 +1,1590 @@
+package algorithms
+
+// This file implements phonetic encodings, which map words that sound alike to the same code
+//
+// # References
+//  - https://en.wikipedia.org/wiki/Phonetic_algorithm
+//  - https://en.wikipedia.org/wiki/Soundex
+//  - https://www.archives.gov/research/census/soundex
+//  - https://commons.apache.org/proper/commons-codec/apidocs/org/apache/commons/codec/language/RefinedSoundex.html
+//  - https://unicode.org/reports/tr15/
+//  - https://en.wikipedia.org/wiki/Metaphone
+//  - https://aspell.net/metaphone/dmetaph.cpp
+//  - https://en.wikipedia.org/wiki/New_York_State_Identification_and_Intelligence_System
+//  - https://commons.apache.org/proper/commons-codec/apidocs/org/apache/commons/codec/language/Nysiis.html
+//  - https://en.wikipedia.org/wiki/Caverphone
+//  - https://en.wikipedia.org/wiki/Match_rating_approach
+//  - Hood, D. (2004) Caverphone Revisited. Caversham Project Occasional Technical Paper
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// The Soundex digit for each letter, vowels (and Y) are 0, and H and W are -1 since they don't separate letters
+var soundexCodes = [26]int8{
+	0, 1, 2, 3, 0, 1, 2, -1, 0, 2, 2, 4, 5, // A-M
+	5, 0, 1, 2, 6, 2, 3, 0, 1, -1, 2, 0, 2, // N-Z
+}
+
+// Calculates the American Soundex code of a word
+//
+// # Notes
+//  - Follows the US National Archives rules, the code is the first letter followed by 3 digits (i.e. "Robert" is "R163")
+//  - Letters with the same digit next to each other are only coded once, including the first letter (i.e. "Pfister" is "P236")
+//  - Letters with the same digit separated by H or W are only coded once, but vowels separate them (i.e. "Ashcraft" is "A261")
+//  - Codes shorter than 4 characters are padded with 0's
+//  - Accents are removed before encoding (i.e. "Çelik" is coded like "Celik"), anything else that isn't an ASCII letter is ignored
+//  - Words with no ASCII letters have an empty code
+//
+// # Parameters
+//  word (string): The word to encode
+//
+// # Returns
+//  string: The Soundex code of the word
+func Soundex(word string) string {
+	code := make([]byte, 0, 4)
+	var previousDigit int8
+
+	// Decomposing splits accented letters into an ASCII letter and a combining mark, which is then ignored
+	for _, currentRune := range norm.NFD.String(word) {
+		currentRune = unicode.ToUpper(currentRune)
+		if currentRune < 'A' || currentRune > 'Z' {
+			continue
+		}
+		digit := soundexCodes[currentRune-'A']
+
+		switch {
+		case len(code) == 0:
+			code = append(code, byte(currentRune))
+		case digit > 0 && digit != previousDigit:
+			code = append(code, byte('0'+digit))
+		}
+		if len(code) == 4 {
+			break
+		}
+
+		// H and W don't separate letters with the same digit, so the previous digit carries over them
+		if digit >= 0 {
+			previousDigit = digit
+		}
+	}
+
+	if len(code) == 0 {
+		return ""
+	}
+	return string(code) + strings.Repeat("0", 4-len(code))
+}
+
+// Returns if two words have the same Soundex code
+//
+// # Notes
+//  - Words with no ASCII letters have an empty code, and never match anything (including each other)
+//
+// # Parameters
+//  inputString (string): The first word to use for the comparison
+//  targetString (string): The second word to use for the comparison
+//
+// # Returns
+//  bool: True if the words have the same non-empty Soundex code
+func SoundexMatch(inputString, targetString string) bool {
+	inputCode := Soundex(inputString)
+	return len(inputCode) > 0 && inputCode == Soundex(targetString)
+}
+
+// Calculates the similarity of the Soundex codes of two words
+//
+// # Notes
+//  - Words with the same code have a similarity of 1, otherwise it's 1 - LevenshteinDistance of the codes / 4
+//  - Words with no ASCII letters have an empty code, and a similarity of 0 unless the words are identical
+//  - The first letter is kept as is, so spellings that only differ in how the first sound is written don't match (i.e. "fone" is F500,
+//     the same as "fine", but "phone" is P500)
+//
+// # Parameters
+//  inputString (string): The first word to use for the comparison
+//  targetString (string): The second word to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func SoundexSimilarity(inputString, targetString string) float32 {
+	if inputString == targetString {
+		return 1
+	}
+
+	inputCode := Soundex(inputString)
+	targetCode := Soundex(targetString)
+	if len(inputCode) == 0 || len(targetCode) == 0 {
+		return 0
+	}
+
+	if inputCode == targetCode {
+		return 1
+	}
+	return 1 - float32(LevenshteinDistance(inputCode, targetCode))/float32(len(inputCode))
+}
+
+// The Refined Soundex digit for each letter, it splits the classic groups up further and codes vowels (and H, W and Y) as 0
+var refinedSoundexCodes = [26]byte{
+	'0', '1', '3', '6', '0', '2', '4', '0', '0', '4', '3', '7', '8', // A-M
+	'8', '0', '1', '5', '9', '3', '6', '0', '2', '0', '5', '0', '5', // N-Z
+}
+
+// Calculates the Refined Soundex code of a word
+//
+// # Notes
+//  - The code is the first letter followed by the digit of every letter, including the first one (i.e. "Robert" is "R901096")
+//  - Unlike Soundex, the code isn't truncated to 4 characters, vowels are kept as 0's, and there are 10 groups instead of 6, so fewer words share a code (i.e. "Smith" is "S38060" and "Sneed" is "S3806", but both are "S530" with Soundex)
+//  - Letters with the same digit next to each other are only coded once
+//  - Accents are removed before encoding, anything else that isn't an ASCII letter is ignored
+//  - Words with no ASCII letters have an empty code
+//
+// # Parameters
+//  word (string): The word to encode
+//
+// # Returns
+//  string: The Refined Soundex code of the word
+func RefinedSoundex(word string) string {
+	var code []byte
+	var previousDigit byte
+
+	for _, currentRune := range norm.NFD.String(word) {
+		currentRune = unicode.ToUpper(currentRune)
+		if currentRune < 'A' || currentRune > 'Z' {
+			continue
+		}
+		if len(code) == 0 {
+			code = append(code, byte(currentRune))
+		}
+
+		digit := refinedSoundexCodes[currentRune-'A']
+		if digit != previousDigit {
+			code = append(code, digit)
+		}
+		previousDigit = digit
+	}
+
+	return string(code)
+}
+
+// Calculates the similarity of the Refined Soundex codes of two words
+//
+// # Notes
+//  - The codes are compared with LevenshteinSimilarity, so codes that are close still get partial credit
+//  - Words with no ASCII letters have an empty code, and a similarity of 0 unless the words are identical
+//
+// # Parameters
+//  inputString (string): The first word to use for the comparison
+//  targetString (string): The second word to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func RefinedSoundexSimilarity(inputString, targetString string) float32 {
+	if inputString == targetString {
+		return 1
+	}
+
+	inputCode := RefinedSoundex(inputString)
+	targetCode := RefinedSoundex(targetString)
+	if len(inputCode) == 0 || len(targetCode) == 0 {
+		return 0
+	}
+	return LevenshteinSimilarity(inputCode, targetCode)
+}
+
+// Returns if a byte is an uppercase ASCII vowel
+func isMetaphoneVowel(letter byte) bool {
+	return strings.IndexByte("AEIOU", letter) >= 0
+}
+
+// Calculates the Metaphone code of a word
+//
+// # Notes
+//  - Follows Lawrence Philips' original rules, the code is made of consonant sounds with "0" standing in for "TH" (i.e. "Thumb" is "0M")
+//  - Common combinations are coded by sound (i.e. PH is F, CK is K, CH is X, SCH is SK, TIO and TIA are X)
+//  - Silent letters are dropped (i.e. KN, GN, PN, AE and WR at the start, B after M at the end, GH before a consonant)
+//  - Vowels are only kept when they start the word, and the code isn't truncated
+//  - Anything that isn't an ASCII letter is ignored, and the letters on either side of it are coded as if they were next to each other (i.e. "Kn1ght" is coded like "Knight")
+//  - Words with no ASCII letters have an empty code
+//
+// # Parameters
+//  word (string): The word to encode
+//
+// # Returns
+//  string: The Metaphone code of the word
+func Metaphone(word string) string {
+	letters := make([]byte, 0, len(word))
+	for _, currentRune := range word {
+		currentRune = unicode.ToUpper(currentRune)
+		if currentRune >= 'A' && currentRune <= 'Z' {
+			letters = append(letters, byte(currentRune))
+		}
+	}
+	if len(letters) < 2 {
+		return string(letters)
+	}
+
+	// Handle the exceptions for the first 2 letters
+	switch {
+	case strings.IndexByte("GKP", letters[0]) >= 0 && letters[1] == 'N', letters[0] == 'A' && letters[1] == 'E', letters[0] == 'W' && letters[1] == 'R':
+		letters = letters[1:]
+	case letters[0] == 'W' && letters[1] == 'H':
+		letters = letters[1:]
+		letters[0] = 'W'
+	case letters[0] == 'X':
+		letters[0] = 'S'
+	}
+
+	// Returns the letter at i, or 0 if it's out of range
+	at := func(i int) byte {
+		if i < 0 || i >= len(letters) {
+			return 0
+		}
+		return letters[i]
+	}
+	// Returns if the letters starting at i match a pattern
+	matches := func(i int, pattern string) bool {
+		return strings.HasPrefix(string(letters[i:]), pattern)
+	}
+	isFrontVowel := func(letter byte) bool {
+		return letter != 0 && strings.IndexByte("EIY", letter) >= 0
+	}
+
+	code := make([]byte, 0, len(letters))
+	for i := 0; i < len(letters); i++ {
+		current := letters[i]
+		previous, next := at(i-1), at(i+1)
+
+		// Double letters are only coded once, except for C (i.e. "accident")
+		if current == previous && current != 'C' {
+			continue
+		}
+
+		switch current {
+		case 'A', 'E', 'I', 'O', 'U':
+			if i == 0 {
+				code = append(code, current)
+			}
+		case 'B':
+			// Silent in MB at the end (i.e. "dumb")
+			if !(previous == 'M' && next == 0) {
+				code = append(code, 'B')
+			}
+		case 'C':
+			switch {
+			case previous == 'S' && isFrontVowel(next):
+				// Silent in SCE, SCI and SCY
+			case matches(i, "CIA"):
+				code = append(code, 'X')
+			case isFrontVowel(next):
+				code = append(code, 'S')
+			case previous == 'S' && next == 'H':
+				code = append(code, 'K')
+			case next == 'H':
+				code = append(code, 'X')
+			default:
+				code = append(code, 'K')
+			}
+		case 'D':
+			if next == 'G' && isFrontVowel(at(i+2)) {
+				code = append(code, 'J')
+				i += 2
+			} else {
+				code = append(code, 'T')
+			}
+		case 'G':
+			switch {
+			case next == 'H' && !isMetaphoneVowel(at(i+2)):
+				// Silent in GH at the end or before a consonant (i.e. "night")
+			case i > 0 && next == 'N' && (at(i+2) == 0 || matches(i, "GNED")):
+				// Silent in GN and GNED at the end (i.e. "sign")
+			case isFrontVowel(next):
+				code = append(code, 'J')
+			default:
+				code = append(code, 'K')
+			}
+		case 'H':
+			// Only coded before a vowel, and when it isn't part of CH, GH, PH, SH or TH
+			if isMetaphoneVowel(next) && strings.IndexByte("CGPST", previous) < 0 {
+				code = append(code, 'H')
+			}
+		case 'K':
+			if previous != 'C' {
+				code = append(code, 'K')
+			}
+		case 'P':
+			if next == 'H' {
+				code = append(code, 'F')
+			} else {
+				code = append(code, 'P')
+			}
+		case 'Q':
+			code = append(code, 'K')
+		case 'S':
+			if next == 'H' || matches(i, "SIO") || matches(i, "SIA") {
+				code = append(code, 'X')
+			} else {
+				code = append(code, 'S')
+			}
+		case 'T':
+			switch {
+			case matches(i, "TIA"), matches(i, "TIO"):
+				code = append(code, 'X')
+			case matches(i, "TCH"):
+				// Silent in TCH
+			case next == 'H':
+				code = append(code, '0')
+			default:
+				code = append(code, 'T')
+			}
+		case 'V':
+			code = append(code, 'F')
+		case 'W', 'Y':
+			if isMetaphoneVowel(next) {
+				code = append(code, current)
+			}
+		case 'X':
+			code = append(code, 'K', 'S')
+		case 'Z':
+			code = append(code, 'S')
+		default:
+			// F, J, L, M, N and R sound like themselves
+			code = append(code, current)
+		}
+	}
+
+	return string(code)
+}
+
+// Calculates the similarity of the Metaphone codes of two words
+//
+// # Notes
+//  - The codes are compared with LevenshteinSimilarity, so words with the same code have a similarity of 1
+//  - Words with no ASCII letters have an empty code, and a similarity of 0 unless the words are identical
+//
+// # Parameters
+//  inputString (string): The first word to use for the comparison
+//  targetString (string): The second word to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func MetaphoneSimilarity(inputString, targetString string) float32 {
+	if inputString == targetString {
+		return 1
+	}
+
+	inputCode := Metaphone(inputString)
+	targetCode := Metaphone(targetString)
+	if len(inputCode) == 0 || len(targetCode) == 0 {
+		return 0
+	}
+	return LevenshteinSimilarity(inputCode, targetCode)
+}
+
+// Holds the state of a word while it's being encoded with Double Metaphone
+type doubleMetaphoneEncoder struct {
+	word          []rune // The uppercase word, padded with spaces so rules can look past the end
+	length        int    // The length of the word without the padding
+	primary       []rune
+	secondary     []rune
+	slavoGermanic bool
+}
+
+// Returns the rune at i, or 0 if it's out of range
+func (encoder *doubleMetaphoneEncoder) at(i int) rune {
+	if i < 0 || i >= len(encoder.word) {
+		return 0
+	}
+	return encoder.word[i]
+}
+
+// Returns if the length runes starting at start match any of the options
+func (encoder *doubleMetaphoneEncoder) stringAt(start, length int, options ...string) bool {
+	if start < 0 || start+length > len(encoder.word) {
+		return false
+	}
+	substring := string(encoder.word[start : start+length])
+	for _, option := range options {
+		if substring == option {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns if the rune at i is a vowel (including Y)
+func (encoder *doubleMetaphoneEncoder) isVowel(i int) bool {
+	if i < 0 || i >= encoder.length {
+		return false
+	}
+	return strings.ContainsRune("AEIOUY", encoder.word[i])
+}
+
+// Adds the same sound to both codes
+func (encoder *doubleMetaphoneEncoder) add(sound string) {
+	encoder.addAlternate(sound, sound)
+}
+
+// Adds a sound to the primary code, and an alternate sound to the secondary code
+func (encoder *doubleMetaphoneEncoder) addAlternate(primarySound, secondarySound string) {
+	encoder.primary = append(encoder.primary, []rune(primarySound)...)
+	encoder.secondary = append(encoder.secondary, []rune(secondarySound)...)
+}
+
+// Calculates the Double Metaphone codes of a word
+//
+// # Notes
+//  - Follows Lawrence Philips' original rules, which cover many non-English (i.e. Slavic, Germanic, Italian, Spanish) spellings
+//  - The primary code is the most likely pronunciation, and the secondary code is an alternate one (i.e. "Smith" is "SM0" and "XMT")
+//  - The secondary code is the same as the primary code when there's no alternate pronunciation
+//  - Both codes are at most 4 characters long, with "0" standing in for "TH"
+//
+// # Parameters
+//  word (string): The word to encode
+//
+// # Returns
+//  string: The primary code of the word
+//  string: The secondary code of the word
+func DoubleMetaphone(word string) (primary, secondary string) {
+	upper := strings.ToUpper(word)
+	encoder := &doubleMetaphoneEncoder{
+		word:          []rune(upper + "     "),
+		length:        utf8.RuneCountInString(upper),
+		slavoGermanic: strings.ContainsAny(upper, "WK") || strings.Contains(upper, "CZ") || strings.Contains(upper, "WITZ"),
+	}
+	if encoder.length == 0 {
+		return "", ""
+	}
+	last := encoder.length - 1
+	current := 0
+
+	// Skip these when they start the word
+	if encoder.stringAt(0, 2, "GN", "KN", "PN", "WR", "PS") {
+		current += 1
+	}
+
+	// An X at the start sounds like an S (i.e. "Xavier")
+	if encoder.at(0) == 'X' {
+		encoder.add("S")
+		current += 1
+	}
+
+	for (len(encoder.primary) < 4 || len(encoder.secondary) < 4) && current < encoder.length {
+		switch encoder.at(current) {
+		case 'A', 'E', 'I', 'O', 'U', 'Y':
+			// Vowels are only coded at the start of the word, and are all A
+			if current == 0 {
+				encoder.add("A")
+			}
+			current += 1
+
+		case 'B':
+			// MB at the end (i.e. "dumb") is already skipped by M
+			encoder.add("P")
+			if encoder.at(current+1) == 'B' {
+				current += 2
+			} else {
+				current += 1
+			}
+
+		case 'Ç':
+			encoder.add("S")
+			current += 1
+
+		case 'C':
+			current = encoder.encodeC(current)
+
+		case 'D':
+			switch {
+			case encoder.stringAt(current, 2, "DG"):
+				if encoder.stringAt(current+2, 1, "I", "E", "Y") {
+					// i.e. "edge"
+					encoder.add("J")
+					current += 3
+				} else {
+					// i.e. "edgar"
+					encoder.add("TK")
+					current += 2
+				}
+			case encoder.stringAt(current, 2, "DT", "DD"):
+				encoder.add("T")
+				current += 2
+			default:
+				encoder.add("T")
+				current += 1
+			}
+
+		case 'F':
+			encoder.add("F")
+			if encoder.at(current+1) == 'F' {
+				current += 2
+			} else {
+				current += 1
+			}
+
+		case 'G':
+			current = encoder.encodeG(current)
+
+		case 'H':
+			// Only coded at the start of the word or between 2 vowels, this also skips HH
+			if (current == 0 || encoder.isVowel(current-1)) && encoder.isVowel(current+1) {
+				encoder.add("H")
+				current += 2
+			} else {
+				current += 1
+			}
+
+		case 'J':
+			current = encoder.encodeJ(current, last)
+
+		case 'K':
+			encoder.add("K")
+			if encoder.at(current+1) == 'K' {
+				current += 2
+			} else {
+				current += 1
+			}
+
+		case 'L':
+			if encoder.at(current+1) == 'L' {
+				// Spanish (i.e. "cabrillo", "gallegos")
+				if (current == encoder.length-3 && encoder.stringAt(current-1, 4, "ILLO", "ILLA", "ALLE")) ||
+					((encoder.stringAt(last-1, 2, "AS", "OS") || encoder.stringAt(last, 1, "A", "O")) && encoder.stringAt(current-1, 4, "ALLE")) {
+					encoder.addAlternate("L", "")
+					current += 2
+					break
+				}
+				current += 2
+			} else {
+				current += 1
+			}
+			encoder.add("L")
+
+		case 'M':
+			// Silent B in UMB at the end or before ER (i.e. "dumb", "thumber")
+			if (encoder.stringAt(current-1, 3, "UMB") && (current+1 == last || encoder.stringAt(current+2, 2, "ER"))) || encoder.at(current+1) == 'M' {
+				current += 2
+			} else {
+				current += 1
+			}
+			encoder.add("M")
+
+		case 'N':
+			encoder.add("N")
+			if encoder.at(current+1) == 'N' {
+				current += 2
+			} else {
+				current += 1
+			}
+
+		case 'Ñ':
+			encoder.add("N")
+			current += 1
+
+		case 'P':
+			if encoder.at(current+1) == 'H' {
+				encoder.add("F")
+				current += 2
+				break
+			}
+			// Also accounts for "campbell" and "raspberry"
+			if encoder.stringAt(current+1, 1, "P", "B") {
+				current += 2
+			} else {
+				current += 1
+			}
+			encoder.add("P")
+
+		case 'Q':
+			encoder.add("K")
+			if encoder.at(current+1) == 'Q' {
+				current += 2
+			} else {
+				current += 1
+			}
+
+		case 'R':
+			// French (i.e. "rogier"), but not "hochmeier"
+			if current == last && !encoder.slavoGermanic && encoder.stringAt(current-2, 2, "IE") && !encoder.stringAt(current-4, 2, "ME", "MA") {
+				encoder.addAlternate("", "R")
+			} else {
+				encoder.add("R")
+			}
+			if encoder.at(current+1) == 'R' {
+				current += 2
+			} else {
+				current += 1
+			}
+
+		case 'S':
+			current = encoder.encodeS(current, last)
+
+		case 'T':
+			switch {
+			case encoder.stringAt(current, 4, "TION"), encoder.stringAt(current, 3, "TIA", "TCH"):
+				encoder.add("X")
+				current += 3
+			case encoder.stringAt(current, 2, "TH"), encoder.stringAt(current, 3, "TTH"):
+				// "thomas", "thames" or Germanic
+				if encoder.stringAt(current+2, 2, "OM", "AM") || encoder.stringAt(0, 4, "VAN ", "VON ") || encoder.stringAt(0, 3, "SCH") {
+					encoder.add("T")
+				} else {
+					encoder.addAlternate("0", "T")
+				}
+				current += 2
+			default:
+				if encoder.stringAt(current+1, 1, "T", "D") {
+					current += 2
+				} else {
+					current += 1
+				}
+				encoder.add("T")
+			}
+
+		case 'V':
+			encoder.add("F")
+			if encoder.at(current+1) == 'V' {
+				current += 2
+			} else {
+				current += 1
+			}
+
+		case 'W':
+			current = encoder.encodeW(current, last)
+
+		case 'X':
+			// French (i.e. "breaux")
+			if !(current == last && (encoder.stringAt(current-3, 3, "IAU", "EAU") || encoder.stringAt(current-2, 2, "AU", "OU"))) {
+				encoder.add("KS")
+			}
+			if encoder.stringAt(current+1, 1, "C", "X") {
+				current += 2
+			} else {
+				current += 1
+			}
+
+		case 'Z':
+			// Chinese pinyin (i.e. "zhao")
+			if encoder.at(current+1) == 'H' {
+				encoder.add("J")
+				current += 2
+				break
+			}
+			if encoder.stringAt(current+1, 2, "ZO", "ZI", "ZA") || (encoder.slavoGermanic && current > 0 && encoder.at(current-1) != 'T') {
+				encoder.addAlternate("S", "TS")
+			} else {
+				encoder.add("S")
+			}
+			if encoder.at(current+1) == 'Z' {
+				current += 2
+			} else {
+				current += 1
+			}
+
+		default:
+			current += 1
+		}
+	}
+
+	primary = string(encoder.primary[:min(len(encoder.primary), 4)])
+	secondary = string(encoder.secondary[:min(len(encoder.secondary), 4)])
+	return primary, secondary
+}
+
+// Encodes the C at current, returning the position of the next rune to encode
+func (encoder *doubleMetaphoneEncoder) encodeC(current int) int {
+	// Germanic (i.e. "bacher", "macher")
+	if current > 1 && !encoder.isVowel(current-2) && encoder.stringAt(current-1, 3, "ACH") &&
+		encoder.at(current+2) != 'I' && (encoder.at(current+2) != 'E' || encoder.stringAt(current-2, 6, "BACHER", "MACHER")) {
+		encoder.add("K")
+		return current + 2
+	}
+
+	// "caesar"
+	if current == 0 && encoder.stringAt(current, 6, "CAESAR") {
+		encoder.add("S")
+		return current + 2
+	}
+
+	// Italian (i.e. "chianti")
+	if encoder.stringAt(current, 4, "CHIA") {
+		encoder.add("K")
+		return current + 2
+	}
+
+	if encoder.stringAt(current, 2, "CH") {
+		// "michael"
+		if current > 0 && encoder.stringAt(current, 4, "CHAE") {
+			encoder.addAlternate("K", "X")
+			return current + 2
+		}
+
+		// Greek roots (i.e. "chemistry", "chorus")
+		if current == 0 && (encoder.stringAt(current+1, 5, "HARAC", "HARIS") || encoder.stringAt(current+1, 3, "HOR", "HYM", "HIA", "HEM")) && !encoder.stringAt(0, 5, "CHORE") {
+			encoder.add("K")
+			return current + 2
+		}
+
+		// Germanic, Greek, or otherwise CH for a KH sound (i.e. "architect", "orchestra", "wachtler", but not "arch" or "tichner")
+		if encoder.stringAt(0, 4, "VAN ", "VON ") || encoder.stringAt(0, 3, "SCH") ||
+			encoder.stringAt(current-2, 6, "ORCHES", "ARCHIT", "ORCHID") ||
+			encoder.stringAt(current+2, 1, "T", "S") ||
+			((encoder.stringAt(current-1, 1, "A", "O", "U", "E") || current == 0) &&
+				encoder.stringAt(current+2, 1, "L", "R", "N", "M", "B", "H", "F", "V", "W", " ")) {
+			encoder.add("K")
+		} else if current > 0 {
+			if encoder.stringAt(0, 2, "MC") {
+				// i.e. "mchugh"
+				encoder.add("K")
+			} else {
+				encoder.addAlternate("X", "K")
+			}
+		} else {
+			encoder.add("X")
+		}
+		return current + 2
+	}
+
+	// Slavic (i.e. "czerny"), but not "wicz"
+	if encoder.stringAt(current, 2, "CZ") && !encoder.stringAt(current-2, 4, "WICZ") {
+		encoder.addAlternate("S", "X")
+		return current + 2
+	}
+
+	// Italian (i.e. "focaccia")
+	if encoder.stringAt(current+1, 3, "CIA") {
+		encoder.add("X")
+		return current + 3
+	}
+
+	// Double C, but not "mcclellan"
+	if encoder.stringAt(current, 2, "CC") && !(current == 1 && encoder.at(0) == 'M') {
+		// "bellocchio", but not "bacchus"
+		if encoder.stringAt(current+2, 1, "I", "E", "H") && !encoder.stringAt(current+2, 2, "HU") {
+			if (current == 1 && encoder.at(current-1) == 'A') || encoder.stringAt(current-1, 5, "UCCEE", "UCCES") {
+				// i.e. "accident", "accede", "succeed"
+				encoder.add("KS")
+			} else {
+				// i.e. "bacci", "bertucci"
+				encoder.add("X")
+			}
+			return current + 3
+		}
+		// Pierce's rule
+		encoder.add("K")
+		return current + 2
+	}
+
+	if encoder.stringAt(current, 2, "CK", "CG", "CQ") {
+		encoder.add("K")
+		return current + 2
+	}
+
+	if encoder.stringAt(current, 2, "CI", "CE", "CY") {
+		// Italian vs English
+		if encoder.stringAt(current, 3, "CIO", "CIE", "CIA") {
+			encoder.addAlternate("S", "X")
+		} else {
+			encoder.add("S")
+		}
+		return current + 2
+	}
+
+	encoder.add("K")
+
+	// Names like "mac caffrey" and "mac gregor"
+	if encoder.stringAt(current+1, 2, " C", " Q", " G") {
+		return current + 3
+	}
+	if encoder.stringAt(current+1, 1, "C", "K", "Q") && !encoder.stringAt(current+1, 2, "CE", "CI") {
+		return current + 2
+	}
+	return current + 1
+}
+
+// Encodes the G at current, returning the position of the next rune to encode
+func (encoder *doubleMetaphoneEncoder) encodeG(current int) int {
+	if encoder.at(current+1) == 'H' {
+		if current > 0 && !encoder.isVowel(current-1) {
+			encoder.add("K")
+			return current + 2
+		}
+
+		// i.e. "ghislane", "ghiradelli"
+		if current == 0 {
+			if encoder.at(current+2) == 'I' {
+				encoder.add("J")
+			} else {
+				encoder.add("K")
+			}
+			return current + 2
+		}
+
+		// Parker's rule (i.e. "hugh", "bough", "broughton")
+		if (current > 1 && encoder.stringAt(current-2, 1, "B", "H", "D")) ||
+			(current > 2 && encoder.stringAt(current-3, 1, "B", "H", "D")) ||
+			(current > 3 && encoder.stringAt(current-4, 1, "B", "H")) {
+			return current + 2
+		}
+
+		if current > 2 && encoder.at(current-1) == 'U' && encoder.stringAt(current-3, 1, "C", "G", "L", "R", "T") {
+			// i.e. "laugh", "mclaughlin", "cough", "rough"
+			encoder.add("F")
+		} else if current > 0 && encoder.at(current-1) != 'I' {
+			encoder.add("K")
+		}
+		return current + 2
+	}
+
+	if encoder.at(current+1) == 'N' {
+		if current == 1 && encoder.isVowel(0) && !encoder.slavoGermanic {
+			encoder.addAlternate("KN", "N")
+		} else if !encoder.stringAt(current+2, 2, "EY") && encoder.at(current+1) != 'Y' && !encoder.slavoGermanic {
+			// Not "cagney"
+			encoder.addAlternate("N", "KN")
+		} else {
+			encoder.add("KN")
+		}
+		return current + 2
+	}
+
+	// i.e. "tagliaro"
+	if encoder.stringAt(current+1, 2, "LI") && !encoder.slavoGermanic {
+		encoder.addAlternate("KL", "L")
+		return current + 2
+	}
+
+	// GES, GEP, GEL, GIE, etc at the start of the word
+	if current == 0 && (encoder.at(current+1) == 'Y' || encoder.stringAt(current+1, 2, "ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER")) {
+		encoder.addAlternate("K", "J")
+		return current + 2
+	}
+
+	// GER and GY, but not "danger", "ranger" or "manger"
+	if (encoder.stringAt(current+1, 2, "ER") || encoder.at(current+1) == 'Y') &&
+		!encoder.stringAt(0, 6, "DANGER", "RANGER", "MANGER") &&
+		!encoder.stringAt(current-1, 1, "E", "I") && !encoder.stringAt(current-1, 3, "RGY", "OGY") {
+		encoder.addAlternate("K", "J")
+		return current + 2
+	}
+
+	// Italian (i.e. "biaggi")
+	if encoder.stringAt(current+1, 1, "E", "I", "Y") || encoder.stringAt(current-1, 4, "AGGI", "OGGI") {
+		if encoder.stringAt(0, 4, "VAN ", "VON ") || encoder.stringAt(0, 3, "SCH") || encoder.stringAt(current+1, 2, "ET") {
+			// Obviously Germanic
+			encoder.add("K")
+		} else if encoder.stringAt(current+1, 4, "IER ") {
+			// Always soft with a French ending
+			encoder.add("J")
+		} else {
+			encoder.addAlternate("J", "K")
+		}
+		return current + 2
+	}
+
+	encoder.add("K")
+	if encoder.at(current+1) == 'G' {
+		return current + 2
+	}
+	return current + 1
+}
+
+// Encodes the J at current, returning the position of the next rune to encode
+func (encoder *doubleMetaphoneEncoder) encodeJ(current, last int) int {
+	// Spanish (i.e. "jose", "san jacinto")
+	if encoder.stringAt(current, 4, "JOSE") || encoder.stringAt(0, 4, "SAN ") {
+		if (current == 0 && encoder.at(current+4) == ' ') || encoder.stringAt(0, 4, "SAN ") {
+			encoder.add("H")
+		} else {
+			encoder.addAlternate("J", "H")
+		}
+		return current + 1
+	}
+
+	if current == 0 {
+		// i.e. "jankelowicz" should match "yankelovich"
+		encoder.addAlternate("J", "A")
+	} else if encoder.isVowel(current-1) && !encoder.slavoGermanic && (encoder.at(current+1) == 'A' || encoder.at(current+1) == 'O') {
+		// Spanish (i.e. "bajador")
+		encoder.addAlternate("J", "H")
+	} else if current == last {
+		encoder.addAlternate("J", "")
+	} else if !encoder.stringAt(current+1, 1, "L", "T", "K", "S", "N", "M", "B", "Z") && !encoder.stringAt(current-1, 1, "S", "K", "L") {
+		encoder.add("J")
+	}
+
+	if encoder.at(current+1) == 'J' {
+		return current + 2
+	}
+	return current + 1
+}
+
+// Encodes the S at current, returning the position of the next rune to encode
+func (encoder *doubleMetaphoneEncoder) encodeS(current, last int) int {
+	// i.e. "island", "isle", "carlisle", "carlysle"
+	if encoder.stringAt(current-1, 3, "ISL", "YSL") {
+		return current + 1
+	}
+
+	// i.e. "sugar"
+	if current == 0 && encoder.stringAt(current, 5, "SUGAR") {
+		encoder.addAlternate("X", "S")
+		return current + 1
+	}
+
+	if encoder.stringAt(current, 2, "SH") {
+		// Germanic
+		if encoder.stringAt(current+1, 4, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			encoder.add("S")
+		} else {
+			encoder.add("X")
+		}
+		return current + 2
+	}
+
+	// Italian and Armenian
+	if encoder.stringAt(current, 3, "SIO", "SIA") || encoder.stringAt(current, 4, "SIAN") {
+		if !encoder.slavoGermanic {
+			encoder.addAlternate("S", "X")
+		} else {
+			encoder.add("S")
+		}
+		return current + 3
+	}
+
+	// German and anglicisations (i.e. "smith" matches "schmidt", "snider" matches "schneider"), and Slavic SZ
+	if (current == 0 && encoder.stringAt(current+1, 1, "M", "N", "L", "W")) || encoder.stringAt(current+1, 1, "Z") {
+		encoder.addAlternate("S", "X")
+		if encoder.stringAt(current+1, 1, "Z") {
+			return current + 2
+		}
+		return current + 1
+	}
+
+	if encoder.stringAt(current, 2, "SC") {
+		// Schlesinger's rule
+		if encoder.at(current+2) == 'H' {
+			// Dutch (i.e. "school", "schooner")
+			if encoder.stringAt(current+3, 2, "OO", "ER", "EN", "UY", "ED", "EM") {
+				// i.e. "schermerhorn", "schenker"
+				if encoder.stringAt(current+3, 2, "ER", "EN") {
+					encoder.addAlternate("X", "SK")
+				} else {
+					encoder.add("SK")
+				}
+				return current + 3
+			}
+			if current == 0 && !encoder.isVowel(3) && encoder.at(3) != 'W' {
+				encoder.addAlternate("X", "S")
+			} else {
+				encoder.add("X")
+			}
+			return current + 3
+		}
+
+		if encoder.stringAt(current+2, 1, "I", "E", "Y") {
+			encoder.add("S")
+			return current + 3
+		}
+		encoder.add("SK")
+		return current + 3
+	}
+
+	// French (i.e. "resnais", "artois")
+	if current == last && encoder.stringAt(current-2, 2, "AI", "OI") {
+		encoder.addAlternate("", "S")
+	} else {
+		encoder.add("S")
+	}
+	if encoder.stringAt(current+1, 1, "S", "Z") {
+		return current + 2
+	}
+	return current + 1
+}
+
+// Encodes the W at current, returning the position of the next rune to encode
+func (encoder *doubleMetaphoneEncoder) encodeW(current, last int) int {
+	// Can also be in the middle of a word
+	if encoder.stringAt(current, 2, "WR") {
+		encoder.add("R")
+		return current + 2
+	}
+
+	if current == 0 && (encoder.isVowel(current+1) || encoder.stringAt(current, 2, "WH")) {
+		if encoder.isVowel(current + 1) {
+			// "wasserman" should match "vasserman"
+			encoder.addAlternate("A", "F")
+		} else {
+			// "uomo" should match "womo"
+			encoder.add("A")
+		}
+	}
+
+	// "arnow" should match "arnoff"
+	if (current == last && encoder.isVowel(current-1)) || encoder.stringAt(current-1, 5, "EWSKI", "EWSKY", "OWSKI", "OWSKY") || encoder.stringAt(0, 3, "SCH") {
+		encoder.addAlternate("", "F")
+		return current + 1
+	}
+
+	// Polish (i.e. "filipowicz")
+	if encoder.stringAt(current, 4, "WICZ", "WITZ") {
+		encoder.addAlternate("TS", "FX")
+		return current + 4
+	}
+
+	return current + 1
+}
+
+// Calculates the similarity of the Double Metaphone codes of two words
+//
+// # Notes
+//  - Compares all 4 pairings of the primary and secondary codes with LevenshteinSimilarity, and returns the highest
+//  - Words that sound alike in different languages share a code (i.e. "Schmidt" and "Smith" share "XMT")
+//  - Words with no codes have a similarity of 0 unless the words are identical
+//
+// # Parameters
+//  inputString (string): The first word to use for the comparison
+//  targetString (string): The second word to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func DoubleMetaphoneSimilarity(inputString, targetString string) float32 {
+	if inputString == targetString {
+		return 1
+	}
+
+	inputPrimary, inputSecondary := DoubleMetaphone(inputString)
+	targetPrimary, targetSecondary := DoubleMetaphone(targetString)
+
+	var highest float32
+	for _, inputCode := range []string{inputPrimary, inputSecondary} {
+		for _, targetCode := range []string{targetPrimary, targetSecondary} {
+			if len(inputCode) == 0 || len(targetCode) == 0 {
+				continue
+			}
+			highest = max(highest, LevenshteinSimilarity(inputCode, targetCode))
+		}
+	}
+	return highest
+}
+
+// The length NYSIIS codes are conventionally truncated to
+const NYSIISMaxLength = 6
+
+// Returns if a byte is an uppercase ASCII vowel, NYSIIS doesn't count Y as one
+func isNYSIISVowel(letter byte) bool {
+	return strings.IndexByte("AEIOU", letter) >= 0
+}
+
+// Calculates the New York State Identification and Intelligence System (NYSIIS) code of a word
+//
+// # Notes
+//  - The code is truncated to NYSIISMaxLength (6) characters, use NYSIISWithMaxLength for longer codes
+//  - Follows the published rule order, the start (i.e. MAC, KN, PH, SCH) and end (i.e. EE, DT, ND) of the word are rewritten, then each letter after the first is coded, then a trailing S, A or AY is dropped
+//  - Vowels are coded as A, and letters with the same code next to each other are only coded once (i.e. "Knight" is "NAGT")
+//  - Accents are removed before encoding, anything else that isn't an ASCII letter is ignored (i.e. "O'Daniel" is coded like "ODaniel")
+//  - Words with no ASCII letters have an empty code
+//
+// # Parameters
+//  word (string): The word to encode
+//
+// # Returns
+//  string: The NYSIIS code of the word
+func NYSIIS(word string) string {
+	return NYSIISWithMaxLength(word, NYSIISMaxLength)
+}
+
+// Calculates the New York State Identification and Intelligence System (NYSIIS) code of a word, truncated to a maximum length
+//
+// # Notes
+//  - A maxLength <= 0 doesn't truncate the code, this is sometimes called the unlimited (or "modified") NYSIIS code, but only the length limit is removed, the rules are the same as NYSIIS
+//
+// # Parameters
+//  word (string): The word to encode
+//  maxLength (int): The longest the code can be, or <= 0 for no limit
+//
+// # Returns
+//  string: The NYSIIS code of the word
+func NYSIISWithMaxLength(word string, maxLength int) string {
+	letters := make([]byte, 0, len(word))
+	for _, currentRune := range norm.NFD.String(word) {
+		currentRune = unicode.ToUpper(currentRune)
+		if currentRune >= 'A' && currentRune <= 'Z' {
+			letters = append(letters, byte(currentRune))
+		}
+	}
+	if len(letters) == 0 {
+		return ""
+	}
+
+	// Rewrite the start of the word
+	name := string(letters)
+	switch {
+	case strings.HasPrefix(name, "MAC"):
+		name = "MCC" + name[3:]
+	case strings.HasPrefix(name, "KN"):
+		name = "NN" + name[2:]
+	case strings.HasPrefix(name, "K"):
+		name = "C" + name[1:]
+	case strings.HasPrefix(name, "PH"), strings.HasPrefix(name, "PF"):
+		name = "FF" + name[2:]
+	case strings.HasPrefix(name, "SCH"):
+		name = "SSS" + name[3:]
+	}
+
+	// Rewrite the end of the word
+	if len(name) >= 2 {
+		switch name[len(name)-2:] {
+		case "EE", "IE":
+			name = name[:len(name)-2] + "Y"
+		case "DT", "RT", "RD", "NT", "ND":
+			name = name[:len(name)-2] + "D"
+		}
+	}
+
+	// Each letter is rewritten in place, so later letters see the rewritten letters before them
+	letters = []byte(name)
+	code := []byte{letters[0]}
+	for i := 1; i < len(letters); i++ {
+		var next, afterNext byte
+		if i+1 < len(letters) {
+			next = letters[i+1]
+		}
+		if i+2 < len(letters) {
+			afterNext = letters[i+2]
+		}
+
+		var replacement string
+		switch current := letters[i]; {
+		case current == 'E' && next == 'V':
+			replacement = "AF"
+		case isNYSIISVowel(current):
+			replacement = "A"
+		case current == 'Q':
+			replacement = "G"
+		case current == 'Z':
+			replacement = "S"
+		case current == 'M':
+			replacement = "N"
+		case current == 'K' && next == 'N':
+			replacement = "NN"
+		case current == 'K':
+			replacement = "C"
+		case current == 'S' && next == 'C' && afterNext == 'H':
+			replacement = "SSS"
+		case current == 'P' && next == 'H':
+			replacement = "FF"
+		// H is silent unless it's between vowels, and W is silent after a vowel
+		case current == 'H' && (!isNYSIISVowel(letters[i-1]) || !isNYSIISVowel(next)):
+			replacement = string(letters[i-1])
+		case current == 'W' && isNYSIISVowel(letters[i-1]):
+			replacement = string(letters[i-1])
+		default:
+			replacement = string(current)
+		}
+		copy(letters[i:], replacement)
+
+		if letters[i] != letters[i-1] {
+			code = append(code, letters[i])
+		}
+	}
+
+	// Drop a trailing S, then replace a trailing AY with Y, or drop a trailing A
+	if len(code) > 1 {
+		if code[len(code)-1] == 'S' {
+			code = code[:len(code)-1]
+		}
+		last := code[len(code)-1]
+		if len(code) > 2 && code[len(code)-2] == 'A' && last == 'Y' {
+			code = append(code[:len(code)-2], 'Y')
+		}
+		if last == 'A' {
+			code = code[:len(code)-1]
+		}
+	}
+
+	if maxLength > 0 && len(code) > maxLength {
+		code = code[:maxLength]
+	}
+	return string(code)
+}
+
+// Calculates the similarity of the NYSIIS codes of two words
+//
+// # Notes
+//  - The codes are truncated to NYSIISMaxLength characters, and compared with LevenshteinSimilarity so codes that are close still get partial credit
+//  - Words with no ASCII letters have an empty code, and a similarity of 0 unless the words are identical
+//
+// # Parameters
+//  inputString (string): The first word to use for the comparison
+//  targetString (string): The second word to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func NYSIISSimilarity(inputString, targetString string) float32 {
+	if inputString == targetString {
+		return 1
+	}
+
+	inputCode := NYSIIS(inputString)
+	targetCode := NYSIIS(targetString)
+	if len(inputCode) == 0 || len(targetCode) == 0 {
+		return 0
+	}
+	return LevenshteinSimilarity(inputCode, targetCode)
+}
+
+// Replaces old with new if it's at the start of text
+func replacePrefix(text, old, new string) string {
+	if strings.HasPrefix(text, old) {
+		return new + text[len(old):]
+	}
+	return text
+}
+
+// Replaces old with new if it's at the end of text
+func replaceSuffix(text, old, new string) string {
+	if strings.HasSuffix(text, old) {
+		return text[:len(text)-len(old)] + new
+	}
+	return text
+}
+
+// Replaces each run of one or more of a letter with a single replacement letter
+func collapseRuns(text string, letter, replacement byte) string {
+	result := make([]byte, 0, len(text))
+	for i := 0; i < len(text); i++ {
+		if text[i] != letter {
+			result = append(result, text[i])
+		} else if i == 0 || text[i-1] != letter {
+			result = append(result, replacement)
+		}
+	}
+	return string(result)
+}
+
+// Calculates the original (1.0) Caverphone code of a word
+//
+// # Notes
+//  - The first version of Caverphone2(), with fewer rules and a 6 character code padded with 1's (i.e. "Whittle" is "WTL111")
+//  - Unlike Caverphone 2.0 a final e isn't removed and y is never coded as a vowel, so Caverphone2() is usually the better choice
+//  - Accents are removed before encoding, anything else that isn't an ASCII letter is ignored
+//  - Words with no ASCII letters have an empty code (the reference implementation returns "111111")
+//
+// # Parameters
+//  word (string): The word to encode
+//
+// # Returns
+//  string: The Caverphone 1.0 code of the word
+func Caverphone1(word string) string {
+	letters := make([]byte, 0, len(word))
+	for _, currentRune := range norm.NFD.String(word) {
+		currentRune = unicode.ToLower(currentRune)
+		if currentRune >= 'a' && currentRune <= 'z' {
+			letters = append(letters, byte(currentRune))
+		}
+	}
+	if len(letters) == 0 {
+		return ""
+	}
+
+	// Lowercase letters are still being rewritten, uppercase letters and digits are finished sounds
+	code := string(letters)
+	for _, start := range []string{"cough", "rough", "tough", "enough"} {
+		code = replacePrefix(code, start, start[:len(start)-2]+"2f")
+	}
+	code = replacePrefix(code, "gn", "2n")
+	code = replaceSuffix(code, "mb", "m2")
+
+	for _, replacement := range [][2]string{
+		{"cq", "2q"}, {"ci", "si"}, {"ce", "se"}, {"cy", "sy"}, {"tch", "2ch"},
+		{"c", "k"}, {"q", "k"}, {"x", "k"}, {"v", "f"}, {"dg", "2g"},
+		{"tio", "sio"}, {"tia", "sia"}, {"d", "t"}, {"ph", "fh"},
+		{"b", "p"}, {"sh", "s2"}, {"z", "s"},
+	} {
+		code = strings.ReplaceAll(code, replacement[0], replacement[1])
+	}
+
+	// Vowels are A at the start, and 3 everywhere else
+	if strings.IndexByte("aeiou", code[0]) >= 0 {
+		code = "A" + code[1:]
+	}
+	for _, vowel := range []string{"a", "e", "i", "o", "u"} {
+		code = strings.ReplaceAll(code, vowel, "3")
+	}
+
+	code = strings.ReplaceAll(code, "3gh3", "3kh3")
+	code = strings.ReplaceAll(code, "gh", "22")
+	code = strings.ReplaceAll(code, "g", "k")
+
+	for _, letter := range []byte("stpkfmn") {
+		code = collapseRuns(code, letter, letter-'a'+'A')
+	}
+
+	for _, replacement := range [][2]string{
+		{"w3", "W3"}, {"wy", "Wy"}, {"wh3", "Wh3"}, {"why", "Why"}, {"w", "2"},
+	} {
+		code = strings.ReplaceAll(code, replacement[0], replacement[1])
+	}
+
+	code = replacePrefix(code, "h", "A")
+	code = strings.ReplaceAll(code, "h", "2")
+
+	for _, replacement := range [][2]string{
+		{"r3", "R3"}, {"ry", "Ry"}, {"r", "2"},
+		{"l3", "L3"}, {"ly", "Ly"}, {"l", "2"},
+		{"j", "y"}, {"y3", "Y3"}, {"y", "2"},
+	} {
+		code = strings.ReplaceAll(code, replacement[0], replacement[1])
+	}
+
+	// 2's are silent, and 3's are never kept
+	code = strings.ReplaceAll(code, "2", "")
+	code = strings.ReplaceAll(code, "3", "")
+
+	return (code + "111111")[:6]
+}
+
+// Calculates the Caverphone 2.0 code of a word
+//
+// # Notes
+//  - Designed by David Hood for matching names with New Zealand accents, the code is 10 characters padded with 1's (i.e. "Thomson" is "TMSN111111")
+//  - Applies the published rewrite rules in order, using plain string replacements instead of regular expressions
+//  - None of the rules drop a "p" between "m" and "s", so "Thompson" is "TMPSN11111" and doesn't share a code with "Thomson" (CaverphoneSimilarity still scores them 0.933), the same as the reference implementations
+//  - Accents are removed before encoding, anything else that isn't an ASCII letter is ignored
+//  - Words with no ASCII letters have an empty code (the reference implementation returns "1111111111")
+//
+// # Parameters
+//  word (string): The word to encode
+//
+// # Returns
+//  string: The Caverphone 2.0 code of the word
+func Caverphone2(word string) string {
+	letters := make([]byte, 0, len(word))
+	for _, currentRune := range norm.NFD.String(word) {
+		currentRune = unicode.ToLower(currentRune)
+		if currentRune >= 'a' && currentRune <= 'z' {
+			letters = append(letters, byte(currentRune))
+		}
+	}
+	if len(letters) == 0 {
+		return ""
+	}
+
+	// Lowercase letters are still being rewritten, uppercase letters and digits are finished sounds
+	code := replaceSuffix(string(letters), "e", "")
+	for _, start := range []string{"cough", "rough", "tough", "enough", "trough"} {
+		code = replacePrefix(code, start, start[:len(start)-2]+"2f")
+	}
+	code = replacePrefix(code, "gn", "2n")
+	code = replaceSuffix(code, "mb", "m2")
+
+	// Each replacement has to see the result of the one before it, so they can't be done in one pass
+	for _, replacement := range [][2]string{
+		{"cq", "2q"}, {"ci", "si"}, {"ce", "se"}, {"cy", "sy"}, {"tch", "2ch"},
+		{"c", "k"}, {"q", "k"}, {"x", "k"}, {"v", "f"}, {"dg", "2g"},
+		{"tio", "sio"}, {"tia", "sia"}, {"d", "t"}, {"ph", "fh"},
+		{"b", "p"}, {"sh", "s2"}, {"z", "s"},
+	} {
+		code = strings.ReplaceAll(code, replacement[0], replacement[1])
+	}
+
+	// Vowels are A at the start, and 3 everywhere else
+	if len(code) > 0 && strings.IndexByte("aeiou", code[0]) >= 0 {
+		code = "A" + code[1:]
+	}
+	for _, vowel := range []string{"a", "e", "i", "o", "u"} {
+		code = strings.ReplaceAll(code, vowel, "3")
+	}
+
+	code = strings.ReplaceAll(code, "j", "y")
+	code = replacePrefix(code, "y3", "Y3")
+	code = replacePrefix(code, "y", "A")
+	code = strings.ReplaceAll(code, "y", "3")
+
+	code = strings.ReplaceAll(code, "3gh3", "3kh3")
+	code = strings.ReplaceAll(code, "gh", "22")
+	code = strings.ReplaceAll(code, "g", "k")
+
+	for _, letter := range []byte("stpkfmn") {
+		code = collapseRuns(code, letter, letter-'a'+'A')
+	}
+
+	code = strings.ReplaceAll(code, "w3", "W3")
+	code = strings.ReplaceAll(code, "wh3", "Wh3")
+	code = replaceSuffix(code, "w", "3")
+	code = strings.ReplaceAll(code, "w", "2")
+
+	code = replacePrefix(code, "h", "A")
+	code = strings.ReplaceAll(code, "h", "2")
+
+	code = strings.ReplaceAll(code, "r3", "R3")
+	code = replaceSuffix(code, "r", "3")
+	code = strings.ReplaceAll(code, "r", "2")
+
+	code = strings.ReplaceAll(code, "l3", "L3")
+	code = replaceSuffix(code, "l", "3")
+	code = strings.ReplaceAll(code, "l", "2")
+
+	// 2's are silent, and 3's are only kept as a final A
+	code = strings.ReplaceAll(code, "2", "")
+	code = replaceSuffix(code, "3", "A")
+	code = strings.ReplaceAll(code, "3", "")
+
+	return (code + "1111111111")[:10]
+}
+
+// Calculates the similarity of the Caverphone 2.0 codes of two words
+//
+// # Notes
+//  - Words with the same code have a similarity of 1, otherwise the codes are compared with JaroSimilarity so close codes still get partial credit
+//  - Words with no ASCII letters have an empty code, and a similarity of 0 unless the words are identical
+//
+// # Parameters
+//  inputString (string): The first word to use for the comparison
+//  targetString (string): The second word to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func CaverphoneSimilarity(inputString, targetString string) float32 {
+	if inputString == targetString {
+		return 1
+	}
+
+	inputCode := Caverphone2(inputString)
+	targetCode := Caverphone2(targetString)
+	if len(inputCode) == 0 || len(targetCode) == 0 {
+		return 0
+	}
+	if inputCode == targetCode {
+		return 1
+	}
+	return JaroSimilarity(inputCode, targetCode)
+}
+
+// Calculates the Match Rating Approach (MRA) code of a word
+//
+// # Notes
+//  - Developed by Western Airlines in 1977, vowels are removed unless they start the word, then doubled consonants are only coded once (i.e. "Byrne" is "BYRN")
+//  - Codes longer than 6 letters are shortened to their first 3 and last 3 letters (i.e. "Christopherson" is "CHRRSN")
+//  - Accents are removed before encoding, anything else that isn't an ASCII letter is ignored
+//  - Words with no ASCII letters have an empty code
+//
+// # Parameters
+//  word (string): The word to encode
+//
+// # Returns
+//  string: The MRA code of the word
+func MRAEncode(word string) string {
+	code := make([]byte, 0, len(word))
+	for _, currentRune := range norm.NFD.String(word) {
+		currentRune = unicode.ToUpper(currentRune)
+		if currentRune < 'A' || currentRune > 'Z' {
+			continue
+		}
+		letter := byte(currentRune)
+
+		// Vowels are only kept at the start, and doubled consonants are only coded once
+		if isMetaphoneVowel(letter) {
+			if len(code) == 0 {
+				code = append(code, letter)
+			}
+			continue
+		}
+		if len(code) > 0 && code[len(code)-1] == letter {
+			continue
+		}
+		code = append(code, letter)
+	}
+
+	if len(code) > 6 {
+		code = append(code[:3], code[len(code)-3:]...)
+	}
+	return string(code)
+}
+
+// Compares two words using the Match Rating Approach (MRA)
+//
+// # Notes
+//  - Codes whose lengths differ by 3 or more never match, and have a rating of 0
+//  - Letters that are the same at the same position from the start, or from the end, of both codes are removed, and the rating is 6 minus the number of letters left in the longer code
+//  - The words match if the rating is at least the minimum for the combined length of the codes (5 for <= 4, 4 for <= 7, 3 for <= 11, and 2 for 12)
+//  - Words with no ASCII letters never match, and have a rating of 0
+//
+// # Parameters
+//  inputString (string): The first word to use for the comparison
+//  targetString (string): The second word to use for the comparison
+//
+// # Returns
+//  bool: True if the words are considered a match
+//  int: The similarity rating of the words (between 0-6, closer to 6 is more similar)
+func MRACompare(inputString, targetString string) (bool, int) {
+	inputCode := []byte(MRAEncode(inputString))
+	targetCode := []byte(MRAEncode(targetString))
+	if len(inputCode) == 0 || len(targetCode) == 0 {
+		return false, 0
+	}
+	if len(inputCode)-len(targetCode) >= 3 || len(targetCode)-len(inputCode) >= 3 {
+		return false, 0
+	}
+
+	var minimumRating int
+	switch combinedLength := len(inputCode) + len(targetCode); {
+	case combinedLength <= 4:
+		minimumRating = 5
+	case combinedLength <= 7:
+		minimumRating = 4
+	case combinedLength <= 11:
+		minimumRating = 3
+	default:
+		minimumRating = 2
+	}
+
+	// Letters are compared with the codes as they were before anything was removed
+	inputUnmatched := len(inputCode)
+	targetUnmatched := len(targetCode)
+	inputMatched := make([]bool, len(inputCode))
+	targetMatched := make([]bool, len(targetCode))
+	match := func(i, j int) {
+		if inputCode[i] != targetCode[j] {
+			return
+		}
+		if !inputMatched[i] {
+			inputMatched[i] = true
+			inputUnmatched -= 1
+		}
+		if !targetMatched[j] {
+			targetMatched[j] = true
+			targetUnmatched -= 1
+		}
+	}
+	for i := 0; i < min(len(inputCode), len(targetCode)); i++ {
+		match(i, i)                                    // Left to right
+		match(len(inputCode)-1-i, len(targetCode)-1-i) // Right to left
+	}
+
+	rating := 6 - max(inputUnmatched, targetUnmatched)
+	return rating >= minimumRating, rating
+}
+
+// Calculates the Match Rating Approach (MRA) similarity of two words
+//
+// # Notes
+//  - The rating from MRACompare() divided by 6, so words that don't match still get partial credit
+//  - Words with no ASCII letters have a similarity of 0 unless the words are identical
+//
+// # Parameters
+//  inputString (string): The first word to use for the comparison
+//  targetString (string): The second word to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func MRASimilarity(inputString, targetString string) float32 {
+	if inputString == targetString {
+		return 1
+	}
+	_, rating := MRACompare(inputString, targetString)
+	return float32(rating) / 6
+}
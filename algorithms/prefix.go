@@ -0,0 +1,118 @@
+package algorithms
+
+// This file implements similarities based on the common prefix or suffix of two strings, for autocomplete-style matching,
+// and for matching words with the same ending (i.e. plurals, file extensions, or hostnames under the same domain)
+//
+// # References
+//  - https://en.wikipedia.org/wiki/Jaro%E2%80%93Winkler_distance#Jaro%E2%80%93Winkler_similarity
+
+import "unicode/utf8"
+
+// Calculates the prefix similarity of two strings
+//
+// # Notes
+//  - The number of runes the strings start with in common, divided by the length of the longer string in runes (i.e. "über" and "überfahrt" have a similarity of 4/9)
+//  - Use CaseInsensitive(PrefixSimilarity), or NewConfiguredAlgorithm(IgnoreConfig(PrefixSimilarity), WithCaseFold(true)), to ignore case
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func PrefixSimilarity(inputString, targetString string) float32 {
+	if inputString == targetString {
+		return 1
+	}
+	if len(inputString) == 0 || len(targetString) == 0 {
+		return 0
+	}
+
+	// Convert to runes to avoid weird encoding issues
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+
+	prefixLength := 0
+	for prefixLength < min(len(inputStringRunes), len(targetStringRunes)) && inputStringRunes[prefixLength] == targetStringRunes[prefixLength] {
+		prefixLength += 1
+	}
+	return float32(prefixLength) / float32(max(len(inputStringRunes), len(targetStringRunes)))
+}
+
+// Wraps a similarity algorithm so strings that start the same way score higher, the way Jaro-Winkler does for Jaro
+//
+// # Notes
+//  - The similarity is base + boost * PrefixSimilarity * (1 - base), so it's never lower than base and never over 1
+//  - Unlike Jaro-Winkler the prefix isn't capped at 4 runes, it's the fraction of the longer string that's a common prefix
+//  - boost is clamped to 0-1, with 0 the similarity is the same as base, and with 1 a string that's a prefix of a slightly longer one scores close to 1
+//
+// # Parameters
+//  base (SimilarityAlgorithm): The algorithm to add the prefix bonus to (i.e. LevenshteinSimilarity)
+//  boost (float32): How much of the remaining similarity the common prefix can make up
+//
+// # Returns
+//  SimilarityAlgorithm: The prefix boosted version of base
+func PrefixBoostedSimilarity(base SimilarityAlgorithm, boost float32) SimilarityAlgorithm {
+	boost = max(0, min(boost, 1))
+	return func(inputString, targetString string) float32 {
+		similarity := base(inputString, targetString)
+		return similarity + boost*PrefixSimilarity(inputString, targetString)*(1-similarity)
+	}
+}
+
+// Calculates the suffix similarity of two strings
+//
+// # Notes
+//  - The number of runes the strings end with in common, divided by the length of the longer string in runes (i.e. "cats" and "dogs" have a similarity of 1/4)
+//  - The strings are walked backwards a rune at a time, so a multi-byte rune is never split (i.e. "é" and "è" share their last byte, but not their last rune)
+//  - A string that is the end of the other scores its share of the longer string (i.e. "ample" and "example" have a similarity of 5/7)
+//  - Use CaseInsensitive(SuffixSimilarity) to ignore case
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func SuffixSimilarity(inputString, targetString string) float32 {
+	if inputString == targetString {
+		return 1
+	}
+	if len(inputString) == 0 || len(targetString) == 0 {
+		return 0
+	}
+
+	longestLength := max(utf8.RuneCountInString(inputString), utf8.RuneCountInString(targetString))
+	suffixLength := 0
+	for len(inputString) > 0 && len(targetString) > 0 {
+		inputRune, inputSize := utf8.DecodeLastRuneInString(inputString)
+		targetRune, targetSize := utf8.DecodeLastRuneInString(targetString)
+		if inputRune != targetRune {
+			break
+		}
+		suffixLength += 1
+		inputString = inputString[:len(inputString)-inputSize]
+		targetString = targetString[:len(targetString)-targetSize]
+	}
+	return float32(suffixLength) / float32(longestLength)
+}
+
+// Wraps a similarity algorithm so strings that end the same way score higher
+//
+// # Notes
+//  - The similarity is base + boost * SuffixSimilarity * (1 - base), the same as PrefixBoostedSimilarity() but with the common suffix
+//  - boost is clamped to 0-1, with 0 the similarity is the same as base
+//
+// # Parameters
+//  base (SimilarityAlgorithm): The algorithm to add the suffix bonus to (i.e. LevenshteinSimilarity)
+//  boost (float32): How much of the remaining similarity the common suffix can make up
+//
+// # Returns
+//  SimilarityAlgorithm: The suffix boosted version of base
+func SuffixBoostedSimilarity(base SimilarityAlgorithm, boost float32) SimilarityAlgorithm {
+	boost = max(0, min(boost, 1))
+	return func(inputString, targetString string) float32 {
+		similarity := base(inputString, targetString)
+		return similarity + boost*SuffixSimilarity(inputString, targetString)*(1-similarity)
+	}
+}
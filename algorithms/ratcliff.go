@@ -0,0 +1,219 @@
+package algorithms
+
+// This file implements the Ratcliff/Obershelp similarity of two strings, and its cheap upper bounds
+//
+// # References
+//  - https://en.wikipedia.org/wiki/Gestalt_pattern_matching
+//  - https://docs.python.org/3/library/difflib.html#difflib.SequenceMatcher.quick_ratio
+//  - https://github.com/python/cpython/blob/main/Lib/difflib.py
+
+import "unicode/utf8"
+
+// Calculates the Ratcliff/Obershelp (gestalt pattern matching) similarity of two strings
+//
+// # Notes
+//  - Equivalent to difflib's SequenceMatcher(None, inputString, targetString).ratio(), but operates on runes
+//  - Finds the longest common block, then recurses on the unmatched regions to the left and right of it
+//  - Like difflib, runes that make up more than 1% of a target string with at least 200 runes can't start a block (autojunk)
+//  - Not symmetric, swapping the strings can change the similarity
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func RatcliffObershelpSimilarity(inputString, targetString string) float32 {
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+
+	totalLength := len(inputStringRunes) + len(targetStringRunes)
+	if totalLength == 0 {
+		return 1
+	}
+
+	matcher := newRatcliffObershelpMatcher(inputStringRunes, targetStringRunes)
+	return 2 * float32(matcher.matchingRunes()) / float32(totalLength)
+}
+
+// Finds the matching blocks of two rune slices the same way as difflib's SequenceMatcher
+type ratcliffObershelpMatcher struct {
+	input          []rune
+	target         []rune
+	targetIndexes  map[rune][]int // The indexes of each rune in target, excluding popular runes
+	previousRun    []int          // The length of the run ending at each target index (offset by 1) for the previous input rune
+	currentRun     []int          // The length of the run ending at each target index (offset by 1) for the current input rune
+	previousWrites []int          // The indexes written to previousRun, so it can be cleared cheaply
+	currentWrites  []int          // The indexes written to currentRun, so it can be cleared cheaply
+}
+
+// Creates a matcher, indexing the target runes
+func newRatcliffObershelpMatcher(input, target []rune) *ratcliffObershelpMatcher {
+	targetIndexes := make(map[rune][]int)
+	for j, currentRune := range target {
+		targetIndexes[currentRune] = append(targetIndexes[currentRune], j)
+	}
+
+	// Popular runes in long targets are junk, the same as difflib's autojunk heuristic
+	if len(target) >= 200 {
+		popularCount := len(target)/100 + 1
+		for currentRune, indexes := range targetIndexes {
+			if len(indexes) > popularCount {
+				delete(targetIndexes, currentRune)
+			}
+		}
+	}
+
+	return &ratcliffObershelpMatcher{
+		input:         input,
+		target:        target,
+		targetIndexes: targetIndexes,
+		previousRun:   make([]int, len(target)+1),
+		currentRun:    make([]int, len(target)+1),
+	}
+}
+
+// Finds the longest matching block in input[inputLow:inputHigh] and target[targetLow:targetHigh]
+//
+// # Notes
+//  - Ties are broken by the block that starts earliest in input, then earliest in target
+//
+// # Returns
+//  int: The start of the block in input
+//  int: The start of the block in target
+//  int: The length of the block, 0 if there are no matches
+func (matcher *ratcliffObershelpMatcher) longestMatch(inputLow, inputHigh, targetLow, targetHigh int) (int, int, int) {
+	bestInput, bestTarget, bestSize := inputLow, targetLow, 0
+
+	for i := inputLow; i < inputHigh; i++ {
+		for _, j := range matcher.targetIndexes[matcher.input[i]] {
+			if j < targetLow {
+				continue
+			}
+			if j >= targetHigh {
+				break
+			}
+			size := matcher.previousRun[j] + 1
+			matcher.currentRun[j+1] = size
+			matcher.currentWrites = append(matcher.currentWrites, j+1)
+			if size > bestSize {
+				bestInput, bestTarget, bestSize = i-size+1, j-size+1, size
+			}
+		}
+
+		// Clear the previous runs, and make the current runs the previous ones
+		for _, j := range matcher.previousWrites {
+			matcher.previousRun[j] = 0
+		}
+		matcher.previousRun, matcher.currentRun = matcher.currentRun, matcher.previousRun
+		matcher.previousWrites, matcher.currentWrites = matcher.currentWrites, matcher.previousWrites[:0]
+	}
+	for _, j := range matcher.previousWrites {
+		matcher.previousRun[j] = 0
+	}
+	matcher.previousWrites = matcher.previousWrites[:0]
+
+	// Popular runes can't start a block, but they can still extend one
+	for bestInput > inputLow && bestTarget > targetLow && matcher.input[bestInput-1] == matcher.target[bestTarget-1] {
+		bestInput, bestTarget, bestSize = bestInput-1, bestTarget-1, bestSize+1
+	}
+	for bestInput+bestSize < inputHigh && bestTarget+bestSize < targetHigh && matcher.input[bestInput+bestSize] == matcher.target[bestTarget+bestSize] {
+		bestSize += 1
+	}
+
+	return bestInput, bestTarget, bestSize
+}
+
+// Counts the runes in all the matching blocks of input and target
+func (matcher *ratcliffObershelpMatcher) matchingRunes() int {
+	type region struct {
+		inputLow, inputHigh, targetLow, targetHigh int
+	}
+
+	matches := 0
+	regions := []region{{0, len(matcher.input), 0, len(matcher.target)}}
+	for len(regions) > 0 {
+		current := regions[len(regions)-1]
+		regions = regions[:len(regions)-1]
+
+		i, j, size := matcher.longestMatch(current.inputLow, current.inputHigh, current.targetLow, current.targetHigh)
+		if size == 0 {
+			continue
+		}
+		matches += size
+
+		// Recurse on the unmatched regions to the left and right of the block
+		if current.inputLow < i && current.targetLow < j {
+			regions = append(regions, region{current.inputLow, i, current.targetLow, j})
+		}
+		if i+size < current.inputHigh && j+size < current.targetHigh {
+			regions = append(regions, region{i + size, current.inputHigh, j + size, current.targetHigh})
+		}
+	}
+
+	return matches
+}
+
+// Calculates an upper bound on the Ratcliff/Obershelp similarity of two strings using character counts
+//
+// # Notes
+//  - Equivalent to difflib's SequenceMatcher.quick_ratio(), but operates on runes
+//  - Counts every rune the strings have in common regardless of order, so it never underestimates the real ratio
+//  - Runs in O(m+n) time
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The upper bound on the similarity (between 0-1, closer to 1 is more similar)
+func QuickRatio(inputString, targetString string) float32 {
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+
+	totalLength := len(inputStringRunes) + len(targetStringRunes)
+	if totalLength == 0 {
+		return 1
+	}
+
+	// Count the runes available in the target string
+	available := make(map[rune]int, len(targetStringRunes))
+	for _, currentRune := range targetStringRunes {
+		available[currentRune] += 1
+	}
+
+	// Consume a matching rune from the target for each rune in the input
+	matches := 0
+	for _, currentRune := range inputStringRunes {
+		if available[currentRune] > 0 {
+			available[currentRune] -= 1
+			matches += 1
+		}
+	}
+
+	return 2 * float32(matches) / float32(totalLength)
+}
+
+// Calculates an upper bound on the Ratcliff/Obershelp similarity of two strings using only their lengths
+//
+// # Notes
+//  - Equivalent to difflib's SequenceMatcher.real_quick_ratio(), but operates on runes
+//  - Never underestimates QuickRatio, and only needs to count the runes in each string
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The upper bound on the similarity (between 0-1, closer to 1 is more similar)
+func RealQuickRatio(inputString, targetString string) float32 {
+	inputStringLength := utf8.RuneCountInString(inputString)
+	targetStringLength := utf8.RuneCountInString(targetString)
+
+	totalLength := inputStringLength + targetStringLength
+	if totalLength == 0 {
+		return 1
+	}
+
+	return 2 * float32(min(inputStringLength, targetStringLength)) / float32(totalLength)
+}
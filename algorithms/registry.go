@@ -0,0 +1,199 @@
+package algorithms
+
+// This file implements registries of similarity and distance algorithms, so they can be looked up by name (i.e. from a configuration file)
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+)
+
+var (
+	registryLock sync.RWMutex
+	registry     = map[string]SimilarityAlgorithm{
+		"affine_gap":               AffineGapSimilarity,
+		"case_aware_levenshtein":   CaseAwareLevenshteinSimilarity,
+		"caverphone":               CaverphoneSimilarity,
+		"cosine_bigram":            CosineBigramSimilarity,
+		"damerau_levenshtein":      DamerauLevenshteinSimilarity,
+		"dice_bigram":              DiceBigramSimilarity,
+		"double_metaphone":         DoubleMetaphoneSimilarity,
+		"grapheme_levenshtein":     GraphemeLevenshteinSimilarity,
+		"hamming":                  HammingSimilarity,
+		"hybrid":                   HybridSimilarity,
+		"indel":                    IndelSimilarity,
+		"jaccard_bigram":           JaccardBigramSimilarity,
+		"jaccard_trigram":          JaccardTrigramSimilarity,
+		"jaro":                     JaroSimilarity,
+		"jaro_winkler":             JaroWinklerSimilarity,
+		"keyboard":                 KeyboardSimilarity,
+		"kondrak_ngram":            KondrakNGramSimilarity,
+		"lcs":                      LCSSimilarity,
+		"levenshtein":              LevenshteinSimilarity,
+		"longest_common_substring": LongestCommonSubstringSimilarity,
+		"metaphone":                MetaphoneSimilarity,
+		"monge_elkan":              NewMongeElkanSimilarity(JaroSimilarity, true),
+		"mra":                      MRASimilarity,
+		"numeric_aware":            NumericAwareSimilarity,
+		"nysiis":                   NYSIISSimilarity,
+		"ocr":                      OCRSimilarity,
+		"optimal_string_alignment": OptimalStringAlignmentSimilarity,
+		"partial_ratio":            PartialRatioSimilarity,
+		"phoneme":                  PhonemeSimilarity,
+		"prefix":                   PrefixSimilarity,
+		"ratcliff_obershelp":       RatcliffObershelpSimilarity,
+		"refined_soundex":          RefinedSoundexSimilarity,
+		"sift3":                    Sift3Similarity,
+		"sift4":                    Sift4Similarity,
+		"smith_waterman":           SmithWatermanSimilarity,
+		"soundex":                  SoundexSimilarity,
+		"ssk":                      NewSSKSimilarity(DefaultSSKLength, DefaultSSKLambda),
+		"suffix":                   SuffixSimilarity,
+		"trigram":                  TrigramSimilarity,
+		"weighted_ratio":           WeightedRatio,
+	}
+
+	distanceRegistry = map[string]DistanceAlgorithm{
+		"damerau_levenshtein":  DamerauLevenshtein,
+		"grapheme_levenshtein": GraphemeLevenshteinDistance,
+		"indel":                IndelDistance,
+		"lcs":                  LCSDistance,
+		"levenshtein":          LevenshteinDistance,
+	}
+)
+
+// Registers a similarity algorithm under a name, so it can be found with GetAlgorithm()
+//
+// # Notes
+//  - Registering a name that's already used replaces the algorithm, including the built-in ones
+//  - Names are case sensitive, the built-in ones are lowercase with underscores (i.e. "jaro_winkler")
+//  - Panics if algorithm is nil, since it's a mistake in the calling code
+//  - Safe to call from multiple goroutines, but is usually called from an init() function
+//
+// # Parameters
+//  name (string): The name to register the algorithm under
+//  algorithm (SimilarityAlgorithm): The algorithm
+func RegisterAlgorithm(name string, algorithm SimilarityAlgorithm) {
+	if algorithm == nil {
+		panic("algorithms: RegisterAlgorithm called with a nil algorithm for " + name)
+	}
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	registry[name] = algorithm
+}
+
+// Gets a registered similarity algorithm by it's name
+//
+// # Notes
+//  - Every SimilarityAlgorithm in this package is registered, see ListAlgorithms() for their names
+//  - Safe to call from multiple goroutines
+//
+// # Parameters
+//  name (string): The name the algorithm was registered under (i.e. "levenshtein")
+//
+// # Returns
+//  SimilarityAlgorithm: The algorithm, nil if there isn't one with that name
+//  bool: True if an algorithm was registered with that name
+func GetAlgorithm(name string) (SimilarityAlgorithm, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	algorithm, exists := registry[name]
+	return algorithm, exists
+}
+
+// Lists the names of every registered similarity algorithm
+//
+// # Returns
+//  []string: The names in sorted order
+func ListAlgorithms() []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Registers a distance algorithm under a name, so it can be found with GetDistance() and DistanceName()
+//
+// # Notes
+//  - Distances have their own names, separate from the similarity algorithms, so "levenshtein" can be both
+//  - Registering a name that's already used replaces the algorithm, including the built-in ones
+//  - Panics if algorithm is nil, since it's a mistake in the calling code
+//  - Safe to call from multiple goroutines, but is usually called from an init() function
+//
+// # Parameters
+//  name (string): The name to register the algorithm under
+//  algorithm (DistanceAlgorithm): The algorithm
+func RegisterDistance(name string, algorithm DistanceAlgorithm) {
+	if algorithm == nil {
+		panic("algorithms: RegisterDistance called with a nil algorithm for " + name)
+	}
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	distanceRegistry[name] = algorithm
+}
+
+// Gets a registered distance algorithm by it's name
+//
+// # Notes
+//  - The built-in distances are all true metrics, so they can be used to build an index.BKTree, see ListDistances() for their names
+//  - Safe to call from multiple goroutines
+//
+// # Parameters
+//  name (string): The name the algorithm was registered under (i.e. "levenshtein")
+//
+// # Returns
+//  DistanceAlgorithm: The algorithm, nil if there isn't one with that name
+//  bool: True if an algorithm was registered with that name
+func GetDistance(name string) (DistanceAlgorithm, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	algorithm, exists := distanceRegistry[name]
+	return algorithm, exists
+}
+
+// Lists the names of every registered distance algorithm
+//
+// # Returns
+//  []string: The names in sorted order
+func ListDistances() []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	names := make([]string, 0, len(distanceRegistry))
+	for name := range distanceRegistry {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Finds the name a distance algorithm was registered under
+//
+// # Notes
+//  - Functions can't be compared in Go, so they're matched by their code, which means closures made by the same function literal can't be told apart
+//  - Register named functions (or one closure per function literal) if their names need to be found
+//  - Only finds the exact function that was registered, i.e. DynamicLevenshtein isn't found even though it calculates the same distance as LevenshteinDistance
+//  - If the same function is registered under several names, the first name in sorted order is returned
+//
+// # Parameters
+//  algorithm (DistanceAlgorithm): The algorithm to find the name of
+//
+// # Returns
+//  string: The name the algorithm was registered under, empty if it isn't registered
+//  bool: True if the algorithm is registered
+func DistanceName(algorithm DistanceAlgorithm) (string, bool) {
+	if algorithm == nil {
+		return "", false
+	}
+	pointer := reflect.ValueOf(algorithm).Pointer()
+	for _, name := range ListDistances() {
+		registered, _ := GetDistance(name)
+		if reflect.ValueOf(registered).Pointer() == pointer {
+			return name, true
+		}
+	}
+	return "", false
+}
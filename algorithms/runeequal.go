@@ -0,0 +1,75 @@
+package algorithms
+
+// This file implements rune equality functions, which let distance algorithms treat different runes as the same
+//
+// # References
+//  - https://pkg.go.dev/unicode#SimpleFold
+//  - https://en.wikipedia.org/wiki/Equivalence_relation
+
+import "unicode"
+
+// Reports whether two runes should be treated as the same by a distance algorithm (i.e. LevenshteinDistanceFunc)
+type RuneEqualFunc func(inputRune, targetRune rune) bool
+
+// Treats different cases of the same letter as equal (i.e. 'a' and 'A', or 'Σ', 'σ' and 'ς')
+//
+// # Notes
+//  - Uses unicode.SimpleFold(), so only single rune case pairs are equal ('ß' and 'S' aren't)
+//
+// # Parameters
+//  inputRune (rune): The first rune to compare
+//  targetRune (rune): The second rune to compare
+//
+// # Returns
+//  bool: True if the runes are the same letter, ignoring case
+func FoldCase(inputRune, targetRune rune) bool {
+	return inputRune == targetRune || isCaseVariant(inputRune, targetRune)
+}
+
+// Treats every digit as equal (i.e. '1' and '7'), and everything else as equal only to itself
+//
+// # Notes
+//  - Uses unicode.IsDigit(), so digits from other scripts (i.e. '٣') are equal to '3' as well
+//
+// # Parameters
+//  inputRune (rune): The first rune to compare
+//  targetRune (rune): The second rune to compare
+//
+// # Returns
+//  bool: True if the runes are the same, or both digits
+func DigitsEqual(inputRune, targetRune rune) bool {
+	return inputRune == targetRune || (unicode.IsDigit(inputRune) && unicode.IsDigit(targetRune))
+}
+
+// Treats every whitespace rune as equal (i.e. ' ' and '\t'), and everything else as equal only to itself
+//
+// # Parameters
+//  inputRune (rune): The first rune to compare
+//  targetRune (rune): The second rune to compare
+//
+// # Returns
+//  bool: True if the runes are the same, or both whitespace
+func WhitespaceEqual(inputRune, targetRune rune) bool {
+	return inputRune == targetRune || (unicode.IsSpace(inputRune) && unicode.IsSpace(targetRune))
+}
+
+// Combines rune equality functions, so runes are equal if any of them say they are
+//
+// # Parameters
+//  equalFuncs (...RuneEqualFunc): The functions to combine, nil functions are skipped
+//
+// # Returns
+//  RuneEqualFunc: A function that treats runes as equal if they're the same, or any of equalFuncs returns true
+func AnyRuneEqual(equalFuncs ...RuneEqualFunc) RuneEqualFunc {
+	return func(inputRune, targetRune rune) bool {
+		if inputRune == targetRune {
+			return true
+		}
+		for _, equal := range equalFuncs {
+			if equal != nil && equal(inputRune, targetRune) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,79 @@
+package algorithms
+
+// This file implements SIFT3, a very fast but rough approximation of the edit distance of two strings
+//
+// # References
+//  - https://siderite.dev/blog/super-fast-and-accurate-string-distance.html
+
+// The default number of runes SIFT3 looks ahead to find a match after a mismatch
+const DefaultSift3MaxOffset = 5
+
+// Calculates the SIFT3 distance of two strings, a rough approximation of the edit distance that runs in linear time
+//
+// # Notes
+//  - Walks both strings at once, and after a mismatch looks up to maxOffset runes ahead in each string to line them back up
+//  - The distance is the average length of the strings minus the runes in common, rounded up so different strings never have a distance of 0
+//  - Doesn't handle transpositions and is less accurate than Sift4Distance, but does less work, so it's meant for cutting down a large set of candidates before running a slower algorithm on what's left
+//  - Operates on runes
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  maxOffset (int): How far ahead to look for a match after a mismatch, DefaultSift3MaxOffset is used if it's <= 0
+//
+// # Returns
+//  int: The approximate edit distance
+func Sift3Distance(inputString, targetString string, maxOffset int) int {
+	if maxOffset <= 0 {
+		maxOffset = DefaultSift3MaxOffset
+	}
+
+	// Convert to runes to avoid weird encoding issues
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+	inputStringLength := len(inputStringRunes)
+	targetStringLength := len(targetStringRunes)
+	if inputStringLength == 0 || targetStringLength == 0 {
+		return max(inputStringLength, targetStringLength)
+	}
+
+	cursor := 0
+	inputOffset, targetOffset := 0, 0
+	commonLength := 0
+	for cursor+inputOffset < inputStringLength && cursor+targetOffset < targetStringLength {
+		if inputStringRunes[cursor+inputOffset] == targetStringRunes[cursor+targetOffset] {
+			commonLength += 1
+		} else {
+			// Look ahead in both strings for the next match
+			inputOffset, targetOffset = 0, 0
+			for i := 0; i < maxOffset; i++ {
+				if cursor+i < inputStringLength && inputStringRunes[cursor+i] == targetStringRunes[cursor] {
+					inputOffset = i
+					break
+				}
+				if cursor+i < targetStringLength && inputStringRunes[cursor] == targetStringRunes[cursor+i] {
+					targetOffset = i
+					break
+				}
+			}
+		}
+		cursor += 1
+	}
+
+	return (inputStringLength+targetStringLength+1)/2 - commonLength
+}
+
+// Calculates the SIFT3 similarity of two strings, using DefaultSift3MaxOffset
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func Sift3Similarity(inputString, targetString string) float32 {
+	similarity := CalculateSimilarity(inputString, targetString, func(inputString, targetString string) int {
+		return Sift3Distance(inputString, targetString, DefaultSift3MaxOffset)
+	})
+	return similarity
+}
@@ -0,0 +1,142 @@
+package algorithms
+
+// This file implements SIFT4, a fast approximation of the edit distance of two strings
+//
+// # References
+//  - https://siderite.dev/blog/super-fast-and-accurate-string-distance.html
+//  - https://github.com/tdebatty/java-string-similarity#sift4
+
+// The default number of runes SIFT4 looks ahead to find a match after a mismatch
+const DefaultSift4MaxOffset = 5
+
+// A match SIFT4 has already found, to check later matches against for transpositions
+type sift4Offset struct {
+	inputPosition  int
+	targetPosition int
+	transposition  bool
+}
+
+// Calculates the SIFT4 distance of two strings, an approximation of the Levenshtein distance that runs in roughly linear time
+//
+// # Notes
+//  - Walks both strings at once, and after a mismatch looks up to maxOffset runes ahead in each string for the next match
+//  - Counts the runes in common, minus transpositions, so the distance is max(m, n) - common + transpositions
+//  - Usually the same as LevenshteinDistance for strings with a few typos, but can be further off when the strings are very different, or when an edit is longer than maxOffset
+//  - Runs in O((m+n)*maxOffset) time instead of O(m*n), so it's much faster on long strings (i.e. descriptions or log lines)
+//  - Uses the "common" version of SIFT4, and operates on runes
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  maxOffset (int): How far ahead to look for a match after a mismatch, DefaultSift4MaxOffset is used if it's <= 0
+//
+// # Returns
+//  int: The approximate edit distance
+func Sift4Distance(inputString, targetString string, maxOffset int) int {
+	if maxOffset <= 0 {
+		maxOffset = DefaultSift4MaxOffset
+	}
+
+	// Convert to runes to avoid weird encoding issues
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+	inputStringLength := len(inputStringRunes)
+	targetStringLength := len(targetStringRunes)
+	if inputStringLength == 0 || targetStringLength == 0 {
+		return max(inputStringLength, targetStringLength)
+	}
+
+	inputCursor, targetCursor := 0, 0
+	commonLength := 0      // The runes in common found before the current run of matches
+	localCommonLength := 0 // The runes in common in the current run of matches
+	transpositions := 0
+	var offsets []sift4Offset
+
+	for inputCursor < inputStringLength && targetCursor < targetStringLength {
+		if inputStringRunes[inputCursor] == targetStringRunes[targetCursor] {
+			localCommonLength += 1
+
+			// Check if this match crosses one that was already found, which makes one of them a transposition
+			isTransposition := false
+			for i := 0; i < len(offsets); {
+				offset := &offsets[i]
+				if inputCursor <= offset.inputPosition || targetCursor <= offset.targetPosition {
+					isTransposition = abs(targetCursor-inputCursor) >= abs(offset.targetPosition-offset.inputPosition)
+					if isTransposition {
+						transpositions += 1
+					} else if !offset.transposition {
+						offset.transposition = true
+						transpositions += 1
+					}
+					break
+				}
+
+				// Matches that both cursors are past can't be crossed anymore
+				if inputCursor > offset.targetPosition && targetCursor > offset.inputPosition {
+					offsets = append(offsets[:i], offsets[i+1:]...)
+				} else {
+					i += 1
+				}
+			}
+			offsets = append(offsets, sift4Offset{inputCursor, targetCursor, isTransposition})
+		} else {
+			commonLength += localCommonLength
+			localCommonLength = 0
+			if inputCursor != targetCursor {
+				inputCursor = min(inputCursor, targetCursor)
+				targetCursor = inputCursor
+			}
+
+			// Look ahead in both strings for the next match, the cursors are moved one less since they're incremented below
+			for i := 0; i < maxOffset && (inputCursor+i < inputStringLength || targetCursor+i < targetStringLength); i++ {
+				if inputCursor+i < inputStringLength && inputStringRunes[inputCursor+i] == targetStringRunes[targetCursor] {
+					inputCursor += i - 1
+					targetCursor -= 1
+					break
+				}
+				if targetCursor+i < targetStringLength && inputStringRunes[inputCursor] == targetStringRunes[targetCursor+i] {
+					inputCursor -= 1
+					targetCursor += i - 1
+					break
+				}
+			}
+		}
+
+		inputCursor += 1
+		targetCursor += 1
+
+		// Reached the end of one string, so line the cursors back up in case there's more to match
+		if inputCursor >= inputStringLength || targetCursor >= targetStringLength {
+			commonLength += localCommonLength
+			localCommonLength = 0
+			inputCursor = min(inputCursor, targetCursor)
+			targetCursor = inputCursor
+		}
+	}
+	commonLength += localCommonLength
+
+	return max(inputStringLength, targetStringLength) - commonLength + transpositions
+}
+
+// Calculates the SIFT4 similarity of two strings, using DefaultSift4MaxOffset
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func Sift4Similarity(inputString, targetString string) float32 {
+	similarity := CalculateSimilarity(inputString, targetString, func(inputString, targetString string) int {
+		return Sift4Distance(inputString, targetString, DefaultSift4MaxOffset)
+	})
+	return similarity
+}
+
+// The absolute value of an int
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
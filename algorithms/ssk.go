@@ -0,0 +1,139 @@
+package algorithms
+
+// This file implements the string subsequence kernel, which compares strings by the (possibly non-contiguous) subsequences they share
+//
+// # References
+//  - https://www.jmlr.org/papers/volume2/lodhi02a/lodhi02a.pdf
+//  - https://en.wikipedia.org/wiki/String_kernel
+
+import "math"
+
+const (
+	// The default length of the subsequences compared by the string subsequence kernel
+	DefaultSSKLength = 2
+
+	// The default decay applied to each rune a subsequence spans, so subsequences with fewer gaps count for more
+	DefaultSSKLambda = 0.5
+
+	// The most runes of each string the string subsequence kernel looks at, since it takes O(k*m*n) time and O(m*n) memory
+	SSKMaxLength = 256
+)
+
+// Calculates the string subsequence kernel similarity of two strings
+//
+// # Notes
+//  - Every subsequence of k runes the strings share adds lambda^(runes it spans) for each place it appears in each string,
+//     so "ab" matches "a_b" with a lower weight than "ab", but still matches where n-gram methods would see nothing in common
+//  - Normalized by the kernel of each string with itself, so identical strings score 1
+//  - Strings with fewer than k runes have no subsequences to compare, so they score 0 unless they're identical
+//  - Only the first SSKMaxLength runes of each string are compared, to bound the O(k*m*n) running time
+//  - Operates on runes
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  k (int): The length of the subsequences to compare, values < 1 are treated as 1
+//  lambda (float32): The decay for each rune a subsequence spans, between 0-1 (exclusive of 0), closer to 0 penalizes gaps more
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func SSKSimilarity(inputString, targetString string, k int, lambda float32) float32 {
+	if lambda <= 0 || lambda > 1 {
+		panic("algorithms: SSKSimilarity lambda must be between 0 and 1")
+	}
+	if inputString == targetString {
+		return 1
+	}
+	if len(inputString) == 0 || len(targetString) == 0 {
+		return 0
+	}
+
+	k = max(k, 1)
+	inputRunes := []rune(inputString)
+	targetRunes := []rune(targetString)
+	inputRunes = inputRunes[:min(len(inputRunes), SSKMaxLength)]
+	targetRunes = targetRunes[:min(len(targetRunes), SSKMaxLength)]
+
+	decay := float64(lambda)
+	inputKernel := subsequenceKernel(inputRunes, inputRunes, k, decay)
+	targetKernel := subsequenceKernel(targetRunes, targetRunes, k, decay)
+	if inputKernel == 0 || targetKernel == 0 {
+		return 0
+	}
+
+	similarity := subsequenceKernel(inputRunes, targetRunes, k, decay) / math.Sqrt(inputKernel*targetKernel)
+	return float32(min(similarity, 1))
+}
+
+// Creates a SimilarityAlgorithm that calculates the string subsequence kernel similarity with fixed parameters
+//
+// # Notes
+//  - Panics if lambda isn't between 0-1 (exclusive of 0)
+//
+// # Parameters
+//  k (int): The length of the subsequences to compare, values < 1 are treated as 1
+//  lambda (float32): The decay for each rune a subsequence spans, between 0-1 (exclusive of 0), closer to 0 penalizes gaps more
+//
+// # Returns
+//  SimilarityAlgorithm: The algorithm, which can be passed to SuggestWord()
+func NewSSKSimilarity(k int, lambda float32) SimilarityAlgorithm {
+	if lambda <= 0 || lambda > 1 {
+		panic("algorithms: NewSSKSimilarity lambda must be between 0 and 1")
+	}
+	return func(inputString, targetString string) float32 {
+		return SSKSimilarity(inputString, targetString, k, lambda)
+	}
+}
+
+// Calculates the unnormalized subsequence kernel of two rune slices with the recursion from Lodhi et al.
+//
+// # Notes
+//  - partial[i][j] holds K'_l of the first i and j runes, the weight of the shared subsequences of length l
+//     measured from their first rune to the end of each prefix, which only needs the previous length's table
+//
+// # Parameters
+//  first ([]rune): The first string
+//  second ([]rune): The second string
+//  k (int): The length of the subsequences to compare
+//  lambda (float64): The decay for each rune a subsequence spans
+//
+// # Returns
+//  float64: The kernel value
+func subsequenceKernel(first, second []rune, k int, lambda float64) float64 {
+	firstLength, secondLength := len(first), len(second)
+	if firstLength < k || secondLength < k {
+		return 0
+	}
+
+	width := secondLength + 1
+	previous := make([]float64, (firstLength+1)*width)
+	current := make([]float64, (firstLength+1)*width)
+	for index := range previous {
+		previous[index] = 1
+	}
+
+	for length := 1; length < k; length++ {
+		clear(current)
+		for i := length; i <= firstLength; i++ {
+			running := 0.0 // K''_l, the weight of the subsequences ending exactly on a match with first[i-1]
+			for j := length; j <= secondLength; j++ {
+				running *= lambda
+				if first[i-1] == second[j-1] {
+					running += lambda * lambda * previous[(i-1)*width+j-1]
+				}
+				current[i*width+j] = lambda*current[(i-1)*width+j] + running
+			}
+		}
+		previous, current = current, previous
+	}
+
+	kernel := 0.0
+	for i := k; i <= firstLength; i++ {
+		for j := k; j <= secondLength; j++ {
+			if first[i-1] == second[j-1] {
+				kernel += lambda * lambda * previous[(i-1)*width+j-1]
+			}
+		}
+	}
+	return kernel
+}
@@ -0,0 +1,89 @@
+package algorithms
+
+// This file implements the longest common substring of two strings, unlike a subsequence the shared runes have to be contiguous
+//
+// # References
+//  - https://en.wikipedia.org/wiki/Longest_common_substring
+
+// Calculates the longest common substring similarity of two strings
+//
+// # Notes
+//  - Normalized by the length of the longer string, so "abc" and "abcdef" have a similarity of 0.5
+//  - Useful for product names, URLs and file paths, where the shared part has to be unbroken
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func LongestCommonSubstringSimilarity(inputString, targetString string) float32 {
+	if inputString == targetString {
+		return 1
+	}
+
+	longestLength := max(len([]rune(inputString)), len([]rune(targetString)))
+	return float32(LongestCommonSubstringLength(inputString, targetString)) / float32(longestLength)
+}
+
+// Calculates the length of the longest common substring of two strings
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  int: The number of runes in the longest common substring
+func LongestCommonSubstringLength(inputString, targetString string) int {
+	_, length := longestCommonSubstring([]rune(inputString), []rune(targetString))
+	return length
+}
+
+// Finds the longest common substring of two strings
+//
+// # Notes
+//  - When there are several substrings with the longest length, the one that ends first in inputString is returned
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  string: The longest common substring, will be a blank string if the strings have nothing in common
+func LongestCommonSubstring(inputString, targetString string) string {
+	inputStringRunes := []rune(inputString)
+	end, length := longestCommonSubstring(inputStringRunes, []rune(targetString))
+	return string(inputStringRunes[end-length : end])
+}
+
+// Finds the longest common substring of two rune slices using the suffix matrix
+//
+// # Notes
+//  - Each cell holds the length of the longest common suffix of the prefixes, and the longest substring is the largest cell
+//  - Runs in O(m*n) time and uses O(m*n) memory, each row only depends on the one before it so this could be reduced to O(n)
+//
+// # Returns
+//  int: The rune index the substring ends at (exclusive) in input
+//  int: The length of the substring
+func longestCommonSubstring(input, target []rune) (int, int) {
+	matrix := make([][]int, len(input)+1)
+	for i := range matrix {
+		matrix[i] = make([]int, len(target)+1)
+	}
+
+	bestEnd, bestLength := 0, 0
+	for i := 1; i <= len(input); i++ {
+		for j := 1; j <= len(target); j++ {
+			if input[i-1] != target[j-1] {
+				continue
+			}
+			// Extend the common suffix of the previous prefixes
+			matrix[i][j] = matrix[i-1][j-1] + 1
+			if matrix[i][j] > bestLength {
+				bestEnd, bestLength = i, matrix[i][j]
+			}
+		}
+	}
+
+	return bestEnd, bestLength
+}
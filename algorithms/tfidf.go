@@ -0,0 +1,119 @@
+package algorithms
+
+// This file implements the cosine similarity of TF-IDF weighted tokens, so rare words count for more than common ones when comparing multi-word strings
+//
+// # References
+//  - https://en.wikipedia.org/wiki/Tf%E2%80%93idf
+//  - https://en.wikipedia.org/wiki/Cosine_similarity
+//  - https://scikit-learn.org/stable/modules/feature_extraction.html#tfidf-term-weighting
+
+import (
+	"math"
+	"strings"
+	"sync"
+)
+
+// Compares multi-word strings by the cosine similarity of their tokens, weighted by how rare each token is in a corpus
+//
+// # Notes
+//  - Tokens are split on whitespace and compared as is, so normalize the corpus the same way as the strings being compared (i.e. lowercase both)
+//  - Each token is weighted by the number of times it appears in the string (TF), times its smoothed inverse document frequency ln((1+N)/(1+df)) + 1 (IDF)
+//  - Tokens that aren't in the corpus have a document frequency of 0, so they get the highest weight instead of being ignored
+//  - Safe to use from multiple goroutines, including while Update() is called
+type TFIDFScorer struct {
+	lock                sync.RWMutex
+	documentFrequencies map[string]int // The number of documents each token appears in
+	documents           int
+}
+
+// Creates a TF-IDF scorer from a corpus of documents
+//
+// # Parameters
+//  corpus ([]string): The documents to calculate the document frequencies from, one string per document (i.e. "university of calgary")
+//
+// # Returns
+//  *TFIDFScorer: The scorer
+func NewTFIDFScorer(corpus []string) *TFIDFScorer {
+	scorer := &TFIDFScorer{}
+	scorer.Update(corpus)
+	return scorer
+}
+
+// Rebuilds the document frequencies from a new corpus, replacing the old one
+//
+// # Parameters
+//  corpus ([]string): The documents to calculate the document frequencies from, one string per document
+func (scorer *TFIDFScorer) Update(corpus []string) {
+	documentFrequencies := make(map[string]int)
+	for _, document := range corpus {
+		seen := make(map[string]bool)
+		for _, token := range strings.Fields(document) {
+			if !seen[token] {
+				seen[token] = true
+				documentFrequencies[token] += 1
+			}
+		}
+	}
+
+	scorer.lock.Lock()
+	defer scorer.lock.Unlock()
+	scorer.documentFrequencies = documentFrequencies
+	scorer.documents = len(corpus)
+}
+
+// Calculates the cosine similarity of the TF-IDF weighted tokens of two strings
+//
+// # Notes
+//  - Shares the signature of a SimilarityAlgorithm, so it can be passed as one (i.e. SuggestWordWithSpecificAlgorithm(word, validWords, scorer.Similarity))
+//  - Only whole tokens are compared, so typos inside a token count as a different token
+//  - Two strings without tokens have a similarity of 1, and a string without tokens against one with tokens has a similarity of 0
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func (scorer *TFIDFScorer) Similarity(inputString, targetString string) float32 {
+	inputCounts := countTokens(inputString)
+	targetCounts := countTokens(targetString)
+	if len(inputCounts) == 0 && len(targetCounts) == 0 {
+		return 1
+	}
+	if len(inputCounts) == 0 || len(targetCounts) == 0 {
+		return 0
+	}
+
+	scorer.lock.RLock()
+	defer scorer.lock.RUnlock()
+
+	var dotProduct, inputMagnitude, targetMagnitude float64
+	for token, count := range inputCounts {
+		weight := float64(count) * scorer.inverseDocumentFrequency(token)
+		inputMagnitude += weight * weight
+		if targetCount, exists := targetCounts[token]; exists {
+			dotProduct += weight * float64(targetCount) * scorer.inverseDocumentFrequency(token)
+		}
+	}
+	for token, count := range targetCounts {
+		weight := float64(count) * scorer.inverseDocumentFrequency(token)
+		targetMagnitude += weight * weight
+	}
+
+	// Rounding can push identical vectors slightly over 1
+	return float32(min(1, dotProduct/math.Sqrt(inputMagnitude*targetMagnitude)))
+}
+
+// The smoothed inverse document frequency of a token, the caller must hold the lock
+func (scorer *TFIDFScorer) inverseDocumentFrequency(token string) float64 {
+	return math.Log(float64(1+scorer.documents)/float64(1+scorer.documentFrequencies[token])) + 1
+}
+
+// Counts the number of times each whitespace separated token appears in a string
+func countTokens(inputString string) map[string]int {
+	counts := make(map[string]int)
+	for _, token := range strings.Fields(inputString) {
+		counts[token] += 1
+	}
+	return counts
+}
@@ -0,0 +1,208 @@
+package algorithms
+
+// This file implements token based similarities, which compare the words in two strings regardless of their order,
+// and the weighted ratio that combines them with the other ratios
+//
+// # References
+//  - https://github.com/seatgeek/fuzzywuzzy#token-sort-ratio
+//  - https://rapidfuzz.github.io/RapidFuzz/Usage/fuzz.html#wratio
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// The weights used by a weighted ratio to combine its similarities
+type WeightedRatioWeights struct {
+	TokenScale             float32 // How much the token sort and token set similarities are scaled by
+	PartialScale           float32 // How much the partial similarities are scaled by when the strings have different lengths
+	LongPartialScale       float32 // How much the partial similarities are scaled by when the strings have very different lengths
+	PartialLengthRatio     float32 // The ratio of the lengths at which the partial similarities are used
+	LongPartialLengthRatio float32 // The ratio of the lengths at which LongPartialScale is used instead of PartialScale
+}
+
+// The default weights for a weighted ratio, these are the same as RapidFuzz's WRatio
+var DefaultWeightedRatioWeights = WeightedRatioWeights{
+	TokenScale:             0.95,
+	PartialScale:           0.9,
+	LongPartialScale:       0.6,
+	PartialLengthRatio:     1.5,
+	LongPartialLengthRatio: 8,
+}
+
+// Calculates the weighted ratio of two strings using DefaultWeightedRatioWeights
+//
+// # Notes
+//  - Combines the Indel similarity, partial ratio, token sort and token set similarities, and returns the best scaled one
+//  - Strings of similar lengths lean on the Indel and token similarities, and strings of different lengths lean on the partial ratios
+//  - Equivalent to RapidFuzz's WRatio (without any preprocessing) divided by 100
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func WeightedRatio(inputString, targetString string) float32 {
+	return weightedRatio(inputString, targetString, DefaultWeightedRatioWeights)
+}
+
+// Creates a weighted ratio algorithm with custom weights
+//
+// # Parameters
+//  weights (WeightedRatioWeights): The weights used to combine the similarities
+//
+// # Returns
+//  SimilarityAlgorithm: The weighted ratio algorithm
+func NewWeightedRatio(weights WeightedRatioWeights) SimilarityAlgorithm {
+	return func(inputString, targetString string) float32 {
+		return weightedRatio(inputString, targetString, weights)
+	}
+}
+
+// Calculates the weighted ratio of two strings
+func weightedRatio(inputString, targetString string, weights WeightedRatioWeights) float32 {
+	if inputString == targetString {
+		return 1
+	}
+
+	inputStringLength := utf8.RuneCountInString(inputString)
+	targetStringLength := utf8.RuneCountInString(targetString)
+	if inputStringLength == 0 || targetStringLength == 0 {
+		return 0
+	}
+
+	lengthRatio := float32(max(inputStringLength, targetStringLength)) / float32(min(inputStringLength, targetStringLength))
+	similarity := indelRatio(inputString, targetString)
+
+	// Similar lengths, so compare the whole strings
+	if lengthRatio < weights.PartialLengthRatio {
+		tokenSimilarity := max(tokenSortRatio(inputString, targetString), tokenSetRatio(inputString, targetString))
+		return min(max(similarity, tokenSimilarity*weights.TokenScale), 1)
+	}
+
+	// Different lengths, so compare the shorter string to parts of the longer one
+	partialScale := weights.PartialScale
+	if lengthRatio >= weights.LongPartialLengthRatio {
+		partialScale = weights.LongPartialScale
+	}
+	similarity = max(similarity, PartialRatioSimilarity(inputString, targetString)*partialScale)
+	similarity = max(similarity, partialTokenRatio(inputString, targetString)*weights.TokenScale*partialScale)
+	return min(similarity, 1)
+}
+
+// Calculates the Indel similarity of two strings, normalized by their lengths in runes
+func indelRatio(inputString, targetString string) float32 {
+	if inputString == targetString {
+		return 1
+	}
+	totalLength := utf8.RuneCountInString(inputString) + utf8.RuneCountInString(targetString)
+	return 1 - float32(LCSDistance(inputString, targetString))/float32(totalLength)
+}
+
+// Splits a string into its whitespace separated tokens, and sorts them
+func sortedTokens(inputString string) []string {
+	tokens := strings.Fields(inputString)
+	sort.Strings(tokens)
+	return tokens
+}
+
+// Calculates the similarity of two strings after sorting their tokens, so the order of the words doesn't matter
+//
+// # Notes
+//  - Tokens are split on whitespace, sorted alphabetically, and joined with single spaces before algorithm is used (i.e. "new york city" and "city new york" are both "city new york")
+//  - Repeated tokens are kept, use TokenSetSimilarity() to ignore them
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  algorithm (SimilarityAlgorithm): The algorithm to compare the sorted strings with (i.e. LevenshteinSimilarity)
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func TokenSortSimilarity(inputString, targetString string, algorithm SimilarityAlgorithm) float32 {
+	return algorithm(strings.Join(sortedTokens(inputString), " "), strings.Join(sortedTokens(targetString), " "))
+}
+
+// Calculates the Indel similarity of two strings after sorting their tokens
+func tokenSortRatio(inputString, targetString string) float32 {
+	return TokenSortSimilarity(inputString, targetString, indelRatio)
+}
+
+// Splits the unique tokens of two strings into the ones they share, and the ones only in each string, all sorted
+func splitTokens(inputString, targetString string) (shared, inputOnly, targetOnly []string) {
+	inputTokens := make(map[string]bool)
+	for _, token := range strings.Fields(inputString) {
+		inputTokens[token] = true
+	}
+	targetTokens := make(map[string]bool)
+	for _, token := range strings.Fields(targetString) {
+		targetTokens[token] = true
+	}
+
+	for token := range inputTokens {
+		if targetTokens[token] {
+			shared = append(shared, token)
+		} else {
+			inputOnly = append(inputOnly, token)
+		}
+	}
+	for token := range targetTokens {
+		if !inputTokens[token] {
+			targetOnly = append(targetOnly, token)
+		}
+	}
+
+	sort.Strings(shared)
+	sort.Strings(inputOnly)
+	sort.Strings(targetOnly)
+	return shared, inputOnly, targetOnly
+}
+
+// Calculates the similarity of two strings based on the tokens they share, so extra or repeated words don't matter as much
+//
+// # Notes
+//  - The unique tokens are split into the ones both strings share, and the ones only in each string, each sorted alphabetically
+//  - Returns the best of algorithm(shared, shared+inputOnly), algorithm(shared, shared+targetOnly), and algorithm(shared+inputOnly, shared+targetOnly)
+//  - When one string's tokens are all in the other (i.e. "new york" and "new york city") the similarity is 1
+//  - When no tokens are shared, it's just algorithm() on the sorted unique tokens
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  algorithm (SimilarityAlgorithm): The algorithm to compare the joined tokens with (i.e. LevenshteinSimilarity)
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func TokenSetSimilarity(inputString, targetString string, algorithm SimilarityAlgorithm) float32 {
+	shared, inputOnly, targetOnly := splitTokens(inputString, targetString)
+
+	sharedString := strings.Join(shared, " ")
+	inputCombined := strings.TrimSpace(sharedString + " " + strings.Join(inputOnly, " "))
+	targetCombined := strings.TrimSpace(sharedString + " " + strings.Join(targetOnly, " "))
+
+	// Comparing an empty string would depend on how algorithm handles it, so only compare the shared tokens if there are some
+	if len(shared) == 0 {
+		return algorithm(inputCombined, targetCombined)
+	}
+	return max(
+		algorithm(sharedString, inputCombined),
+		algorithm(sharedString, targetCombined),
+		algorithm(inputCombined, targetCombined),
+	)
+}
+
+// Calculates the Indel similarity of the shared tokens of two strings, and the shared tokens followed by the rest of each string's tokens
+func tokenSetRatio(inputString, targetString string) float32 {
+	return TokenSetSimilarity(inputString, targetString, indelRatio)
+}
+
+// Calculates the partial ratio of the sorted unique tokens of two strings, any shared token is a perfect match
+func partialTokenRatio(inputString, targetString string) float32 {
+	shared, inputOnly, targetOnly := splitTokens(inputString, targetString)
+	if len(shared) > 0 {
+		return 1
+	}
+	return PartialRatioSimilarity(strings.Join(inputOnly, " "), strings.Join(targetOnly, " "))
+}
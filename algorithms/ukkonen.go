@@ -0,0 +1,115 @@
+package algorithms
+
+// This file implements Ukkonen's diagonal transition algorithm for the Levenshtein distance, which finds distances up to k in O(k*min(m,n)) time
+//
+// # References
+//  - https://doi.org/10.1016/S0019-9958(85)80046-2 (Ukkonen 1985, Algorithms for approximate string matching)
+//  - https://doi.org/10.1007/BF01840446 (Myers 1986, An O(ND) difference algorithm and its variations)
+//  - https://en.wikipedia.org/wiki/Levenshtein_distance#Computing_Levenshtein_distance
+
+// Calculates the Levenshtein distance of two strings up to a maximum, using Ukkonen's diagonal transition algorithm
+//
+// # Notes
+//  - Returns maxDistance+1 for any strings further apart than maxDistance, the same as BoundedLevenshteinDistance()
+//  - Unlike BoundedLevenshteinDistance(), it never fills in the matrix, it only tracks the furthest row each diagonal reaches for each distance
+//  - Runs in O(k*min(m,n)) in the worst case, and closer to O(n+k^2) when the strings are similar, since runs of matching runes are skipped in one step
+//  - A negative maxDistance is treated as 0
+//  - Operates on runes
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  maxDistance (int): The largest distance to calculate exactly
+//
+// # Returns
+//  int: The Levenshtein distance (add, edit, delete distance), or maxDistance+1 if it's larger than maxDistance
+func UkkonenDistance(inputString, targetString string, maxDistance int) int {
+	// # Correctness
+	//
+	// Let D[i][j] be the Levenshtein distance of the first i runes of a (inputString) and the first j runes of b (targetString),
+	// and call the cells with j-i = k diagonal k. The answer is D[m][n], which is on diagonal n-m.
+	//
+	// Lemma 1 (Ukkonen 1985, Lemma 3 of Myers 1986): D[i+1][j+1] is D[i][j] or D[i][j]+1, so D never decreases along a diagonal.
+	// Proof: adding one rune to one of the strings changes the distance by at most 1, so neighbouring cells differ by at most 1.
+	// D[i+1][j+1] <= D[i][j]+1 by substituting (or matching) the last runes, and
+	// D[i+1][j+1] = min(D[i][j]+cost, D[i][j+1]+1, D[i+1][j]+1) >= min(D[i][j], (D[i][j]-1)+1, (D[i][j]-1)+1) = D[i][j].
+	//
+	// So for a distance d, the cells on diagonal k with D <= d are a prefix of the diagonal, and are described by the last
+	// row they reach, L(d, k). D[m][n] <= d exactly when L(d, n-m) = m.
+	//
+	// Lemma 2: L(d, k) is found by taking the furthest of
+	//  - L(d-1, k)+1   (substitute a rune, staying on diagonal k)
+	//  - L(d-1, k+1)+1 (delete a rune of a, moving from diagonal k+1)
+	//  - L(d-1, k-1)   (insert a rune of b, moving from diagonal k-1)
+	// and then following the diagonal while the runes match (the "slide", or snake in Myers' terms).
+	// Proof: a cell on diagonal k with D = d is reached by one edit from a cell with D = d-1 on diagonal k, k-1 or k+1,
+	// followed by matches (which cost nothing). By Lemma 1 every cell up to L(d-1, k') on diagonal k' is within d-1,
+	// so starting the edit from the furthest of them reaches at least as far as starting from any other. Matches cost
+	// nothing, so sliding as far as possible never hurts, and the slide stops at the first mismatch, where going
+	// further needs another edit.
+	//
+	// By induction on d, starting from L(0, 0) being the length of the common prefix, each round computes L(d, k)
+	// for every k in [-d, d], and the loop stops at the first d with L(d, n-m) = m, which is D[m][n].
+	// There are at most maxDistance+1 rounds of at most 2*maxDistance+1 diagonals, and each diagonal slides at most
+	// min(m, n) runes in total, which gives the O(k*min(m,n)) bound.
+
+	maxDistance = max(maxDistance, 0)
+	limit := maxDistance + 1
+
+	// Convert to runes to avoid weird encoding issues
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+	inputStringLength := len(inputStringRunes)
+	targetStringLength := len(targetStringRunes)
+
+	// Each rune of difference in length needs at least one insertion or deletion
+	finalDiagonal := targetStringLength - inputStringLength
+	if finalDiagonal > maxDistance || -finalDiagonal > maxDistance {
+		return limit
+	}
+
+	// The furthest row reached on each diagonal, diagonal k is stored at k+offset
+	// Diagonals that haven't been reached yet are -1, which is never the furthest of the options
+	offset := maxDistance + 1
+	previousRows := make([]int, 2*maxDistance+3)
+	currentRows := make([]int, 2*maxDistance+3)
+	for i := range previousRows {
+		previousRows[i] = -1
+		currentRows[i] = -1
+	}
+
+	for distance := 0; distance <= maxDistance; distance++ {
+		for diagonal := -distance; diagonal <= distance; diagonal++ {
+			// Diagonals that start past the end of either string can't be part of the answer
+			if diagonal < -inputStringLength || diagonal > targetStringLength {
+				continue
+			}
+
+			row := 0
+			if distance > 0 {
+				row = max(
+					previousRows[diagonal+offset]+1,   // Edit/replace
+					previousRows[diagonal+offset+1]+1, // Delete
+					previousRows[diagonal+offset-1],   // Add
+				)
+			} else if diagonal != 0 {
+				continue
+			}
+			row = max(row, -diagonal) // Diagonals below the main one start at row -diagonal
+			row = min(row, inputStringLength, targetStringLength-diagonal)
+
+			// Follow the runs of matching runes
+			for row < inputStringLength && row+diagonal < targetStringLength && inputStringRunes[row] == targetStringRunes[row+diagonal] {
+				row += 1
+			}
+			currentRows[diagonal+offset] = row
+
+			if diagonal == finalDiagonal && row == inputStringLength {
+				return distance
+			}
+		}
+		previousRows, currentRows = currentRows, previousRows
+	}
+
+	return limit
+}
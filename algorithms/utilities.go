@@ -1,11 +1,14 @@
 package algorithms
 
+import "sort"
+
 type Suggestion struct {
 	Likelihood float32 // How confident the suggestion is
 	Word       string  // The suggested word
 }
 
 type DistanceAlgorithm func(inputString, targetString string) int
+type DistanceAlgorithmWithError func(inputString, targetString string) (int, error)
 type SimilarityAlgorithm func(inputString, targetString string) float32
 
 // Function that calculates the similarity of two strings using a distance algortithm
@@ -38,6 +41,30 @@ func CalculateSimilarity(inputString, targetString string, algorithm DistanceAlg
 	return similarity
 }
 
+// Function that calculates the similarity of two strings using a distance algorithm that can fail
+//
+// # Notes
+//   - Panics if algorithm returns an error, so only use it when you know the inputs are valid (i.e. equal lengths for HammingDistance)
+//
+// # Parameters
+//
+//	inputString (string): The first string to use for the comparison
+//	targetString (string): The second string to use for the comparison
+//	algorithm (DistanceAlgorithmWithError): The algorithm to use to calculate the distance
+//
+// # Returns
+//
+//	float32: The similarity (between 0-1, closer to 1 is more similar)
+func CalculateSimilarityOrPanic(inputString, targetString string, algorithm DistanceAlgorithmWithError) float32 {
+	return CalculateSimilarity(inputString, targetString, func(inputString, targetString string) int {
+		distance, err := algorithm(inputString, targetString)
+		if err != nil {
+			panic(err)
+		}
+		return distance
+	})
+}
+
 // Function that suggests the highest similarity word to the input string
 //
 // # Parameters
@@ -50,12 +77,235 @@ func CalculateSimilarity(inputString, targetString string, algorithm DistanceAlg
 //
 //	float32: The similarity (between 0-1, closer to 1 is more similar)
 func SuggestWord(inputString string, validStrings []string, algorithm SimilarityAlgorithm) Suggestion {
+	ranked := rankSuggestions(inputString, validStrings, 1, algorithm, func(likelihood float32) bool {
+		return likelihood > 0
+	})
+	if len(ranked) == 0 {
+		return Suggestion{}
+	}
+	return ranked[0]
+}
+
+// Function that suggests the n highest similarity words to the input string
+//
+// # Notes
+//   - Strings with a likelihood of 0 are never suggested, so fewer than n suggestions may be returned
+//   - Strings with the same likelihood are ranked in the order they appear in validStrings
+//
+// # Parameters
+//
+//	inputString (string): The first string to use for the comparison
+//	validStrings ([]string): The valid words to check against
+//	n (int): The maximum number of suggestions to return
+//	algorithm (SimilarityAlgorithm): The algorithm to run and generate the similarity for
+//
+// # Returns
+//
+//	[]Suggestion: Up to n suggestions, sorted from most to least likely
+func SuggestTopN(inputString string, validStrings []string, n int, algorithm SimilarityAlgorithm) []Suggestion {
+	return SuggestTopNWithThreshold(inputString, validStrings, n, 0, algorithm)
+}
+
+// Function that suggests the n highest similarity words to the input string that are over a threshold
+//
+// # Notes
+//   - Strings with the same likelihood are ranked in the order they appear in validStrings
+//
+// # Parameters
+//
+//	inputString (string): The first string to use for the comparison
+//	validStrings ([]string): The valid words to check against
+//	n (int): The maximum number of suggestions to return
+//	threshold (float32): The likelihood a suggestion needs to be over to be included
+//	algorithm (SimilarityAlgorithm): The algorithm to run and generate the similarity for
+//
+// # Returns
+//
+//	[]Suggestion: Up to n suggestions, sorted from most to least likely
+func SuggestTopNWithThreshold(inputString string, validStrings []string, n int, threshold float32, algorithm SimilarityAlgorithm) []Suggestion {
+	if n <= 0 {
+		return []Suggestion{}
+	}
+	return rankSuggestions(inputString, validStrings, n, algorithm, func(likelihood float32) bool {
+		return likelihood > threshold
+	})
+}
+
+// Function that finds every word with a similarity to the input string of at least a threshold
+//
+// # Notes
+//   - Unlike SuggestTopNWithThreshold, words with a likelihood equal to threshold are included, so a threshold of 0 returns every word
+//   - Strings with the same likelihood are ranked in the order they appear in validStrings
+//
+// # Parameters
+//
+//	inputString (string): The first string to use for the comparison
+//	validStrings ([]string): The valid words to check against
+//	threshold (float32): The minimum likelihood a word needs to be included
+//	algorithm (SimilarityAlgorithm): The algorithm to run and generate the similarity for
+//
+// # Returns
+//
+//	[]Suggestion: Every suggestion at or over the threshold, sorted from most to least likely
+func FindAllAboveThreshold(inputString string, validStrings []string, threshold float32, algorithm SimilarityAlgorithm) []Suggestion {
+	return FindAllAboveThresholdWithLimit(inputString, validStrings, threshold, -1, algorithm)
+}
+
+// Function that finds the most similar words to the input string with a similarity of at least a threshold, up to a maximum number of words
+//
+// # Notes
+//   - Only keeps maxResults suggestions while searching, so it uses less memory than FindAllAboveThreshold on low thresholds
+//
+// # Parameters
+//
+//	inputString (string): The first string to use for the comparison
+//	validStrings ([]string): The valid words to check against
+//	threshold (float32): The minimum likelihood a word needs to be included
+//	maxResults (int): The maximum number of suggestions to return, or a negative number for no limit
+//	algorithm (SimilarityAlgorithm): The algorithm to run and generate the similarity for
+//
+// # Returns
+//
+//	[]Suggestion: Up to maxResults suggestions at or over the threshold, sorted from most to least likely
+func FindAllAboveThresholdWithLimit(inputString string, validStrings []string, threshold float32, maxResults int, algorithm SimilarityAlgorithm) []Suggestion {
+	if maxResults == 0 {
+		return []Suggestion{}
+	}
+	return rankSuggestions(inputString, validStrings, maxResults, algorithm, func(likelihood float32) bool {
+		return likelihood >= threshold
+	})
+}
+
+// Ranks the strings in validStrings by their similarity to inputString
+//
+// # Notes
+//   - Only strings whose likelihood is accepted by keep are ranked
+//   - Strings with the same likelihood are ranked in the order they appear in validStrings
+//
+// # Parameters
+//
+//	inputString (string): The first string to use for the comparison
+//	validStrings ([]string): The valid words to check against
+//	n (int): The maximum number of suggestions to keep, or a negative number to keep all of them
+//	algorithm (SimilarityAlgorithm): The algorithm to run and generate the similarity for
+//	keep (func(float32) bool): Returns if a likelihood is high enough to be ranked
+//
+// # Returns
+//
+//	[]Suggestion: The suggestions, sorted from most to least likely
+func rankSuggestions(inputString string, validStrings []string, n int, algorithm SimilarityAlgorithm, keep func(likelihood float32) bool) []Suggestion {
+	ranked := []Suggestion{}
+
+	// Without a limit it's cheaper to sort once at the end
+	if n < 0 {
+		for _, currentString := range validStrings {
+			likelihood := algorithm(inputString, currentString)
+			if keep(likelihood) {
+				ranked = append(ranked, Suggestion{likelihood, currentString})
+			}
+		}
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return ranked[i].Likelihood > ranked[j].Likelihood
+		})
+		return ranked
+	}
+
+	for _, currentString := range validStrings {
+		likelihood := algorithm(inputString, currentString)
+		if !keep(likelihood) {
+			continue
+		}
+		// Already full of suggestions at least this likely
+		if len(ranked) == n && likelihood <= ranked[n-1].Likelihood {
+			continue
+		}
+
+		// Insert after any suggestions that are at least as likely, dropping the least likely if full
+		position := sort.Search(len(ranked), func(i int) bool {
+			return ranked[i].Likelihood < likelihood
+		})
+		if len(ranked) < n {
+			ranked = append(ranked, Suggestion{})
+		}
+		copy(ranked[position+1:], ranked[position:])
+		ranked[position] = Suggestion{likelihood, currentString}
+	}
+
+	return ranked
+}
+
+// Function that suggests the highest similarity word to the input string
+//
+// Deprecated: Use SuggestWordAboveThreshold, which also returns the likelihood of the word.
+//
+// # Parameters
+//
+//	inputString (string): The first string to use for the comparison
+//	validStrings ([]string): The valid words to check against
+//	threshold (float32): The minimum threshold of likelihood the result needs to be at
+//	algorithm (SimilarityAlgorithm): The algorithm to run and generate the similarity for
+//
+// # Returns
+//
+//	string: The most likely word, will be a blank string if no likely word was found over the threshold
+func SuggestWordWithThreshold(inputString string, validStrings []string, threshold float32, algorithm SimilarityAlgorithm) string {
+	suggested, _ := SuggestWordAboveThreshold(inputString, validStrings, threshold, algorithm)
+	return suggested.Word
+}
+
+// Function that suggests the highest similarity word to the input string, if it's over a threshold
+//
+// # Notes
+//   - Replaces SuggestWordWithThreshold, so callers get the likelihood without running the algorithm again
+//
+// # Parameters
+//
+//	inputString (string): The first string to use for the comparison
+//	validStrings ([]string): The valid words to check against
+//	threshold (float32): The likelihood the result needs to be over
+//	algorithm (SimilarityAlgorithm): The algorithm to run and generate the similarity for
+//
+// # Returns
+//
+//	Suggestion: The most likely word, and it's likelihood, will be the zero Suggestion if no word was over the threshold
+//	bool: True if a word was over the threshold
+func SuggestWordAboveThreshold(inputString string, validStrings []string, threshold float32, algorithm SimilarityAlgorithm) (Suggestion, bool) {
+	suggested := SuggestWord(inputString, validStrings, algorithm)
+
+	if !(suggested.Likelihood > threshold) {
+		return Suggestion{}, false
+	}
+	return suggested, true
+}
+
+// Function that suggests the highest similarity word to the input string, skipping candidates that can't beat the current best
+//
+// # Notes
+//   - upperBound must never return less than algorithm would for the same strings (i.e. QuickRatio or RealQuickRatio for a Ratcliff/Obershelp or Indel based algorithm)
+//   - Candidates whose upper bound is below the current best are skipped without running algorithm, which is where the speedup comes from
+//   - Returns the same result as SuggestWord when upperBound holds
+//
+// # Parameters
+//
+//	inputString (string): The first string to use for the comparison
+//	validStrings ([]string): The valid words to check against
+//	algorithm (SimilarityAlgorithm): The algorithm to run and generate the similarity for
+//	upperBound (SimilarityAlgorithm): A cheap algorithm that never underestimates algorithm
+//
+// # Returns
+//
+//	Suggestion: The most likely word, and it's likelihood
+func SuggestWordWithUpperBound(inputString string, validStrings []string, algorithm, upperBound SimilarityAlgorithm) Suggestion {
 	var (
 		highestRatio float32
 		result       string
 	)
 
 	for _, currentString := range validStrings {
+		// Can't beat the current best, so don't bother with the real algorithm
+		if upperBound(inputString, currentString) <= highestRatio {
+			continue
+		}
 		likelihood := algorithm(inputString, currentString)
 		if likelihood > highestRatio {
 			highestRatio = likelihood
@@ -66,24 +316,173 @@ func SuggestWord(inputString string, validStrings []string, algorithm Similarity
 	return Suggestion{highestRatio, result}
 }
 
-// Function that suggests the highest similarity word to the input string
+// Function that suggests the highest similarity word to the input string, out of the words within a Levenshtein distance of it
+//
+// # Notes
+//   - Candidates are filtered with BoundedLevenshteinDistance first, which stops early on the (usually many) words that are far away
+//   - algorithm is only run on the candidates within maxDistance, so it can be any similarity algorithm
 //
 // # Parameters
 //
 //	inputString (string): The first string to use for the comparison
 //	validStrings ([]string): The valid words to check against
-//	threshold (float32): The minimum threshold of likelihood the result needs to be at
+//	maxDistance (int): The largest Levenshtein distance a candidate can be from inputString
 //	algorithm (SimilarityAlgorithm): The algorithm to run and generate the similarity for
 //
 // # Returns
 //
-//	string: The most likely word, will be a blank string if no likely word was found over the threshold
-func SuggestWordWithThreshold(inputString string, validStrings []string, threshold float32, algorithm SimilarityAlgorithm) string {
-	suggested := SuggestWord(inputString, validStrings, algorithm)
+//	Suggestion: The most likely word, and it's likelihood, will be empty if no word was within maxDistance
+func BoundedSuggestWord(inputString string, validStrings []string, maxDistance int, algorithm SimilarityAlgorithm) Suggestion {
+	var (
+		highestRatio float32
+		result       string
+	)
 
-	if !(suggested.Likelihood > threshold) {
-		return ""
-	} else {
-		return suggested.Word
+	for _, currentString := range validStrings {
+		if BoundedLevenshteinDistance(inputString, currentString, maxDistance) > maxDistance {
+			continue
+		}
+		likelihood := algorithm(inputString, currentString)
+		if likelihood > highestRatio {
+			highestRatio = likelihood
+			result = currentString
+		}
+	}
+
+	return Suggestion{highestRatio, result}
+}
+
+// Function that reports whether two strings are within a maximum distance of each other
+//
+// # Notes
+//   - Calculates the full distance with algorithm, use LevenshteinWithin() or DamerauLevenshteinWithin() to stop as soon as the distance is known to be over maxDistance
+//   - A nil algorithm uses LevenshteinWithin()
+//   - Nothing is within a negative maxDistance
+//
+// # Parameters
+//
+//	inputString (string): The first string to use for the comparison
+//	targetString (string): The second string to use for the comparison
+//	maxDistance (int): The largest distance the strings can be apart
+//	algorithm (DistanceAlgorithm): The algorithm to use to calculate the distance
+//
+// # Returns
+//
+//	bool: True if the distance between the strings is at most maxDistance
+func IsWithinDistance(inputString, targetString string, maxDistance int, algorithm DistanceAlgorithm) bool {
+	if maxDistance < 0 {
+		return false
+	}
+	if algorithm == nil {
+		return LevenshteinWithin(inputString, targetString, maxDistance)
+	}
+	return algorithm(inputString, targetString) <= maxDistance
+}
+
+// Function that finds the first word in a corpus within a Levenshtein distance of the input string
+//
+// # Notes
+//   - Stops at the first word within maxDistance, so it's much faster than SuggestWord() when any match will do (i.e. checking input against reserved words)
+//   - Uses LevenshteinWithin(), so words that are far away are skipped after a few rows
+//
+// # Parameters
+//
+//	inputString (string): The word to look for
+//	validStrings ([]string): The words to check against, in the order to check them
+//	maxDistance (int): The largest Levenshtein distance a word can be from inputString
+//
+// # Returns
+//
+//	string: The first word within maxDistance, or an empty string if there isn't one
+//	bool: True if a word was found
+func AnyWithinDistance(inputString string, validStrings []string, maxDistance int) (string, bool) {
+	for _, currentString := range validStrings {
+		if LevenshteinWithin(inputString, currentString, maxDistance) {
+			return currentString, true
+		}
+	}
+	return "", false
+}
+
+// How often each word is used relative to the others (i.e. the number of times it appears in a large body of text)
+type FrequencyCorpus map[string]float64
+
+// The default weight of the similarity in FrequencyWeightedSuggestWord(), the rest of the weight goes to how common the word is
+const DefaultFrequencyAlpha = 0.9
+
+// Function that suggests the word with the best mix of similarity to the input string and how common it is
+//
+// # Notes
+//   - Uses DefaultFrequencyAlpha, see FrequencyWeightedSuggestWordWithAlpha()
+//
+// # Parameters
+//
+//	inputString (string): The first string to use for the comparison
+//	corpus (FrequencyCorpus): The valid words to check against, and their frequencies
+//	algorithm (SimilarityAlgorithm): The algorithm to run and generate the similarity for
+//
+// # Returns
+//
+//	Suggestion: The most likely word, and it's weighted score
+func FrequencyWeightedSuggestWord(inputString string, corpus FrequencyCorpus, algorithm SimilarityAlgorithm) Suggestion {
+	return FrequencyWeightedSuggestWordWithAlpha(inputString, corpus, DefaultFrequencyAlpha, algorithm)
+}
+
+// Function that suggests the word with the best mix of similarity to the input string and how common it is, with a custom weighting
+//
+// # Notes
+//   - Each word is scored as similarity*alpha + frequencyRank*(1-alpha), and the Likelihood of the result is it's score
+//   - frequencyRank is 1 for the most frequent word, and goes down evenly to 1/n for the least frequent, words with the same frequency have the same rank
+//   - Ranks are used instead of raw frequencies since word frequencies follow Zipf's law, so all but the most common words would have a frequency of almost 0
+//   - Words with a similarity of 0 are never suggested, and words with the same score go to the more frequent word, then alphabetical order
+//   - Ranking the corpus takes O(n log n) time on every call
+//
+// # Parameters
+//
+//	inputString (string): The first string to use for the comparison
+//	corpus (FrequencyCorpus): The valid words to check against, and their frequencies
+//	alpha (float64): The weight of the similarity (between 0-1), 1 ignores frequency entirely, values outside of 0-1 are clamped
+//	algorithm (SimilarityAlgorithm): The algorithm to run and generate the similarity for
+//
+// # Returns
+//
+//	Suggestion: The most likely word, and it's weighted score
+func FrequencyWeightedSuggestWordWithAlpha(inputString string, corpus FrequencyCorpus, alpha float64, algorithm SimilarityAlgorithm) Suggestion {
+	alpha = max(0, min(alpha, 1))
+
+	// Most frequent first, so the first word with the best score is also the most common
+	words := make([]string, 0, len(corpus))
+	for word := range corpus {
+		words = append(words, word)
 	}
+	sort.Slice(words, func(i, j int) bool {
+		if corpus[words[i]] != corpus[words[j]] {
+			return corpus[words[i]] > corpus[words[j]]
+		}
+		return words[i] < words[j]
+	})
+
+	var (
+		highestScore float64
+		result       string
+		rank         int
+	)
+	for i, word := range words {
+		if i == 0 || corpus[word] != corpus[words[i-1]] {
+			rank = i
+		}
+
+		similarity := algorithm(inputString, word)
+		if similarity <= 0 {
+			continue
+		}
+		frequencyRank := 1 - float64(rank)/float64(len(words))
+		score := float64(similarity)*alpha + frequencyRank*(1-alpha)
+		if score > highestScore {
+			highestScore = score
+			result = word
+		}
+	}
+
+	return Suggestion{float32(highestScore), result}
 }
@@ -0,0 +1,665 @@
+package algorithms
+
+// This file implements the weighted Levenshtein distance of two strings, where each operation can have a different cost
+//
+// # References
+//  - https://en.wikipedia.org/wiki/Levenshtein_distance
+//  - https://en.wikipedia.org/wiki/Wagner%E2%80%93Fischer_algorithm
+//  - https://en.wikipedia.org/wiki/Confusion_matrix
+
+import (
+	"math"
+	"slices"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The rows of keys on a standard US QWERTY keyboard, each row is offset half a key further right than the one above it
+var qwertyRows = []string{"1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./"}
+
+// The key each shifted symbol is typed with on a standard US QWERTY keyboard
+var qwertyShiftedKeys = map[rune]rune{
+	'!': '1', '@': '2', '#': '3', '$': '4', '%': '5', '^': '6', '&': '7', '*': '8', '(': '9', ')': '0', '_': '-', '+': '=',
+	'{': '[', '}': ']', '|': '\\', ':': ';', '"': '\'', '<': ',', '>': '.', '?': '/',
+}
+
+// The keys next to each key on a standard QWERTY keyboard, including the ones diagonally above and below it
+var qwertyNeighbours = func() map[rune]map[rune]bool {
+	neighbours := make(map[rune]map[rune]bool)
+	addNeighbours := func(key, neighbour rune) {
+		if neighbours[key] == nil {
+			neighbours[key] = make(map[rune]bool)
+		}
+		if neighbours[neighbour] == nil {
+			neighbours[neighbour] = make(map[rune]bool)
+		}
+		neighbours[key][neighbour] = true
+		neighbours[neighbour][key] = true
+	}
+
+	for rowIndex, row := range qwertyRows {
+		keys := []rune(row)
+		for i, key := range keys {
+			// The key to the right on the same row
+			if i+1 < len(keys) {
+				addNeighbours(key, keys[i+1])
+			}
+			// The keys diagonally below, which are one to the left and directly below because of the offset
+			if rowIndex+1 < len(qwertyRows) {
+				below := []rune(qwertyRows[rowIndex+1])
+				for _, j := range []int{i - 1, i} {
+					if j >= 0 && j < len(below) {
+						addNeighbours(key, below[j])
+					}
+				}
+			}
+		}
+	}
+	return neighbours
+}()
+
+// Gets the unshifted key a rune is typed with (i.e. 'A' is typed with 'a', and '!' with '1')
+func qwertyKey(key rune) rune {
+	if unshifted, isShifted := qwertyShiftedKeys[key]; isShifted {
+		return unshifted
+	}
+	return unicode.ToLower(key)
+}
+
+// Gets the keys next to a key on a standard US QWERTY keyboard
+//
+// # Notes
+//  - Covers letters, digits and the punctuation on the main part of the keyboard, including the ones diagonally above and below it (i.e. 's' is next to "adewxz")
+//  - Shifted runes are looked up by the key they're typed with, so 'S' and 's' have the same neighbours, as do '!' and '1'
+//  - The neighbours are always the unshifted keys
+//
+// # Parameters
+//  key (rune): The key to get the neighbours of
+//
+// # Returns
+//  []rune: The neighbouring keys in sorted order, nil if the rune isn't on the keyboard
+func QWERTYNeighbours(key rune) []rune {
+	neighbours := qwertyNeighbours[qwertyKey(key)]
+	if len(neighbours) == 0 {
+		return nil
+	}
+	result := make([]rune, 0, len(neighbours))
+	for neighbour := range neighbours {
+		result = append(result, neighbour)
+	}
+	slices.Sort(result)
+	return result
+}
+
+// A substitution cost based on how close two keys are on a standard QWERTY keyboard
+//
+// # Notes
+//  - Covers letters, digits and punctuation, and runes typed with the same key are compared regardless of shift (i.e. 'a' and 'A', or '1' and '!')
+//  - Costs 0 for the same key, 0.5 for adjacent keys (i.e. "s" and "d", or "s" and "w"), and 1 for anything else
+//
+// # Parameters
+//  inputRune (rune): The rune being replaced
+//  targetRune (rune): The rune replacing it
+//
+// # Returns
+//  float64: The cost of the substitution
+func QWERTYSubstitutionCost(inputRune, targetRune rune) float64 {
+	inputRune = qwertyKey(inputRune)
+	targetRune = qwertyKey(targetRune)
+
+	if inputRune == targetRune {
+		return 0
+	}
+	if qwertyNeighbours[inputRune][targetRune] {
+		return 0.5
+	}
+	return 1
+}
+
+// Where each key is on a standard US QWERTY keyboard, as {x, y} in key widths from the top left key
+//
+// # Notes
+//  - Each row is offset half a key further right than the one above it, the same as QWERTYNeighbours()
+//  - Used by QWERTYDistance(), replace it (or its entries) to use another layout like AZERTY or Dvorak, but not while it's being used from other goroutines
+var QWERTYKeyCoordinates = func() map[rune][2]float64 {
+	coordinates := make(map[rune][2]float64)
+	for rowIndex, row := range qwertyRows {
+		for column, key := range []rune(row) {
+			coordinates[key] = [2]float64{float64(column) + 0.5*float64(rowIndex), float64(rowIndex)}
+		}
+	}
+	return coordinates
+}()
+
+// The distance between two keys (in key widths) at which QWERTYDistance() costs a full edit
+const QWERTYMaxKeyDistance = 2
+
+// A substitution cost based on the Euclidean distance between two keys in QWERTYKeyCoordinates
+//
+// # Notes
+//  - The distance in key widths divided by QWERTYMaxKeyDistance, so keys next to each other on a row cost 0.5, diagonal neighbours about 0.56, and keys 2 or more apart cost 1
+//  - Runes that aren't in QWERTYKeyCoordinates are looked up by the key they're typed with (i.e. 'A' as 'a', and '!' as '1')
+//  - Costs 0 for the same rune, and 1 if either rune isn't on the keyboard
+//
+// # Parameters
+//  inputRune (rune): The rune being replaced
+//  targetRune (rune): The rune replacing it
+//
+// # Returns
+//  float64: The cost of the substitution (between 0-1)
+func QWERTYDistance(inputRune, targetRune rune) float64 {
+	if inputRune == targetRune {
+		return 0
+	}
+	inputCoordinates, inputFound := keyCoordinates(inputRune)
+	targetCoordinates, targetFound := keyCoordinates(targetRune)
+	if !inputFound || !targetFound {
+		return 1
+	}
+
+	distance := math.Hypot(inputCoordinates[0]-targetCoordinates[0], inputCoordinates[1]-targetCoordinates[1])
+	return min(distance/QWERTYMaxKeyDistance, 1)
+}
+
+// Gets the coordinates of a rune from QWERTYKeyCoordinates, falling back to the key it's typed with
+func keyCoordinates(key rune) ([2]float64, bool) {
+	if coordinates, found := QWERTYKeyCoordinates[key]; found {
+		return coordinates, true
+	}
+	coordinates, found := QWERTYKeyCoordinates[qwertyKey(key)]
+	return coordinates, found
+}
+
+// Calculates the Levenshtein distance of two strings with custom operation costs
+//
+// # Notes
+//  - Useful when some mistakes are more likely than others, i.e. adjacent keys for typos with QWERTYSubstitutionCost, or similar looking characters for OCR
+//  - substitutionCost is only called for runes that are different, matching runes are always free
+//  - Uses the Wagner–Fischer algorithm over runes, keeping 2 rows of the matrix, and runs in O(m*n) time
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  insertCost (float64): The cost of inserting a rune from targetString
+//  deleteCost (float64): The cost of deleting a rune from inputString
+//  substitutionCost (func(rune, rune) float64): The cost of replacing a rune from inputString with one from targetString
+//
+// # Returns
+//  float64: The lowest total cost of the operations that turn inputString into targetString
+func WeightedLevenshteinDistance(inputString, targetString string, insertCost, deleteCost float64, substitutionCost func(inputRune, targetRune rune) float64) float64 {
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+
+	// Only the previous row of the matrix is needed to calculate the current one
+	previousRow := make([]float64, len(targetStringRunes)+1)
+	currentRow := make([]float64, len(targetStringRunes)+1)
+	for j := 1; j <= len(targetStringRunes); j++ {
+		previousRow[j] = previousRow[j-1] + insertCost
+	}
+
+	for i := 1; i <= len(inputStringRunes); i++ {
+		currentRow[0] = previousRow[0] + deleteCost
+		for j := 1; j <= len(targetStringRunes); j++ {
+			if inputStringRunes[i-1] == targetStringRunes[j-1] {
+				// Characters match, no cost added
+				currentRow[j] = previousRow[j-1]
+			} else {
+				currentRow[j] = min(
+					currentRow[j-1]+insertCost, // Add
+					previousRow[j]+deleteCost,  // Delete
+					previousRow[j-1]+substitutionCost(inputStringRunes[i-1], targetStringRunes[j-1]), // Edit/replace
+				)
+			}
+		}
+		previousRow, currentRow = currentRow, previousRow
+	}
+
+	return previousRow[len(targetStringRunes)]
+}
+
+// Calculates the Levenshtein distance of two strings, where typing a neighbouring key on a QWERTY keyboard only costs half an edit
+//
+// # Notes
+//  - Equivalent to WeightedLevenshteinDistance(inputString, targetString, 1, 1, QWERTYSubstitutionCost), so "cst" is 0.5 from "cat" but 1 from "cut"
+//  - Use QWERTYNeighbours() to see which keys count as neighbours
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The distance between the strings
+func KeyboardDistance(inputString, targetString string) float32 {
+	return float32(WeightedLevenshteinDistance(inputString, targetString, 1, 1, QWERTYSubstitutionCost))
+}
+
+// Calculates the similarity of two strings with KeyboardDistance()
+//
+// # Notes
+//  - Normalized by the length of the longer string in runes, since no edit costs more than 1
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func KeyboardSimilarity(inputString, targetString string) float32 {
+	if inputString == targetString {
+		return 1
+	}
+	longest := max(utf8.RuneCountInString(inputString), utf8.RuneCountInString(targetString))
+	return 1 - KeyboardDistance(inputString, targetString)/float32(longest)
+}
+
+// The default cost of changing the case of a rune in CaseAwareLevenshteinSimilarity()
+const DefaultCaseCost float32 = 0.25
+
+// Checks if two runes are different cases of the same letter, by following the unicode.SimpleFold() orbit of inputRune
+func isCaseVariant(inputRune, targetRune rune) bool {
+	for folded := unicode.SimpleFold(inputRune); folded != inputRune; folded = unicode.SimpleFold(folded) {
+		if folded == targetRune {
+			return true
+		}
+	}
+	return false
+}
+
+// Calculates the Levenshtein distance of two strings, where changing the case of a rune costs less than other substitutions
+//
+// # Notes
+//  - Case pairs are found with unicode.SimpleFold(), so non-ASCII pairs like 'Ä' and 'ä', or 'Σ', 'σ' and 'ς' are discounted too
+//  - Only single rune case changes are discounted, so 'ß' and "SS" are still a substitution and an insertion apart
+//  - A caseCost outside of 0-1 is clamped, 0 ignores case entirely and 1 is the normal Levenshtein distance
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  caseCost (float32): The cost of changing the case of a rune (i.e. DefaultCaseCost)
+//
+// # Returns
+//  float32: The distance between the strings
+func CaseAwareLevenshtein(inputString, targetString string, caseCost float32) float32 {
+	caseCost = max(0, min(caseCost, 1))
+	return float32(WeightedLevenshteinDistance(inputString, targetString, 1, 1, func(inputRune, targetRune rune) float64 {
+		if isCaseVariant(inputRune, targetRune) {
+			return float64(caseCost)
+		}
+		return 1
+	}))
+}
+
+// Calculates the similarity of two strings with CaseAwareLevenshtein() and DefaultCaseCost
+//
+// # Notes
+//  - Normalized by the length of the longer string in runes, since no edit costs more than 1
+//  - Useful for identifiers, where "HTTPserver" and "HTTPServer" are nearly the same
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func CaseAwareLevenshteinSimilarity(inputString, targetString string) float32 {
+	return caseAwareLevenshteinSimilarity(inputString, targetString, DefaultCaseCost)
+}
+
+// Creates a similarity algorithm like CaseAwareLevenshteinSimilarity() with a custom case cost
+//
+// # Parameters
+//  caseCost (float32): The cost of changing the case of a rune, clamped to 0-1
+//
+// # Returns
+//  SimilarityAlgorithm: The similarity algorithm
+func NewCaseAwareLevenshteinSimilarity(caseCost float32) SimilarityAlgorithm {
+	return func(inputString, targetString string) float32 {
+		return caseAwareLevenshteinSimilarity(inputString, targetString, caseCost)
+	}
+}
+
+// Calculates the similarity of two strings with CaseAwareLevenshtein()
+func caseAwareLevenshteinSimilarity(inputString, targetString string, caseCost float32) float32 {
+	if inputString == targetString {
+		return 1
+	}
+	longest := max(utf8.RuneCountInString(inputString), utf8.RuneCountInString(targetString))
+	return 1 - CaseAwareLevenshtein(inputString, targetString, caseCost)/float32(longest)
+}
+
+// Custom substitution costs for pairs of runes (i.e. from a confusion matrix of OCR mistakes)
+//
+// # Notes
+//  - Written as SubstitutionCosts{'0': {'O': 0.1}, 'm': {'n': 0.4}}, where costs[from][to] is the cost of replacing from with to
+//  - Pairs that aren't listed cost 1, and the same rune always costs 0
+type SubstitutionCosts map[rune]map[rune]float32
+
+// Gets the cost of substituting one rune for another, treating the costs as symmetric
+//
+// # Notes
+//  - Uses costs[from][to] if it's listed, then costs[to][from], so each pair only needs to be listed once
+//  - Negative costs are treated as 0
+//
+// # Parameters
+//  from (rune): The rune being replaced
+//  to (rune): The rune replacing it
+//
+// # Returns
+//  float32: The cost of the substitution
+func (costs SubstitutionCosts) Cost(from, to rune) float32 {
+	if from == to {
+		return 0
+	}
+	if cost, exists := costs[from][to]; exists {
+		return max(cost, 0)
+	}
+	if cost, exists := costs[to][from]; exists {
+		return max(cost, 0)
+	}
+	return 1
+}
+
+// Gets the cost of substituting one rune for another, only using the direction it's listed in
+//
+// # Notes
+//  - Only uses costs[from][to], for confusions that are more likely one way than the other
+//  - Negative costs are treated as 0
+//
+// # Parameters
+//  from (rune): The rune being replaced
+//  to (rune): The rune replacing it
+//
+// # Returns
+//  float32: The cost of the substitution
+func (costs SubstitutionCosts) DirectedCost(from, to rune) float32 {
+	if from == to {
+		return 0
+	}
+	if cost, exists := costs[from][to]; exists {
+		return max(cost, 0)
+	}
+	return 1
+}
+
+// The most a single substitution can cost, which is at least 1 since pairs that aren't listed cost 1
+func (costs SubstitutionCosts) maxCost() float32 {
+	var highest float32 = 1
+	for _, targets := range costs {
+		for _, cost := range targets {
+			highest = max(highest, cost)
+		}
+	}
+	return highest
+}
+
+// Calculates the Levenshtein distance of two strings with custom substitution costs
+//
+// # Notes
+//  - Inserts and deletes cost 1, and substitutions cost costs.Cost(), so the costs are treated as symmetric
+//  - For directed costs use WeightedLevenshteinDistance() with costs.DirectedCost()
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  costs (SubstitutionCosts): The cost of substituting each pair of runes
+//
+// # Returns
+//  float32: The lowest total cost of the operations that turn inputString into targetString
+func LevenshteinWithCosts(inputString, targetString string, costs SubstitutionCosts) float32 {
+	distance := WeightedLevenshteinDistance(inputString, targetString, 1, 1, func(inputRune, targetRune rune) float64 {
+		return float64(costs.Cost(inputRune, targetRune))
+	})
+	return float32(distance)
+}
+
+// Calculates the Levenshtein similarity of two strings with custom substitution costs
+//
+// # Notes
+//  - Normalized by the most the distance could be, min(m, n)*min(c, 2) + |m-n| where c is the highest substitution cost, so it's between 0-1 for any costs
+//  - With unit costs this is 1 - LevenshteinDistance/max(m, n), which is less forgiving than LevenshteinSimilarity's normalization by m+n
+//  - Use a closure to pass it to SuggestWord, i.e. func(a, b string) float32 { return LevenshteinWithCostsSimilarity(a, b, costs) }
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  costs (SubstitutionCosts): The cost of substituting each pair of runes
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func LevenshteinWithCostsSimilarity(inputString, targetString string, costs SubstitutionCosts) float32 {
+	if inputString == targetString {
+		return 1
+	}
+
+	inputStringLength := utf8.RuneCountInString(inputString)
+	targetStringLength := utf8.RuneCountInString(targetString)
+	shorter := min(inputStringLength, targetStringLength)
+	longer := max(inputStringLength, targetStringLength)
+
+	// A substitution never costs more than deleting and inserting
+	largest := float32(shorter)*min(costs.maxCost(), 2) + float32(longer-shorter)
+	if largest == 0 {
+		return 1
+	}
+	return max(0, 1-LevenshteinWithCosts(inputString, targetString, costs)/largest)
+}
+
+// A substitution of one short run of runes for another (i.e. "rn" read as "m" by OCR)
+//
+// # Notes
+//  - Rules apply in both directions, so {"rn", "m", 0.25} also turns "m" into "rn"
+type SubstitutionRule struct {
+	From string  // The runes being replaced
+	To   string  // The runes replacing them
+	Cost float32 // The cost of the substitution, negative costs are treated as 0
+}
+
+// Substitution rules for common OCR confusions, where runes (or runs of runes) look alike in a scanned document
+//
+// # Notes
+//  - Covers "rn" and "m", "cl" and "d", "vv" and "w", "l", "1" and "I", and "0" and "O", each costing 0.25
+var OCRCosts = []SubstitutionRule{
+	{"rn", "m", 0.25},
+	{"cl", "d", 0.25},
+	{"vv", "w", 0.25},
+	{"l", "1", 0.25},
+	{"l", "I", 0.25},
+	{"1", "I", 0.25},
+	{"0", "O", 0.25},
+}
+
+// Runes that look alike, so OCR engines (and people reading text) often mistake one for the other
+//
+// # Notes
+//  - Based on the single rune entries of the Unicode Consortium's confusables.txt for ASCII letters and digits, plus the digits OCR often reads as letters (i.e. '5' and 'S')
+//  - Each entry only needs to be listed once, VisualSimilarity() checks both directions
+//  - Runs of runes that look like one rune (i.e. "rn" and "m") can't be substitutions, use OCRCosts with LevenshteinWithRules() for those
+//  - Add entries to cover other confusions, but not while it's being used from other goroutines
+var VisualConfusables = map[rune][]rune{
+	'0': {'O', 'o', '\u039f', '\u041e'}, // Greek Ο, Cyrillic О
+	'1': {'l', 'I', '|'},
+	'2': {'Z'},
+	'5': {'S'},
+	'8': {'B'},
+	'l': {'I', '|', '\u0399', '\u0406'}, // Greek Ι, Cyrillic І
+	'I': {'|', '\u0399', '\u0406'},      // Greek Ι, Cyrillic І
+	'a': {'\u0430', '\u0251', '\u03b1'}, // Cyrillic а, Latin ɑ, Greek α
+	'c': {'\u0441', '\u03f2'},           // Cyrillic с, Greek ϲ
+	'e': {'\u0435'},                     // Cyrillic е
+	'i': {'\u0456', '\u0131'},           // Cyrillic і, dotless ı
+	'j': {'\u0458'},                     // Cyrillic ј
+	'o': {'\u043e', '\u03bf'},           // Cyrillic о, Greek ο
+	'p': {'\u0440', '\u03c1'},           // Cyrillic р, Greek ρ
+	's': {'\u0455'},                     // Cyrillic ѕ
+	'x': {'\u0445', '\u00d7'},           // Cyrillic х, multiplication sign
+	'y': {'\u0443'},                     // Cyrillic у
+	'A': {'\u0410', '\u0391'},           // Cyrillic А, Greek Α
+	'B': {'\u0412', '\u0392'},           // Cyrillic В, Greek Β
+	'C': {'\u0421'},                     // Cyrillic С
+	'E': {'\u0415', '\u0395'},           // Cyrillic Е, Greek Ε
+	'H': {'\u041d', '\u0397'},           // Cyrillic Н, Greek Η
+	'K': {'\u041a', '\u039a'},           // Cyrillic К, Greek Κ
+	'M': {'\u041c', '\u039c'},           // Cyrillic М, Greek Μ
+	'N': {'\u039d'},                     // Greek Ν
+	'O': {'\u041e', '\u039f'},           // Cyrillic О, Greek Ο
+	'P': {'\u0420', '\u03a1'},           // Cyrillic Р, Greek Ρ
+	'S': {'\u0405'},                     // Cyrillic Ѕ
+	'T': {'\u0422', '\u03a4'},           // Cyrillic Т, Greek Τ
+	'X': {'\u0425', '\u03a7'},           // Cyrillic Х, Greek Χ
+	'Y': {'\u03a5'},                     // Greek Υ
+	'Z': {'\u0396'},                     // Greek Ζ
+}
+
+// The similarity VisualSimilarity() gives runes that are listed as confusable with each other in VisualConfusables
+const VisualConfusableSimilarity = 0.75
+
+// Calculates how alike two runes look
+//
+// # Notes
+//  - 1 for the same rune, VisualConfusableSimilarity if either rune lists the other in VisualConfusables, and 0 for anything else
+//  - Not transitive, two runes are only similar if one of them lists the other (i.e. '1' and 'l', but not 'l' and '7')
+//
+// # Parameters
+//  inputRune (rune): The first rune to compare
+//  targetRune (rune): The second rune to compare
+//
+// # Returns
+//  float64: The similarity (between 0-1, closer to 1 is more similar)
+func VisualSimilarity(inputRune, targetRune rune) float64 {
+	if inputRune == targetRune {
+		return 1
+	}
+	if slices.Contains(VisualConfusables[inputRune], targetRune) || slices.Contains(VisualConfusables[targetRune], inputRune) {
+		return VisualConfusableSimilarity
+	}
+	return 0
+}
+
+// A substitution cost where runes that look alike are cheaper to replace
+//
+// # Notes
+//  - Calculated as 1 - VisualSimilarity(), so confusables cost 0.25, the same as the rules in OCRCosts
+//  - Pass it to WeightedLevenshteinDistance(), or to WithSubstitutionCost() for WeightedLevenshteinFunc
+//
+// # Parameters
+//  inputRune (rune): The rune being replaced
+//  targetRune (rune): The rune replacing it
+//
+// # Returns
+//  float64: The cost of the substitution (between 0-1)
+func VisualSubstitutionCost(inputRune, targetRune rune) float64 {
+	return 1 - VisualSimilarity(inputRune, targetRune)
+}
+
+// A substitution rule converted to runes, in one direction
+type runeRule struct {
+	from []rune
+	to   []rune
+	cost float32
+}
+
+// Calculates the Levenshtein distance of two strings, with substitution rules that can replace short runs of runes
+//
+// # Notes
+//  - Inserts, deletes and substitutions cost 1, and a rule costs rule.Cost when its From runes end at the current position in one string and its To runes in the other
+//  - Unlike SubstitutionCosts, rules can replace several runes at once (i.e. "cornpany" is 0.25 from "company" with OCRCosts), since a rule jumps back len(From) rows and len(To) columns of the matrix
+//  - A rule is only used when it's cheaper than the normal edits, so the distance is never more than LevenshteinDistance
+//  - Rules with an empty From or To are skipped, use insertions and deletions for those
+//  - Keeps the whole matrix, since rules can look back more than one row, and runs in O(m*n*r) time for r rules
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  rules ([]SubstitutionRule): The substitution rules (i.e. OCRCosts)
+//
+// # Returns
+//  float32: The lowest total cost of the operations that turn inputString into targetString
+func LevenshteinWithRules(inputString, targetString string, rules []SubstitutionRule) float32 {
+	inputStringRunes := []rune(inputString)
+	targetStringRunes := []rune(targetString)
+
+	// Every rule applies in both directions
+	directedRules := make([]runeRule, 0, 2*len(rules))
+	for _, rule := range rules {
+		if rule.From == "" || rule.To == "" || rule.From == rule.To {
+			continue
+		}
+		cost := max(rule.Cost, 0)
+		directedRules = append(directedRules,
+			runeRule{[]rune(rule.From), []rune(rule.To), cost},
+			runeRule{[]rune(rule.To), []rune(rule.From), cost},
+		)
+	}
+
+	matrix := make([][]float32, len(inputStringRunes)+1)
+	for i := range matrix {
+		matrix[i] = make([]float32, len(targetStringRunes)+1)
+		matrix[i][0] = float32(i)
+	}
+	for j := range matrix[0] {
+		matrix[0][j] = float32(j)
+	}
+
+	for i := 1; i <= len(inputStringRunes); i++ {
+		for j := 1; j <= len(targetStringRunes); j++ {
+			var cost float32 = 1
+			if inputStringRunes[i-1] == targetStringRunes[j-1] {
+				cost = 0
+			}
+			matrix[i][j] = min(
+				matrix[i][j-1]+1,      // Add
+				matrix[i-1][j]+1,      // Delete
+				matrix[i-1][j-1]+cost, // Edit/replace
+			)
+
+			// Rules that end here in both strings
+			for _, rule := range directedRules {
+				fromStart, toStart := i-len(rule.from), j-len(rule.to)
+				if fromStart < 0 || toStart < 0 {
+					continue
+				}
+				if slices.Equal(inputStringRunes[fromStart:i], rule.from) && slices.Equal(targetStringRunes[toStart:j], rule.to) {
+					matrix[i][j] = min(matrix[i][j], matrix[fromStart][toStart]+rule.cost)
+				}
+			}
+		}
+	}
+
+	return matrix[len(inputStringRunes)][len(targetStringRunes)]
+}
+
+// Calculates the Levenshtein similarity of two strings, with substitution rules that can replace short runs of runes
+//
+// # Notes
+//  - Normalized by the length of the longer string in runes, since the distance is never more than LevenshteinDistance
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//  rules ([]SubstitutionRule): The substitution rules (i.e. OCRCosts)
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func LevenshteinWithRulesSimilarity(inputString, targetString string, rules []SubstitutionRule) float32 {
+	if inputString == targetString {
+		return 1
+	}
+	longest := max(utf8.RuneCountInString(inputString), utf8.RuneCountInString(targetString))
+	return max(0, 1-LevenshteinWithRules(inputString, targetString, rules)/float32(longest))
+}
+
+// Calculates the similarity of two strings, where common OCR confusions only cost a little
+//
+// # Notes
+//  - The same as LevenshteinWithRulesSimilarity() with OCRCosts, i.e. "cornpany" and "company" are 0.969
+//  - Useful for matching scanned text against a corpus, like invoice line items against product names
+//
+// # Parameters
+//  inputString (string): The first string to use for the comparison
+//  targetString (string): The second string to use for the comparison
+//
+// # Returns
+//  float32: The similarity (between 0-1, closer to 1 is more similar)
+func OCRSimilarity(inputString, targetString string) float32 {
+	return LevenshteinWithRulesSimilarity(inputString, targetString, OCRCosts)
+}
@@ -0,0 +1,220 @@
+package speyl
+
+import (
+	"sort"
+	"strings"
+)
+
+// A deduplicated list of valid words, that can be passed anywhere a []string of valid words can
+//
+// # Notes
+//   - Words are kept in the order they were added, unless the corpus was made with NewSortedCorpus()
+//   - Not safe to Add() or Remove() while searching the corpus in another goroutine
+type Corpus struct {
+	words  []string
+	lookup map[string]struct{} // The words in the corpus, for checking duplicates
+	sorted bool                // Whether words are kept in sorted order
+}
+
+// The types that can be used as a list of valid words
+type WordList interface {
+	[]string | *Corpus
+}
+
+// Gets the words out of a word list
+//
+// # Parameters
+//
+//	validWords (W): The []string or *Corpus to get the words from
+//
+// # Returns
+//
+//	[]string: The words, a nil *Corpus has no words
+func wordsOf[W WordList](validWords W) []string {
+	switch words := any(validWords).(type) {
+	case []string:
+		return words
+	case *Corpus:
+		return words.Words()
+	}
+	return nil
+}
+
+// Creates a corpus from a list of words
+//
+// # Notes
+//   - Duplicate words are only kept the first time they appear
+//
+// # Parameters
+//
+//	words ([]string): The words to put in the corpus, the slice isn't modified
+//
+// # Returns
+//
+//	*Corpus: The corpus, with words in the order they first appear
+func NewCorpus(words []string) *Corpus {
+	corpus := &Corpus{
+		words:  make([]string, 0, len(words)),
+		lookup: make(map[string]struct{}, len(words)),
+	}
+	for _, word := range words {
+		corpus.Add(word)
+	}
+	return corpus
+}
+
+// Creates a corpus from a list of words, that keeps it's words sorted
+//
+// # Notes
+//   - Duplicate words are removed, and words added later are inserted in sorted order
+//
+// # Parameters
+//
+//	words ([]string): The words to put in the corpus, the slice isn't modified
+//
+// # Returns
+//
+//	*Corpus: The corpus, with words in sorted order
+func NewSortedCorpus(words []string) *Corpus {
+	corpus := NewCorpus(words)
+	corpus.sorted = true
+	sort.Strings(corpus.words)
+	return corpus
+}
+
+// Adds a word to the corpus, if it isn't already in it
+//
+// # Parameters
+//
+//	word (string): The word to add
+//
+// # Returns
+//
+//	bool: True if the word was added, false if it was already in the corpus
+func (corpus *Corpus) Add(word string) bool {
+	if corpus.Contains(word) {
+		return false
+	}
+	if corpus.lookup == nil {
+		corpus.lookup = make(map[string]struct{})
+	}
+	corpus.lookup[word] = struct{}{}
+
+	if !corpus.sorted {
+		corpus.words = append(corpus.words, word)
+		return true
+	}
+	position := sort.SearchStrings(corpus.words, word)
+	corpus.words = append(corpus.words, "")
+	copy(corpus.words[position+1:], corpus.words[position:])
+	corpus.words[position] = word
+	return true
+}
+
+// Removes a word from the corpus
+//
+// # Notes
+//   - The order of the other words is kept, so it runs in O(n) time
+//
+// # Parameters
+//
+//	word (string): The word to remove
+//
+// # Returns
+//
+//	bool: True if the word was removed, false if it wasn't in the corpus
+func (corpus *Corpus) Remove(word string) bool {
+	if !corpus.Contains(word) {
+		return false
+	}
+	delete(corpus.lookup, word)
+
+	var position int
+	if corpus.sorted {
+		position = sort.SearchStrings(corpus.words, word)
+	} else {
+		for position = range corpus.words {
+			if corpus.words[position] == word {
+				break
+			}
+		}
+	}
+	corpus.words = append(corpus.words[:position], corpus.words[position+1:]...)
+	return true
+}
+
+// Checks if a word is in the corpus
+//
+// # Parameters
+//
+//	word (string): The word to look for
+//
+// # Returns
+//
+//	bool: True if the word is in the corpus
+func (corpus *Corpus) Contains(word string) bool {
+	if corpus == nil {
+		return false
+	}
+	_, exists := corpus.lookup[word]
+	return exists
+}
+
+// The number of words in the corpus
+//
+// # Returns
+//
+//	int: The number of words
+func (corpus *Corpus) Len() int {
+	if corpus == nil {
+		return 0
+	}
+	return len(corpus.words)
+}
+
+// Gets the words in the corpus
+//
+// # Notes
+//   - The slice is shared with the corpus to avoid copying large corpuses, so it shouldn't be modified, and it's only valid until the next Add() or Remove()
+//
+// # Returns
+//
+//	[]string: The words, in the order they were added (or sorted order for a sorted corpus)
+func (corpus *Corpus) Words() []string {
+	if corpus == nil {
+		return nil
+	}
+	return corpus.words
+}
+
+// Removes the duplicates from a list of words, keeping the first time each word appears
+//
+// # Notes
+//   - Use this to clean up a word list before searching it, duplicates make SuggestWord() slower without changing the result
+//   - If caseFold is true, words are lowercased with strings.ToLower before they're compared, but the first word is returned as it was written (i.e. "Paris", "paris" becomes "Paris")
+//   - NewCorpus() removes exact duplicates the same way, and keeps the corpus deduplicated as words are added
+//
+// # Parameters
+//
+//	words ([]string): The words to deduplicate, the slice isn't modified
+//	caseFold (bool): Whether words that only differ in case count as duplicates
+//
+// # Returns
+//
+//	[]string: The words without duplicates, in the order they first appear
+func DeduplicateCorpus(words []string, caseFold bool) []string {
+	result := make([]string, 0, len(words))
+	seen := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		key := word
+		if caseFold {
+			key = strings.ToLower(word)
+		}
+		if _, exists := seen[key]; exists {
+			continue
+		}
+		seen[key] = struct{}{}
+		result = append(result, word)
+	}
+	return result
+}
@@ -1,3 +1,8 @@
 module github.com/Descent098/speyl
 
 go 1.22.0
+
+require (
+	github.com/rivo/uniseg v0.4.7
+	golang.org/x/text v0.22.0
+)
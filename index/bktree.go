@@ -0,0 +1,151 @@
+// Indexes that speed up searching a corpus of words, by avoiding comparing against every word
+package index
+
+// This file implements a BK-tree (Burkhard-Keller tree) for finding the words within a distance of a query
+//
+// # References
+//  - https://en.wikipedia.org/wiki/BK-tree
+//  - https://signal-to-noise.xyz/post/bk-tree/
+
+import (
+	"sort"
+
+	"github.com/Descent098/speyl/algorithms"
+)
+
+// A tree of words, where each child is stored under it's distance from the parent
+//
+// # Notes
+//   - The metric must be a true metric (i.e. LevenshteinDistance), the triangle inequality is what allows subtrees to be skipped
+//   - Not safe to Insert() and Search() concurrently
+type BKTree struct {
+	root   *bkNode
+	metric algorithms.DistanceAlgorithm
+	size   int
+}
+
+type bkNode struct {
+	word     string
+	children map[int]*bkNode // Children keyed by their distance to word
+}
+
+// Creates a BK-tree containing words
+//
+// # Parameters
+//
+//	words ([]string): The words to put in the tree
+//	metric (algorithms.DistanceAlgorithm): The distance to organize the tree with (i.e. algorithms.LevenshteinDistance)
+//
+// # Returns
+//
+//	*BKTree: The tree containing the words
+func NewBKTree(words []string, metric algorithms.DistanceAlgorithm) *BKTree {
+	tree := &BKTree{metric: metric}
+	for _, word := range words {
+		tree.Insert(word)
+	}
+	return tree
+}
+
+// Adds a word to the tree, words that are already in the tree are ignored
+//
+// # Parameters
+//
+//	word (string): The word to add
+func (tree *BKTree) Insert(word string) {
+	if tree.root == nil {
+		tree.root = &bkNode{word: word}
+		tree.size = 1
+		return
+	}
+
+	current := tree.root
+	for {
+		distance := tree.metric(word, current.word)
+		if distance == 0 {
+			return
+		}
+		child, exists := current.children[distance]
+		if !exists {
+			if current.children == nil {
+				current.children = make(map[int]*bkNode)
+			}
+			current.children[distance] = &bkNode{word: word}
+			tree.size += 1
+			return
+		}
+		current = child
+	}
+}
+
+// Gets the number of words in the tree
+//
+// # Returns
+//
+//	int: The number of words in the tree
+func (tree *BKTree) Len() int {
+	return tree.size
+}
+
+// Finds all the words in the tree within a distance of the query
+//
+// # Notes
+//   - The likelihood of each suggestion is normalized the same way as algorithms.CalculateSimilarity()
+//   - Only subtrees whose distance from their parent is within maxDistance of the parent's distance to the query are searched
+//
+// # Parameters
+//
+//	query (string): The word to search for
+//	maxDistance (int): The maximum distance a word can be from the query
+//
+// # Returns
+//
+//	[]algorithms.Suggestion: The words within maxDistance, sorted from most to least likely
+func (tree *BKTree) Search(query string, maxDistance int) []algorithms.Suggestion {
+	results := []algorithms.Suggestion{}
+	if tree.root == nil || maxDistance < 0 {
+		return results
+	}
+
+	candidates := []*bkNode{tree.root}
+	for len(candidates) > 0 {
+		current := candidates[len(candidates)-1]
+		candidates = candidates[:len(candidates)-1]
+
+		distance := tree.metric(query, current.word)
+		if distance <= maxDistance {
+			results = append(results, algorithms.Suggestion{
+				Likelihood: normalizeDistance(query, current.word, distance),
+				Word:       current.word,
+			})
+		}
+
+		// By the triangle inequality, only children in [distance-maxDistance, distance+maxDistance] can be close enough
+		for childDistance, child := range current.children {
+			if childDistance >= distance-maxDistance && childDistance <= distance+maxDistance {
+				candidates = append(candidates, child)
+			}
+		}
+	}
+
+	sortSuggestions(results)
+	return results
+}
+
+// Normalizes a distance into a likelihood the same way as algorithms.CalculateSimilarity()
+func normalizeDistance(inputString, targetString string, distance int) float32 {
+	if inputString == targetString {
+		return 1
+	}
+	return 1 - float32(distance)/(float32(len(inputString))+float32(len(targetString)))
+}
+
+// Sorts suggestions from most to least likely, with ties sorted alphabetically so results are deterministic
+func sortSuggestions(suggestions []algorithms.Suggestion) {
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Likelihood != suggestions[j].Likelihood {
+			return suggestions[i].Likelihood > suggestions[j].Likelihood
+		}
+		return suggestions[i].Word < suggestions[j].Word
+	})
+}
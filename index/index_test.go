@@ -0,0 +1,259 @@
+package index
+
+import (
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Descent098/speyl/algorithms"
+)
+
+// Loads the premade corpus from the root of the repo
+func loadWords(tb testing.TB) []string {
+	content, err := os.ReadFile("../words.txt")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return strings.Split(string(content), "\r\n")
+}
+
+// Finds the words within maxDistance of query by checking every word
+func linearSearch(query string, words []string, maxDistance int, metric algorithms.DistanceAlgorithm) []algorithms.Suggestion {
+	results := []algorithms.Suggestion{}
+	for _, word := range words {
+		if distance := metric(query, word); distance <= maxDistance {
+			results = append(results, algorithms.Suggestion{Likelihood: normalizeDistance(query, word, distance), Word: word})
+		}
+	}
+	sortSuggestions(results)
+	return results
+}
+
+func TestBKTree(t *testing.T) {
+	uniqueWords := []string{"hi", "hello", "help", "hell", "bonjour", "alumni", "alumnus", "alum", "almond", ""}
+	tree := NewBKTree(append(uniqueWords, "hello"), algorithms.LevenshteinDistance)
+
+	if tree.Len() != len(uniqueWords) {
+		t.Errorf("BKTree.Len() expected %d (duplicates ignored) got %d", len(uniqueWords), tree.Len())
+	}
+
+	for _, query := range []string{"alumni", "almni", "helo", "bonjur", "zzz", ""} {
+		for maxDistance := range 4 {
+			expected := linearSearch(query, uniqueWords, maxDistance, algorithms.LevenshteinDistance)
+			result := tree.Search(query, maxDistance)
+			if len(result) != len(expected) {
+				t.Errorf("BKTree.Search(%s, %d) expected %v got %v", query, maxDistance, expected, result)
+				continue
+			}
+			for i := range result {
+				if result[i] != expected[i] {
+					t.Errorf("BKTree.Search(%s, %d) expected %v got %v", query, maxDistance, expected, result)
+					break
+				}
+			}
+		}
+	}
+
+	empty := NewBKTree(nil, algorithms.LevenshteinDistance)
+	if result := empty.Search("alumni", 2); len(result) != 0 {
+		t.Errorf("BKTree.Search on an empty tree should return nothing, got %v", result)
+	}
+}
+
+func BenchmarkBKTree(b *testing.B) {
+	words := loadWords(b)
+	tree := NewBKTree(words, algorithms.LevenshteinDistance)
+
+	for _, maxDistance := range []int{1, 2} {
+		b.Run("BKTree/"+strconv.Itoa(maxDistance), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				tree.Search("almni", maxDistance)
+			}
+		})
+		b.Run("Linear/"+strconv.Itoa(maxDistance), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				linearSearch("almni", words, maxDistance, algorithms.LevenshteinDistance)
+			}
+		})
+	}
+	b.Run("SuggestWord", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			algorithms.SuggestWord("almni", words, algorithms.LevenshteinSimilarity)
+		}
+	})
+}
+
+// Finds the words that start with prefix by checking every word, sorted alphabetically
+func linearPrefixSearch(prefix string, words []string) []string {
+	results := []string{}
+	for _, word := range words {
+		if strings.HasPrefix(word, prefix) {
+			results = append(results, word)
+		}
+	}
+	slices.Sort(results)
+	return results
+}
+
+func TestTrie(t *testing.T) {
+	uniqueWords := []string{"hi", "hello", "help", "hell", "bonjour", "alumni", "alumnus", "alum", "almond", "über", ""}
+	trie := NewTrie(append(uniqueWords, "hello"))
+
+	if trie.Len() != len(uniqueWords) {
+		t.Errorf("Trie.Len() expected %d (duplicates ignored) got %d", len(uniqueWords), trie.Len())
+	}
+
+	for _, prefix := range []string{"", "h", "hel", "hell", "hello", "helloo", "al", "alum", "ü", "u", "zzz"} {
+		expected := linearPrefixSearch(prefix, uniqueWords)
+		if result := trie.Search(prefix); !slices.Equal(result, expected) {
+			t.Errorf("Trie.Search(%s) expected %v got %v", prefix, expected, result)
+		}
+	}
+
+	result := trie.SearchWithSimilarity("alum", algorithms.LevenshteinSimilarity, 2)
+	expected := []algorithms.Suggestion{{Likelihood: 1, Word: "alum"}, {Likelihood: 0.8, Word: "alumni"}}
+	if !slices.Equal(result, expected) {
+		t.Errorf("Trie.SearchWithSimilarity(alum, 2) expected %v got %v", expected, result)
+	}
+	if result := trie.SearchWithSimilarity("alum", algorithms.LevenshteinSimilarity, -1); len(result) != 3 {
+		t.Errorf("Trie.SearchWithSimilarity(alum, -1) expected 3 suggestions got %v", result)
+	}
+
+	empty := NewTrie(nil)
+	if result := empty.Search("al"); len(result) != 0 {
+		t.Errorf("Trie.Search on an empty trie should return nothing, got %v", result)
+	}
+	if result := empty.SearchWithSimilarity("al", algorithms.LevenshteinSimilarity, 5); len(result) != 0 {
+		t.Errorf("Trie.SearchWithSimilarity on an empty trie should return nothing, got %v", result)
+	}
+}
+
+func BenchmarkTrie(b *testing.B) {
+	words := loadWords(b)
+	trie := NewTrie(words)
+
+	for _, prefix := range []string{"a", "alum"} {
+		b.Run("Trie/"+prefix, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				trie.Search(prefix)
+			}
+		})
+		b.Run("Linear/"+prefix, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				linearPrefixSearch(prefix, words)
+			}
+		})
+	}
+	b.Run("SearchWithSimilarity", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			trie.SearchWithSimilarity("alum", algorithms.JaroWinklerSimilarity, 5)
+		}
+	})
+	b.Run("SuggestTopN", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			algorithms.SuggestTopN("alum", words, 5, algorithms.JaroWinklerSimilarity)
+		}
+	})
+}
+
+func TestSerializeBKTree(t *testing.T) {
+	words := append(loadWords(t)[:5000], "alumni", "alumnus", "almond", "hello", "héllo", "")
+	tree := NewBKTree(words, algorithms.LevenshteinDistance)
+
+	gobData, err := MarshalBKTree(tree)
+	if err != nil {
+		t.Fatalf("MarshalBKTree() returned an error: %v", err)
+	}
+	jsonData, err := MarshalBKTreeJSON(tree)
+	if err != nil {
+		t.Fatalf("MarshalBKTreeJSON() returned an error: %v", err)
+	}
+	fromGob, err := UnmarshalBKTree(gobData)
+	if err != nil {
+		t.Fatalf("UnmarshalBKTree() returned an error: %v", err)
+	}
+	fromJSON, err := UnmarshalBKTreeJSON(jsonData)
+	if err != nil {
+		t.Fatalf("UnmarshalBKTreeJSON() returned an error: %v", err)
+	}
+
+	// The loaded trees should give the same results as the original
+	for name, loaded := range map[string]*BKTree{"gob": fromGob, "JSON": fromJSON} {
+		if loaded.Len() != tree.Len() {
+			t.Errorf("BKTree loaded from %s expected %d words got %d", name, tree.Len(), loaded.Len())
+		}
+		for _, query := range []string{"alumni", "almni", "helo", "abacus", "zzz", ""} {
+			for maxDistance := range 3 {
+				if expected, result := tree.Search(query, maxDistance), loaded.Search(query, maxDistance); !slices.Equal(result, expected) {
+					t.Errorf("BKTree loaded from %s Search(%s, %d) expected %v got %v", name, query, maxDistance, expected, result)
+				}
+			}
+		}
+		loaded.Insert("almni")
+		if result := loaded.Search("almni", 0); len(result) != 1 || loaded.Len() != tree.Len()+1 {
+			t.Errorf("BKTree loaded from %s should still be usable after loading, got %v", name, result)
+		}
+	}
+
+	// Saving is deterministic
+	if again, _ := MarshalBKTreeJSON(tree); string(again) != string(jsonData) {
+		t.Errorf("MarshalBKTreeJSON() should give the same output for the same tree")
+	}
+
+	// Empty trees round trip too
+	emptyData, err := MarshalBKTree(NewBKTree(nil, algorithms.IndelDistance))
+	if err != nil {
+		t.Fatalf("MarshalBKTree() on an empty tree returned an error: %v", err)
+	}
+	if empty, err := UnmarshalBKTree(emptyData); err != nil || empty.Len() != 0 || len(empty.Search("alumni", 2)) != 0 {
+		t.Errorf("UnmarshalBKTree() on an empty tree expected an empty tree, got %v, %v", empty, err)
+	}
+
+	// Invalid data, and metrics that aren't registered, are errors when loading
+	invalid := []string{
+		`{"Metric":"not_a_metric","Nodes":[]}`,
+		`{"Metric":"levenshtein","Nodes":[{"Word":"a","Parent":0,"Distance":0}]}`,
+		`{"Metric":"levenshtein","Nodes":[{"Word":"a","Parent":-1,"Distance":0},{"Word":"b","Parent":1,"Distance":1}]}`,
+		`{"Metric":"levenshtein","Nodes":[{"Word":"a","Parent":-1,"Distance":0},{"Word":"b","Parent":0,"Distance":1},{"Word":"c","Parent":0,"Distance":1}]}`,
+		`not json`,
+	}
+	for _, data := range invalid {
+		if _, err := UnmarshalBKTreeJSON([]byte(data)); err == nil {
+			t.Errorf("UnmarshalBKTreeJSON(%s) should return an error", data)
+		}
+	}
+	if _, err := UnmarshalBKTree([]byte("not gob")); err == nil {
+		t.Errorf("UnmarshalBKTree() with invalid data should return an error")
+	}
+
+	// Metrics that aren't registered can't be saved
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MarshalBKTree() with an unregistered metric should panic")
+		}
+	}()
+	MarshalBKTree(NewBKTree(words[:10], func(inputString, targetString string) int {
+		return algorithms.LevenshteinDistance(inputString, targetString)
+	}))
+}
+
+func BenchmarkSerializeBKTree(b *testing.B) {
+	words := loadWords(b)[:50000]
+	data, err := MarshalBKTree(NewBKTree(words, algorithms.LevenshteinDistance))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("NewBKTree", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			NewBKTree(words, algorithms.LevenshteinDistance)
+		}
+	})
+	b.Run("UnmarshalBKTree", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			UnmarshalBKTree(data)
+		}
+	})
+}
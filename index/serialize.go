@@ -0,0 +1,182 @@
+package index
+
+// This file implements saving and loading BK-trees, so a tree over a large corpus only has to be built once
+//
+// # References
+//  - https://pkg.go.dev/encoding/gob
+//  - https://pkg.go.dev/encoding/json
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Descent098/speyl/algorithms"
+)
+
+// The form a BK-tree is saved in, the metric is saved by it's registered name since functions can't be serialized
+type serializedBKTree struct {
+	Metric string
+	Nodes  []serializedBKNode // Parents always come before their children, the root is first
+}
+
+type serializedBKNode struct {
+	Word     string
+	Parent   int // The index of the parent in Nodes, -1 for the root
+	Distance int // The distance to the parent
+}
+
+// Saves a BK-tree with encoding/gob
+//
+// # Notes
+//   - The metric is saved by the name it's registered under with algorithms.RegisterDistance(), see algorithms.DistanceName()
+//   - Panics if the metric isn't registered, since the tree couldn't be loaded again
+//   - The structure of the tree is saved as is, so loading it doesn't call the metric
+//
+// # Parameters
+//
+//	tree (*BKTree): The tree to save
+//
+// # Returns
+//
+//	[]byte: The encoded tree
+//	error: An error if the tree couldn't be encoded
+func MarshalBKTree(tree *BKTree) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(serializeBKTree(tree)); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// Loads a BK-tree saved with MarshalBKTree()
+//
+// # Parameters
+//
+//	data ([]byte): The encoded tree
+//
+// # Returns
+//
+//	*BKTree: The tree
+//	error: An error if the data isn't a valid tree, or it's metric isn't registered
+func UnmarshalBKTree(data []byte) (*BKTree, error) {
+	var serialized serializedBKTree
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&serialized); err != nil {
+		return nil, err
+	}
+	return deserializeBKTree(serialized)
+}
+
+// Saves a BK-tree as JSON
+//
+// # Notes
+//   - The same as MarshalBKTree(), but human readable and larger
+//   - Panics if the metric isn't registered, since the tree couldn't be loaded again
+//
+// # Parameters
+//
+//	tree (*BKTree): The tree to save
+//
+// # Returns
+//
+//	[]byte: The JSON encoded tree
+//	error: An error if the tree couldn't be encoded
+func MarshalBKTreeJSON(tree *BKTree) ([]byte, error) {
+	return json.Marshal(serializeBKTree(tree))
+}
+
+// Loads a BK-tree saved with MarshalBKTreeJSON()
+//
+// # Parameters
+//
+//	data ([]byte): The JSON encoded tree
+//
+// # Returns
+//
+//	*BKTree: The tree
+//	error: An error if the data isn't a valid tree, or it's metric isn't registered
+func UnmarshalBKTreeJSON(data []byte) (*BKTree, error) {
+	var serialized serializedBKTree
+	if err := json.Unmarshal(data, &serialized); err != nil {
+		return nil, err
+	}
+	return deserializeBKTree(serialized)
+}
+
+// Flattens a tree into nodes that point to their parents, children are visited in order of distance so the output is deterministic
+func serializeBKTree(tree *BKTree) serializedBKTree {
+	name, registered := algorithms.DistanceName(tree.metric)
+	if !registered {
+		panic("index: the BKTree's metric isn't registered, use algorithms.RegisterDistance() so it can be saved")
+	}
+
+	serialized := serializedBKTree{Metric: name, Nodes: make([]serializedBKNode, 0, tree.size)}
+	if tree.root == nil {
+		return serialized
+	}
+
+	type pending struct {
+		node     *bkNode
+		parent   int
+		distance int
+	}
+	stack := []pending{{tree.root, -1, 0}}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		index := len(serialized.Nodes)
+		serialized.Nodes = append(serialized.Nodes, serializedBKNode{current.node.word, current.parent, current.distance})
+
+		distances := make([]int, 0, len(current.node.children))
+		for distance := range current.node.children {
+			distances = append(distances, distance)
+		}
+		// Pushed in reverse so the closest child is visited first
+		slices.Sort(distances)
+		for i := len(distances) - 1; i >= 0; i-- {
+			stack = append(stack, pending{current.node.children[distances[i]], index, distances[i]})
+		}
+	}
+	return serialized
+}
+
+// Rebuilds a tree from its flattened nodes, without calling the metric
+func deserializeBKTree(serialized serializedBKTree) (*BKTree, error) {
+	metric, registered := algorithms.GetDistance(serialized.Metric)
+	if !registered {
+		return nil, fmt.Errorf("index: the BKTree's metric %q isn't registered", serialized.Metric)
+	}
+
+	tree := &BKTree{metric: metric}
+	nodes := make([]*bkNode, len(serialized.Nodes))
+	for i, serializedNode := range serialized.Nodes {
+		node := &bkNode{word: serializedNode.Word}
+		nodes[i] = node
+
+		if i == 0 {
+			if serializedNode.Parent != -1 {
+				return nil, errors.New("index: the first node of a BKTree must be the root")
+			}
+			tree.root = node
+			continue
+		}
+		if serializedNode.Parent < 0 || serializedNode.Parent >= i || serializedNode.Distance <= 0 {
+			return nil, fmt.Errorf("index: node %d of the BKTree has an invalid parent or distance", i)
+		}
+
+		parent := nodes[serializedNode.Parent]
+		if _, exists := parent.children[serializedNode.Distance]; exists {
+			return nil, fmt.Errorf("index: node %d of the BKTree has the same distance as another child of it's parent", i)
+		}
+		if parent.children == nil {
+			parent.children = make(map[int]*bkNode)
+		}
+		parent.children[serializedNode.Distance] = node
+	}
+	tree.size = len(nodes)
+	return tree, nil
+}
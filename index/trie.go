@@ -0,0 +1,154 @@
+package index
+
+// This file implements a trie (prefix tree) for finding the words that start with a prefix
+//
+// # References
+//  - https://en.wikipedia.org/wiki/Trie
+
+import (
+	"slices"
+
+	"github.com/Descent098/speyl/algorithms"
+)
+
+// A tree of words, where each edge is a rune and words that share a prefix share the path to it
+//
+// # Notes
+//   - Finding the words with a prefix only visits the nodes under that prefix, so it doesn't depend on the size of the rest of the corpus
+//   - Not safe to Insert() and Search() concurrently
+type Trie struct {
+	root *trieNode
+	size int
+}
+
+type trieNode struct {
+	children map[rune]*trieNode // Children keyed by the next rune
+	terminal bool               // Whether a word ends at this node
+	word     string             // The full word, if a word ends at this node
+}
+
+// Creates a trie containing words
+//
+// # Parameters
+//
+//	words ([]string): The words to put in the trie
+//
+// # Returns
+//
+//	*Trie: The trie containing the words
+func NewTrie(words []string) *Trie {
+	trie := &Trie{}
+	for _, word := range words {
+		trie.Insert(word)
+	}
+	return trie
+}
+
+// Adds a word to the trie, words that are already in the trie are ignored
+//
+// # Parameters
+//
+//	word (string): The word to add
+func (trie *Trie) Insert(word string) {
+	if trie.root == nil {
+		trie.root = &trieNode{}
+	}
+
+	current := trie.root
+	for _, currentRune := range word {
+		child, exists := current.children[currentRune]
+		if !exists {
+			if current.children == nil {
+				current.children = make(map[rune]*trieNode)
+			}
+			child = &trieNode{}
+			current.children[currentRune] = child
+		}
+		current = child
+	}
+
+	if !current.terminal {
+		current.terminal = true
+		current.word = word
+		trie.size += 1
+	}
+}
+
+// Gets the number of words in the trie
+//
+// # Returns
+//
+//	int: The number of words in the trie
+func (trie *Trie) Len() int {
+	return trie.size
+}
+
+// Finds all the words in the trie that start with a prefix
+//
+// # Notes
+//   - The prefix is matched rune by rune, and is case sensitive
+//   - An empty prefix returns every word in the trie
+//
+// # Parameters
+//
+//	prefix (string): The prefix to search for
+//
+// # Returns
+//
+//	[]string: The words that start with prefix (including prefix itself if it's a word), sorted alphabetically
+func (trie *Trie) Search(prefix string) []string {
+	results := []string{}
+	if trie.root == nil {
+		return results
+	}
+
+	// Walk down to the node for the prefix
+	current := trie.root
+	for _, currentRune := range prefix {
+		child, exists := current.children[currentRune]
+		if !exists {
+			return results
+		}
+		current = child
+	}
+
+	// Collect every word under it
+	candidates := []*trieNode{current}
+	for len(candidates) > 0 {
+		node := candidates[len(candidates)-1]
+		candidates = candidates[:len(candidates)-1]
+
+		if node.terminal {
+			results = append(results, node.word)
+		}
+		for _, child := range node.children {
+			candidates = append(candidates, child)
+		}
+	}
+
+	slices.Sort(results)
+	return results
+}
+
+// Finds the words in the trie that start with a prefix, ranked by their similarity to it
+//
+// # Notes
+//   - Only the words that start with prefix are compared, instead of the whole corpus
+//   - Uses algorithms.SuggestTopN(), so words with a similarity of 0 are left out, and ties are sorted alphabetically
+//
+// # Parameters
+//
+//	prefix (string): The prefix to search for
+//	algorithm (algorithms.SimilarityAlgorithm): The algorithm to rank the words with (i.e. algorithms.JaroWinklerSimilarity)
+//	topN (int): The maximum number of suggestions to return, all of them are returned if it's < 0
+//
+// # Returns
+//
+//	[]algorithms.Suggestion: Up to topN suggestions, sorted from most to least likely
+func (trie *Trie) SearchWithSimilarity(prefix string, algorithm algorithms.SimilarityAlgorithm, topN int) []algorithms.Suggestion {
+	words := trie.Search(prefix)
+	if topN < 0 {
+		topN = len(words)
+	}
+	return algorithms.SuggestTopN(prefix, words, topN, algorithm)
+}
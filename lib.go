@@ -4,47 +4,139 @@
 //
 //	CheckSimilarity(): Check the similarity of a word to a corpus of validWords
 //	LoadWords(): Returns a slice of a huge number of words (>350,000)
+//	LoadWordsFromReader(): Returns a slice of the words read from an io.Reader
+//	LoadFrequenciesFromReader(): Returns the word frequencies read from an io.Reader
+//	LoadPremadeFrequencies(): Returns the word frequencies counted from a public domain book
 package speyl
 
 import (
+	"bufio"
+	"context"
 	_ "embed"
-	"log"
-	"os"
-	"path/filepath"
+	"errors"
+	"fmt"
+	"io"
 	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/Descent098/speyl/algorithms"
 )
 
-// Gets the file path of the currently running go file
+// The bundled corpus, with one word per line
 //
-// # Returns
+//go:embed words.txt
+var premadeWords string
+
+// The bundled frequency list, with one word and it's frequency per line
 //
-//	string: the path to the current file in go
-func getCurrentFilePath() string {
-	_, filename, _, ok := runtime.Caller(1)
-	if !ok {
-		panic("Could not get caller information")
-	}
-	return filename
-}
+//go:embed frequencies.txt
+var premadeFrequencies string
+
+// Returned by LoadWordsFromReader() when the separator is empty
+var ErrEmptySeparator = errors.New("separator must not be empty")
 
 // Helper function to load a default corpus of over 350,000 words
 //
+// # Notes
+//   - The words are embedded in the binary, so it works without the source tree
+//
 // # Returns
 //
 //	[]string: A slice with the words in the corpus
 func LoadPremadeWords() []string {
-	currentFileDir := filepath.Dir(getCurrentFilePath())
-	filePath := filepath.Join(currentFileDir, "words.txt")
+	return strings.Split(premadeWords, "\r\n")
+}
+
+// Loads a corpus of words from a reader (i.e. a file, HTTP response body, or pipe)
+//
+// # Notes
+//   - Empty words are skipped, so a trailing separator doesn't add an empty word
+//   - The whole reader is read into memory before it's split
+//
+// # Parameters
+//
+//	r (io.Reader): The reader to load the words from
+//	separator (string): The string between each word (i.e. "\n")
+//
+// # Returns
+//
+//	[]string: A slice with the words in the order they were read
+//	error: ErrEmptySeparator if separator is empty, or the error from reading r
+func LoadWordsFromReader(r io.Reader, separator string) ([]string, error) {
+	if separator == "" {
+		return nil, ErrEmptySeparator
+	}
 
-	content, err := os.ReadFile(filePath)
+	content, err := io.ReadAll(r)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	result := strings.Split(string(content), "\r\n")
-	return result
+
+	words := []string{}
+	for _, word := range strings.Split(string(content), separator) {
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	return words, nil
+}
+
+// Loads word frequencies from a reader, for use with algorithms.FrequencyWeightedSuggestWord()
+//
+// # Notes
+//   - Each line is a word followed by whitespace and it's frequency (i.e. "the 23135851162"), which is the format most published frequency lists use
+//   - Blank lines are skipped, and if a word appears more than once the frequencies are added together
+//
+// # Parameters
+//
+//	r (io.Reader): The reader to load the frequencies from
+//
+// # Returns
+//
+//	algorithms.FrequencyCorpus: The frequency of each word
+//	error: An error if a line isn't a word and a number, or the error from reading r
+func LoadFrequenciesFromReader(r io.Reader) (algorithms.FrequencyCorpus, error) {
+	corpus := algorithms.FrequencyCorpus{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected a word and a frequency, got %q", line, scanner.Text())
+		}
+		frequency, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		corpus[fields[0]] += frequency
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return corpus, nil
+}
+
+// Helper function to load a small set of word frequencies, for use with algorithms.FrequencyWeightedSuggestWord()
+//
+// # Notes
+//   - The frequencies are the number of times each word appears in Isaac Newton's Opticks (4th edition, 1730), using the public domain Project Gutenberg transcription (about 100,000 words)
+//   - Only words that are in LoadPremadeWords() are counted, all in lowercase, and one and two letter words are only kept if they're common words (i.e. "of", but not the "c" of "the point C")
+//   - That's only 3,708 words, with British spellings (i.e. "colour") and a lot of words about light, so for general spell checking load a larger list with LoadFrequenciesFromReader()
+//   - The list is embedded in the binary, so it works without the source tree
+//
+// # Returns
+//
+//	algorithms.FrequencyCorpus: The frequency of each word
+func LoadPremadeFrequencies() algorithms.FrequencyCorpus {
+	corpus, err := LoadFrequenciesFromReader(strings.NewReader(premadeFrequencies))
+	if err != nil {
+		panic(fmt.Sprintf("speyl: the embedded frequencies.txt is invalid: %v", err))
+	}
+	return corpus
 }
 
 // Used to get a suggested word with a specific algorithm
@@ -52,14 +144,14 @@ func LoadPremadeWords() []string {
 // # Parameters
 //
 //	inputWord (string): The word to find a similar word for
-//	validWords ([]string): A slice with the words in the corpus
+//	validWords ([]string | *Corpus): The words in the corpus
 //	algorithm (algorithms.SimilarityAlgorithm): The algorithm to use to calculate the similarity of the words
 //
 // # Returns
 //
 //	algorithms.Suggestion: A pointer to the suggestion struct with the word and it's likelihood
-func SuggestWordWithSpecificAlgorithm(word string, validWords []string, algorithm algorithms.SimilarityAlgorithm) algorithms.Suggestion {
-	result := algorithms.SuggestWord(word, validWords, algorithm)
+func SuggestWordWithSpecificAlgorithm[W WordList](word string, validWords W, algorithm algorithms.SimilarityAlgorithm) algorithms.Suggestion {
+	result := algorithms.SuggestWord(word, wordsOf(validWords), algorithm)
 	return result
 }
 
@@ -68,16 +160,16 @@ func SuggestWordWithSpecificAlgorithm(word string, validWords []string, algorith
 // # Parameters
 //
 //	inputWord (string): The word to find a similar word for
-//	validWords ([]string): A slice with the words that are considered valid
+//	validWords ([]string | *Corpus): The words that are considered valid
 //
 // # Returns
 //
 //	Suggestion: A suggestion struct with the word and it's likelihood
-func SuggestWord(word string, validWords []string) algorithms.Suggestion {
+func SuggestWord[W WordList](word string, validWords W) algorithms.Suggestion {
 	highestRatio := float32(0)
 	currentSuggestion := ""
 
-	for _, currentWord := range validWords {
+	for _, currentWord := range wordsOf(validWords) {
 		res := algorithms.JaroSimilarity(word, currentWord)
 		if res > highestRatio {
 			highestRatio = res
@@ -89,3 +181,130 @@ func SuggestWord(word string, validWords []string) algorithms.Suggestion {
 		Word:       currentSuggestion,
 	}
 }
+
+// Used to get a suggested word with a specific algorithm, which stops when ctx is cancelled
+//
+// # Notes
+//   - Returns the same suggestion as SuggestWordWithSpecificAlgorithm() if it isn't cancelled
+//   - Checks if ctx is done every 1,000 words, so an interactive search can be dropped when a newer one starts
+//
+// # Parameters
+//
+//	ctx (context.Context): The context that can be used to cancel the search
+//	inputWord (string): The word to find a similar word for
+//	validWords ([]string | *Corpus): The words in the corpus
+//	algorithm (algorithms.SimilarityAlgorithm): The algorithm to use to calculate the similarity of the words
+//
+// # Returns
+//
+//	algorithms.Suggestion: The suggestion struct with the word and it's likelihood
+//	error: ctx.Err() if the search was cancelled before it finished
+func SuggestWordContext[W WordList](ctx context.Context, word string, validWords W, algorithm algorithms.SimilarityAlgorithm) (algorithms.Suggestion, error) {
+	const checkInterval = 1000 // How many words to check between looking at ctx
+	words := wordsOf(validWords)
+
+	var result algorithms.Suggestion
+	for i := 0; i < len(words); i += checkInterval {
+		if err := ctx.Err(); err != nil {
+			return algorithms.Suggestion{}, err
+		}
+		batch := words[i:min(i+checkInterval, len(words))]
+		suggestion := algorithms.SuggestWord(word, batch, algorithm)
+		if suggestion.Likelihood > result.Likelihood {
+			result = suggestion
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return algorithms.Suggestion{}, err
+	}
+	return result, nil
+}
+
+// Used to get a suggested word by splitting the search across multiple goroutines
+//
+// # Notes
+//   - Returns the same suggestion as SuggestWordWithSpecificAlgorithm()
+//   - Only worth it for slower algorithms or huge corpuses, the goroutines cost more than Jaro similarity saves on ~370,000 words
+//
+// # Parameters
+//
+//	inputWord (string): The word to find a similar word for
+//	validWords ([]string | *Corpus): The words in the corpus
+//	algorithm (algorithms.SimilarityAlgorithm): The algorithm to use to calculate the similarity of the words
+//	workers (int): The number of goroutines to split the search across, defaults to runtime.NumCPU() if <= 0
+//
+// # Returns
+//
+//	algorithms.Suggestion: The suggestion struct with the word and it's likelihood
+func ParallelSuggestWord[W WordList](word string, validWords W, algorithm algorithms.SimilarityAlgorithm, workers int) algorithms.Suggestion {
+	// Can't error without a context that can be cancelled
+	result, _ := ParallelSuggestWordContext(context.Background(), word, validWords, algorithm, workers)
+	return result
+}
+
+// Used to get a suggested word by splitting the search across multiple goroutines, which stops when ctx is cancelled
+//
+// # Notes
+//   - Each goroutine checks if ctx is done every 1,000 words
+//
+// # Parameters
+//
+//	ctx (context.Context): The context that can be used to cancel the search
+//	inputWord (string): The word to find a similar word for
+//	wordList ([]string | *Corpus): The words in the corpus
+//	algorithm (algorithms.SimilarityAlgorithm): The algorithm to use to calculate the similarity of the words
+//	workers (int): The number of goroutines to split the search across, defaults to runtime.NumCPU() if <= 0
+//
+// # Returns
+//
+//	algorithms.Suggestion: The suggestion struct with the word and it's likelihood
+//	error: ctx.Err() if the search was cancelled before it finished
+func ParallelSuggestWordContext[W WordList](ctx context.Context, word string, wordList W, algorithm algorithms.SimilarityAlgorithm, workers int) (algorithms.Suggestion, error) {
+	const checkInterval = 1000 // How many words to check between looking at ctx
+	validWords := wordsOf(wordList)
+
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	workers = max(1, min(workers, len(validWords)))
+	chunkSize := (len(validWords) + workers - 1) / workers
+
+	// Each worker writes the best suggestion in it's chunk to it's own index
+	results := make([]algorithms.Suggestion, workers)
+	wg := sync.WaitGroup{}
+
+	for worker := range workers {
+		start := min(worker*chunkSize, len(validWords))
+		end := min(start+chunkSize, len(validWords))
+
+		wg.Add(1)
+		go func(worker int, chunk []string) {
+			defer wg.Done()
+			for i := 0; i < len(chunk); i += checkInterval {
+				if ctx.Err() != nil {
+					return
+				}
+				batch := chunk[i:min(i+checkInterval, len(chunk))]
+				suggestion := algorithms.SuggestWord(word, batch, algorithm)
+				if suggestion.Likelihood > results[worker].Likelihood {
+					results[worker] = suggestion
+				}
+			}
+		}(worker, validWords[start:end])
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return algorithms.Suggestion{}, err
+	}
+
+	// Merge the chunks in order, so ties go to the earliest word like the synchronous version
+	var result algorithms.Suggestion
+	for _, suggestion := range results {
+		if suggestion.Likelihood > result.Likelihood {
+			result = suggestion
+		}
+	}
+	return result, nil
+}
@@ -1,8 +1,14 @@
 package speyl
 
 import (
+	"context"
+	"errors"
 	"math"
+	"slices"
+	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 
 	"github.com/Descent098/speyl/algorithms"
 )
@@ -84,3 +90,395 @@ func BenchmarkSuggestWord(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkSuggestWordWithUpperBound(b *testing.B) {
+	validWords := LoadPremadeWords()
+
+	b.Run("Unbounded", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			algorithms.SuggestWord("almni", validWords, algorithms.IndelSimilarity)
+		}
+	})
+	b.Run("QuickRatio", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			algorithms.SuggestWordWithUpperBound("almni", validWords, algorithms.IndelSimilarity, algorithms.QuickRatio)
+		}
+	})
+	b.Run("RealQuickRatio", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			algorithms.SuggestWordWithUpperBound("almni", validWords, algorithms.IndelSimilarity, algorithms.RealQuickRatio)
+		}
+	})
+}
+
+func BenchmarkBoundedSuggestWord(b *testing.B) {
+	validWords := LoadPremadeWords()
+
+	b.Run("Unbounded", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			algorithms.SuggestWord("almni", validWords, algorithms.LevenshteinSimilarity)
+		}
+	})
+	b.Run("Bounded", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			algorithms.BoundedSuggestWord("almni", validWords, 2, algorithms.LevenshteinSimilarity)
+		}
+	})
+}
+
+func BenchmarkAnyWithinDistance(b *testing.B) {
+	validWords := LoadPremadeWords()
+
+	// Nothing is within 1 edit, so every word has to be checked
+	b.Run("IsWithinDistance", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for _, word := range validWords {
+				if algorithms.IsWithinDistance("qxzvjk", word, 1, algorithms.LevenshteinDistance) {
+					break
+				}
+			}
+		}
+	})
+	b.Run("AnyWithinDistance", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			algorithms.AnyWithinDistance("qxzvjk", validWords, 1)
+		}
+	})
+}
+
+func TestParallelSuggestWord(t *testing.T) {
+	validWords := []string{"hi", "hello", "bonjour", "alumni", "alumnus", "alum", "xyz", "alumni"}
+
+	for _, word := range []string{"alumni", "almni", "helo", "bonjur", "zzz", ""} {
+		expected := algorithms.SuggestWord(word, validWords, algorithms.LevenshteinSimilarity)
+		for _, workers := range []int{-1, 0, 1, 3, 100} {
+			result := ParallelSuggestWord(word, validWords, algorithms.LevenshteinSimilarity, workers)
+			if result != expected {
+				t.Errorf("ParallelSuggestWord(%s, %d workers) differed from the synchronous version: %v != %v", word, workers, result, expected)
+			}
+		}
+	}
+
+	if result := ParallelSuggestWord("alumni", []string{}, algorithms.LevenshteinSimilarity, 4); result != (algorithms.Suggestion{}) {
+		t.Errorf("ParallelSuggestWord with no valid words should return an empty suggestion, got %v", result)
+	}
+
+	// A cancelled context should stop the search
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := ParallelSuggestWordContext(ctx, "alumni", validWords, algorithms.LevenshteinSimilarity, 2)
+	if err != context.Canceled {
+		t.Errorf("ParallelSuggestWordContext with a cancelled context should return context.Canceled, got %v", err)
+	}
+	if result != (algorithms.Suggestion{}) {
+		t.Errorf("ParallelSuggestWordContext with a cancelled context should return an empty suggestion, got %v", result)
+	}
+}
+
+func TestSuggestWordContext(t *testing.T) {
+	validWords := []string{"hi", "hello", "bonjour", "alumni", "alumnus", "alum", "xyz", "alumni"}
+
+	for _, word := range []string{"alumni", "almni", "helo", "bonjur", "zzz", ""} {
+		expected := SuggestWordWithSpecificAlgorithm(word, validWords, algorithms.LevenshteinSimilarity)
+		result, err := SuggestWordContext(context.Background(), word, NewCorpus(validWords), algorithms.LevenshteinSimilarity)
+		if err != nil || result != expected {
+			t.Errorf("SuggestWordContext(%s) differed from the synchronous version: %v, %v != %v", word, result, err, expected)
+		}
+	}
+
+	// Cancel part way through a big corpus, like a newer keystroke would
+	words := make([]string, 10_000)
+	for i := range words {
+		words[i] = "alumni"
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := 0
+	cancelling := func(inputString, targetString string) float32 {
+		calls += 1
+		if calls == 1500 {
+			cancel()
+		}
+		return algorithms.LevenshteinSimilarity(inputString, targetString)
+	}
+
+	type searchResult struct {
+		suggestion algorithms.Suggestion
+		err        error
+	}
+	done := make(chan searchResult)
+	go func() {
+		suggestion, err := SuggestWordContext(ctx, "almni", words, cancelling)
+		done <- searchResult{suggestion, err}
+	}()
+
+	select {
+	case result := <-done:
+		if !errors.Is(result.err, context.Canceled) {
+			t.Errorf("SuggestWordContext cancelled mid-search should return context.Canceled, got %v", result.err)
+		}
+		if result.suggestion != (algorithms.Suggestion{}) {
+			t.Errorf("SuggestWordContext cancelled mid-search should return an empty suggestion, got %v", result.suggestion)
+		}
+		// The batch it was cancelled in finishes, but the next one shouldn't start
+		if calls != 2000 {
+			t.Errorf("SuggestWordContext cancelled after 1,500 words should stop after 2,000 words, stopped after %d", calls)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("SuggestWordContext didn't return after being cancelled")
+	}
+}
+
+func BenchmarkParallelSuggestWord(b *testing.B) {
+	validWords := LoadPremadeWords()
+
+	b.Run("Synchronous", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			SuggestWordWithSpecificAlgorithm("almni", validWords, algorithms.LevenshteinSimilarity)
+		}
+	})
+	b.Run("Parallel", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			ParallelSuggestWord("almni", validWords, algorithms.LevenshteinSimilarity, 0)
+		}
+	})
+}
+
+func TestCorpus(t *testing.T) {
+	corpus := NewCorpus([]string{"hello", "alumni", "hi", "alumni", "bonjour", "hello"})
+	if expected := []string{"hello", "alumni", "hi", "bonjour"}; !slices.Equal(corpus.Words(), expected) {
+		t.Errorf("NewCorpus() should remove duplicates and keep the order, expected %v got %v", expected, corpus.Words())
+	}
+
+	if !corpus.Add("alumnus") || corpus.Add("hi") {
+		t.Errorf("Corpus.Add() should only add words that aren't in the corpus")
+	}
+	if !corpus.Remove("hello") || corpus.Remove("xyz") {
+		t.Errorf("Corpus.Remove() should only remove words that are in the corpus")
+	}
+	if expected := []string{"alumni", "hi", "bonjour", "alumnus"}; !slices.Equal(corpus.Words(), expected) || corpus.Len() != len(expected) {
+		t.Errorf("Corpus should be %v after adding and removing words, got %v", expected, corpus.Words())
+	}
+	if corpus.Contains("hello") || !corpus.Contains("alumnus") {
+		t.Errorf("Corpus.Contains() didn't match the words in the corpus")
+	}
+
+	sortedCorpus := NewSortedCorpus([]string{"hello", "alumni", "hi", "alumni"})
+	sortedCorpus.Add("bonjour")
+	sortedCorpus.Add("zebra")
+	sortedCorpus.Remove("hi")
+	if expected := []string{"alumni", "bonjour", "hello", "zebra"}; !slices.Equal(sortedCorpus.Words(), expected) {
+		t.Errorf("NewSortedCorpus() should keep the words sorted, expected %v got %v", expected, sortedCorpus.Words())
+	}
+
+	// The zero value and nil corpuses are empty
+	var emptyCorpus Corpus
+	if !emptyCorpus.Add("hi") || emptyCorpus.Len() != 1 {
+		t.Errorf("The zero value Corpus should be usable, got %v", emptyCorpus.Words())
+	}
+	var nilCorpus *Corpus
+	if nilCorpus.Len() != 0 || nilCorpus.Contains("hi") || nilCorpus.Words() != nil {
+		t.Errorf("A nil Corpus should be empty")
+	}
+
+	// Anything that accepts a []string also accepts a *Corpus
+	validWords := []string{"hi", "hello", "bonjour", "alumni"}
+	corpus = NewCorpus(validWords)
+	for _, word := range []string{"alumni", "almni", "helo"} {
+		if result, expected := SuggestWord(word, corpus), SuggestWord(word, validWords); result != expected {
+			t.Errorf("SuggestWord(%s) with a Corpus differed from a []string: %v != %v", word, result, expected)
+		}
+		if result, expected := SuggestWordWithSpecificAlgorithm(word, corpus, algorithms.LevenshteinSimilarity), SuggestWordWithSpecificAlgorithm(word, validWords, algorithms.LevenshteinSimilarity); result != expected {
+			t.Errorf("SuggestWordWithSpecificAlgorithm(%s) with a Corpus differed from a []string: %v != %v", word, result, expected)
+		}
+		if result, expected := ParallelSuggestWord(word, corpus, algorithms.LevenshteinSimilarity, 2), ParallelSuggestWord(word, validWords, algorithms.LevenshteinSimilarity, 2); result != expected {
+			t.Errorf("ParallelSuggestWord(%s) with a Corpus differed from a []string: %v != %v", word, result, expected)
+		}
+	}
+	if result := SuggestWord("alumni", nilCorpus); result != (algorithms.Suggestion{}) {
+		t.Errorf("SuggestWord with a nil Corpus should return an empty suggestion, got %v", result)
+	}
+}
+
+func TestDeduplicateCorpus(t *testing.T) {
+	words := []string{"Paris", "hello", "paris", "alumni", "hello", "PARIS", "Alumni", "über", "Über"}
+	original := slices.Clone(words)
+
+	if result, expected := DeduplicateCorpus(words, false), []string{"Paris", "hello", "paris", "alumni", "PARIS", "Alumni", "über", "Über"}; !slices.Equal(result, expected) {
+		t.Errorf("DeduplicateCorpus(caseFold=false) expected %v got %v", expected, result)
+	}
+	// The first casing that appears is the one that's kept
+	if result, expected := DeduplicateCorpus(words, true), []string{"Paris", "hello", "alumni", "über"}; !slices.Equal(result, expected) {
+		t.Errorf("DeduplicateCorpus(caseFold=true) expected %v got %v", expected, result)
+	}
+	if !slices.Equal(words, original) {
+		t.Errorf("DeduplicateCorpus() shouldn't modify the words passed in, got %v", words)
+	}
+	if result := DeduplicateCorpus(nil, true); len(result) != 0 {
+		t.Errorf("DeduplicateCorpus(nil) should be empty, got %v", result)
+	}
+}
+
+func BenchmarkDeduplicateCorpus(b *testing.B) {
+	// The premade corpus, with 5% of the words repeated
+	uniqueWords := LoadPremadeWords()
+	words := slices.Clone(uniqueWords)
+	for i := 0; i < len(uniqueWords); i += 20 {
+		words = append(words, uniqueWords[i])
+	}
+	deduplicated := DeduplicateCorpus(words, false)
+
+	b.Run("DeduplicateCorpus", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			DeduplicateCorpus(words, false)
+		}
+	})
+	b.Run("SuggestWord/Duplicates", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			SuggestWordWithSpecificAlgorithm("almni", words, algorithms.LevenshteinSimilarity)
+		}
+	})
+	b.Run("SuggestWord/Deduplicated", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			SuggestWordWithSpecificAlgorithm("almni", deduplicated, algorithms.LevenshteinSimilarity)
+		}
+	})
+}
+
+func TestLoadWords(t *testing.T) {
+	if words := LoadPremadeWords(); len(words) < 350000 || words[0] == "" {
+		t.Errorf("LoadPremadeWords() should load over 350,000 words from the embedded corpus, got %d", len(words))
+	}
+
+	type testCase struct {
+		content       string
+		separator     string
+		expectedWords []string
+	}
+
+	cases := []testCase{
+		{"hi\nhello\nalumni\n", "\n", []string{"hi", "hello", "alumni"}},
+		{"hi\r\nhello\r\n\r\nalumni", "\r\n", []string{"hi", "hello", "alumni"}},
+		{"hi,hello,alumni", ",", []string{"hi", "hello", "alumni"}},
+		{"hi hello", ",", []string{"hi hello"}},
+		{"", "\n", []string{}},
+	}
+
+	for _, currentCase := range cases {
+		words, err := LoadWordsFromReader(strings.NewReader(currentCase.content), currentCase.separator)
+		if err != nil || !slices.Equal(words, currentCase.expectedWords) {
+			t.Errorf("LoadWordsFromReader(%q, %q) expected %v got %v (%v)", currentCase.content, currentCase.separator, currentCase.expectedWords, words, err)
+		}
+	}
+
+	if _, err := LoadWordsFromReader(strings.NewReader("hi"), ""); err != ErrEmptySeparator {
+		t.Errorf("LoadWordsFromReader() with an empty separator should return ErrEmptySeparator, got %v", err)
+	}
+	readErr := errors.New("read failed")
+	if _, err := LoadWordsFromReader(iotest.ErrReader(readErr), "\n"); err != readErr {
+		t.Errorf("LoadWordsFromReader() should return the reader's error, got %v", err)
+	}
+}
+
+func TestLoadFrequenciesFromReader(t *testing.T) {
+	corpus, err := LoadFrequenciesFromReader(strings.NewReader("the 100\nof\t50.5\n\nthe 20\n"))
+	expected := algorithms.FrequencyCorpus{"the": 120, "of": 50.5}
+	if err != nil || len(corpus) != len(expected) || corpus["the"] != expected["the"] || corpus["of"] != expected["of"] {
+		t.Errorf("LoadFrequenciesFromReader() expected %v got %v (%v)", expected, corpus, err)
+	}
+
+	premade := LoadPremadeFrequencies()
+	if len(premade) != 3708 || premade["the"] != 9825 || premade["of"] <= premade["light"] {
+		t.Errorf("LoadPremadeFrequencies() should load the 3,708 word counts from Opticks, got %d", len(premade))
+	}
+	if suggestion := algorithms.FrequencyWeightedSuggestWord("thw", premade, algorithms.LevenshteinSimilarity); suggestion.Word != "the" {
+		t.Errorf("FrequencyWeightedSuggestWord('thw') with LoadPremadeFrequencies() expected the got %s", suggestion.Word)
+	}
+
+	for _, content := range []string{"the", "the 100 extra", "the many"} {
+		if _, err := LoadFrequenciesFromReader(strings.NewReader(content)); err == nil {
+			t.Errorf("LoadFrequenciesFromReader(%q) should return an error", content)
+		}
+	}
+}
+
+func BenchmarkSift3(b *testing.B) {
+	validWords := LoadPremadeWords()
+
+	b.Run("Sift3Similarity", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			algorithms.SuggestWord("almni", validWords, algorithms.Sift3Similarity)
+		}
+	})
+	b.Run("Sift4Similarity", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			algorithms.SuggestWord("almni", validWords, algorithms.Sift4Similarity)
+		}
+	})
+	b.Run("JaroSimilarity", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			algorithms.SuggestWord("almni", validWords, algorithms.JaroSimilarity)
+		}
+	})
+}
+
+func BenchmarkSSK(b *testing.B) {
+	validWords := LoadPremadeWords()
+
+	b.Run("SSKSimilarity", func(b *testing.B) {
+		algorithm := algorithms.NewSSKSimilarity(algorithms.DefaultSSKLength, algorithms.DefaultSSKLambda)
+		for n := 0; n < b.N; n++ {
+			algorithms.SuggestWord("almni", validWords, algorithm)
+		}
+	})
+	b.Run("SSKSimilarityMaxLength", func(b *testing.B) {
+		long := strings.Repeat("almni", algorithms.SSKMaxLength/5)
+		for n := 0; n < b.N; n++ {
+			algorithms.SSKSimilarity(long, long[1:], algorithms.DefaultSSKLength, algorithms.DefaultSSKLambda)
+		}
+	})
+}
+
+func BenchmarkMyersLevenshtein(b *testing.B) {
+	validWords := LoadPremadeWords()
+
+	b.Run("DynamicLevenshtein", func(b *testing.B) {
+		algorithm := func(inputString, targetString string) float32 {
+			return algorithms.CalculateSimilarity(inputString, targetString, algorithms.DynamicLevenshtein)
+		}
+		for n := 0; n < b.N; n++ {
+			algorithms.SuggestWord("almni", validWords, algorithm)
+		}
+	})
+	b.Run("SpaceEfficientLevenshteinDistance", func(b *testing.B) {
+		algorithm := func(inputString, targetString string) float32 {
+			return algorithms.CalculateSimilarity(inputString, targetString, algorithms.SpaceEfficientLevenshteinDistance)
+		}
+		for n := 0; n < b.N; n++ {
+			algorithms.SuggestWord("almni", validWords, algorithm)
+		}
+	})
+	b.Run("MyersLevenshtein", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			algorithms.SuggestWord("almni", validWords, algorithms.LevenshteinSimilarity)
+		}
+	})
+}
+
+func BenchmarkComparer(b *testing.B) {
+	validWords := LoadPremadeWords()
+
+	b.Run("SuggestWord", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			algorithms.SuggestWord("almni", validWords, algorithms.IndelSimilarity)
+		}
+	})
+	b.Run("Comparer", func(b *testing.B) {
+		b.ReportAllocs()
+		comparer := algorithms.NewComparer()
+		for n := 0; n < b.N; n++ {
+			algorithms.SuggestWord("almni", validWords, comparer.IndelSimilarity)
+		}
+	})
+}